@@ -0,0 +1,63 @@
+package access
+
+import (
+	"auction/auction"
+	"time"
+)
+
+type List string
+
+const (
+	ListBlock List = "block"
+	ListAllow List = "allow"
+)
+
+// Scope is the auction a list entry applies to, or every auction when it is global
+type Scope struct {
+	Global  bool       `json:"global"`
+	Auction auction.ID `json:"auction,omitempty"`
+}
+
+// GlobalScope applies to every auction
+func GlobalScope() Scope {
+	return Scope{Global: true}
+}
+
+// AuctionScope applies to a single auction
+func AuctionScope(id auction.ID) Scope {
+	return Scope{Auction: id}
+}
+
+// Entry puts a bidder on a block or allow list
+type Entry struct {
+	List    List           `json:"list"`
+	Scope   Scope          `json:"scope"`
+	Bidder  auction.Bidder `json:"bidder"`
+	Reason  string         `json:"reason,omitempty"`
+	AddedAt time.Time      `json:"added_at"`
+}
+
+// Lists decides who can bid in an auction. A bidder on the global block list or the block list of the auction is
+// always rejected. An auction that is invite only also rejects every bidder who is not on its allow list or the global
+// allow list.
+type Lists interface {
+	// Block adds the bidder to the block list of the scope
+	Block(scope Scope, bidder auction.Bidder, reason string) (Entry, error)
+	// Unblock removes the bidder from the block list of the scope
+	Unblock(scope Scope, bidder auction.Bidder) error
+	// Allow adds the bidder to the allow list of the scope
+	Allow(scope Scope, bidder auction.Bidder, reason string) (Entry, error)
+	// Disallow removes the bidder from the allow list of the scope
+	Disallow(scope Scope, bidder auction.Bidder) error
+	// SetInviteOnly makes an auction only accept bidders on an allow list, or accept every bidder who is not blocked
+	SetInviteOnly(id auction.ID, inviteOnly bool) error
+	// GetEntries returns the entries of a list in the scope, ordered by bidder
+	GetEntries(list List, scope Scope) ([]Entry, error)
+	// Check returns an AccessDeniedError if the bidder cannot bid in the auction
+	Check(id auction.ID, bidder auction.Bidder) error
+}
+
+// Config configures the lists. Now is the clock used to timestamp entries, and is time.Now if it is not set.
+type Config struct {
+	Now func() time.Time
+}
@@ -0,0 +1,111 @@
+package access
+
+import (
+	"auction/auction"
+	"errors"
+	"time"
+)
+
+type defaultLists struct {
+	config Config
+	store  Storer
+}
+
+func NewLists(config Config, store Storer) Lists {
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &defaultLists{
+		config: config,
+		store:  store,
+	}
+}
+
+func (l *defaultLists) Block(scope Scope, bidder auction.Bidder, reason string) (Entry, error) {
+	return l.add(ListBlock, scope, bidder, reason)
+}
+
+func (l *defaultLists) Unblock(scope Scope, bidder auction.Bidder) error {
+	return l.store.DeleteEntry(ListBlock, scope, bidder)
+}
+
+func (l *defaultLists) Allow(scope Scope, bidder auction.Bidder, reason string) (Entry, error) {
+	return l.add(ListAllow, scope, bidder, reason)
+}
+
+func (l *defaultLists) Disallow(scope Scope, bidder auction.Bidder) error {
+	return l.store.DeleteEntry(ListAllow, scope, bidder)
+}
+
+func (l *defaultLists) SetInviteOnly(id auction.ID, inviteOnly bool) error {
+	return l.store.SaveInviteOnly(id, inviteOnly)
+}
+
+func (l *defaultLists) GetEntries(list List, scope Scope) ([]Entry, error) {
+	return l.store.GetEntries(list, scope)
+}
+
+// add saves an entry, replacing the reason and time of an entry that already exists
+func (l *defaultLists) add(list List, scope Scope, bidder auction.Bidder, reason string) (Entry, error) {
+	if bidder == "" {
+		return Entry{}, &InvalidEntryError{message: "an entry must have a bidder"}
+	}
+	if scope.Global && scope.Auction != "" {
+		return Entry{}, &InvalidEntryError{message: "a global entry cannot be for a single auction"}
+	}
+	entry := Entry{
+		List:    list,
+		Scope:   scope,
+		Bidder:  bidder,
+		Reason:  reason,
+		AddedAt: l.config.Now(),
+	}
+	err := l.store.SaveEntry(entry)
+	if err != nil {
+		return Entry{}, errors.Join(errors.New("failed to save entry"), err)
+	}
+	return entry, nil
+}
+
+func (l *defaultLists) Check(id auction.ID, bidder auction.Bidder) error {
+	for _, scope := range []Scope{GlobalScope(), AuctionScope(id)} {
+		entry, found, err := l.find(ListBlock, scope, bidder)
+		if err != nil {
+			return err
+		}
+		if found {
+			reason := "blocked"
+			if entry.Reason != "" {
+				reason += ": " + entry.Reason
+			}
+			return &AccessDeniedError{bidder: bidder, auction: id, reason: reason}
+		}
+	}
+
+	inviteOnly, err := l.store.GetInviteOnly(id)
+	if err != nil {
+		return errors.Join(errors.New("failed to fetch invite only"), err)
+	}
+	if !inviteOnly {
+		return nil
+	}
+	for _, scope := range []Scope{GlobalScope(), AuctionScope(id)} {
+		_, found, err := l.find(ListAllow, scope, bidder)
+		if err != nil || found {
+			return err
+		}
+	}
+	return &AccessDeniedError{bidder: bidder, auction: id, reason: "the auction is invite only"}
+}
+
+// find returns the entry of the bidder on the list and whether there is one
+func (l *defaultLists) find(list List, scope Scope, bidder auction.Bidder) (Entry, bool, error) {
+	entry, err := l.store.GetEntry(list, scope, bidder)
+	var notFound *EntryNotFoundError
+	if errors.As(err, &notFound) {
+		return Entry{}, false, nil
+	} else if err != nil {
+		return Entry{}, false, errors.Join(errors.New("failed to fetch entry"), err)
+	}
+	return entry, true, nil
+}
@@ -0,0 +1,69 @@
+package access
+
+import (
+	"auction/auction"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	lists := NewLists(Config{}, NewMemoryStorage())
+	steps := []error{}
+	add := func(_ Entry, err error) {
+		steps = append(steps, err)
+	}
+	add(lists.Block(GlobalScope(), "Riley", "chargebacks"))
+	add(lists.Block(AuctionScope("auction-1"), "Pat", "competitor"))
+	add(lists.Allow(AuctionScope("auction-2"), "Sasha", ""))
+	add(lists.Allow(GlobalScope(), "Morgan", "trusted dealer"))
+	add(lists.Allow(AuctionScope("auction-2"), "Riley", ""))
+	steps = append(steps, lists.SetInviteOnly("auction-2", true))
+	for _, err := range steps {
+		if err != nil {
+			t.Fatalf("Failed to set up lists: %s", err.Error())
+		}
+	}
+
+	type testCase struct {
+		auction auction.ID
+		bidder  auction.Bidder
+		allowed bool
+	}
+	testCases := []testCase{
+		{auction: "auction-1", bidder: "Sasha", allowed: true},
+		{auction: "auction-1", bidder: "Pat", allowed: false},
+		{auction: "auction-1", bidder: "Riley", allowed: false},
+		{auction: "auction-3", bidder: "Pat", allowed: true},
+		{auction: "auction-2", bidder: "Sasha", allowed: true},
+		{auction: "auction-2", bidder: "Morgan", allowed: true},
+		{auction: "auction-2", bidder: "John", allowed: false},
+		// a block takes precedence over an invitation
+		{auction: "auction-2", bidder: "Riley", allowed: false},
+	}
+	for _, test := range testCases {
+		t.Run(string(test.auction)+" "+string(test.bidder), func(t *testing.T) {
+			err := lists.Check(test.auction, test.bidder)
+			if test.allowed && err != nil {
+				t.Fatalf("Expected bidder to be allowed, got %s", err.Error())
+			}
+			if _, ok := err.(*AccessDeniedError); !test.allowed && !ok {
+				t.Fatalf("Expected AccessDeniedError but got %#v", err)
+			}
+		})
+	}
+}
+
+func TestInvalidEntry(t *testing.T) {
+	lists := NewLists(Config{}, NewMemoryStorage())
+	_, err := lists.Block(GlobalScope(), "", "")
+	if _, ok := err.(*InvalidEntryError); !ok {
+		t.Fatalf("Expected InvalidEntryError but got %#v", err)
+	}
+	_, err = lists.Block(Scope{Global: true, Auction: "auction-1"}, "Pat", "")
+	if _, ok := err.(*InvalidEntryError); !ok {
+		t.Fatalf("Expected InvalidEntryError but got %#v", err)
+	}
+	err = lists.Unblock(GlobalScope(), "Pat")
+	if _, ok := err.(*EntryNotFoundError); !ok {
+		t.Fatalf("Expected EntryNotFoundError but got %#v", err)
+	}
+}
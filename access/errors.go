@@ -0,0 +1,33 @@
+package access
+
+import (
+	"auction/auction"
+	"fmt"
+)
+
+type InvalidEntryError struct {
+	message string
+}
+
+func (e *InvalidEntryError) Error() string {
+	return e.message
+}
+
+type EntryNotFoundError struct {
+	list   List
+	bidder auction.Bidder
+}
+
+func (e *EntryNotFoundError) Error() string {
+	return fmt.Sprintf("bidder %s is not on the %s list", e.bidder, e.list)
+}
+
+type AccessDeniedError struct {
+	bidder  auction.Bidder
+	auction auction.ID
+	reason  string
+}
+
+func (e *AccessDeniedError) Error() string {
+	return fmt.Sprintf("bidder %s cannot bid in auction %s: %s", e.bidder, e.auction, e.reason)
+}
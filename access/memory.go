@@ -0,0 +1,84 @@
+package access
+
+import (
+	"auction/auction"
+	"sort"
+	"sync"
+)
+
+// entryKey identifies the entry of a bidder on a list
+type entryKey struct {
+	list   List
+	scope  Scope
+	bidder auction.Bidder
+}
+
+type memoryStorage struct {
+	entries    map[entryKey]Entry
+	inviteOnly map[auction.ID]bool
+	mtx        *sync.Mutex
+}
+
+func NewMemoryStorage() Storer {
+	return &memoryStorage{
+		entries:    map[entryKey]Entry{},
+		inviteOnly: map[auction.ID]bool{},
+		mtx:        &sync.Mutex{},
+	}
+}
+
+func (m memoryStorage) SaveEntry(entry Entry) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.entries[entryKey{list: entry.List, scope: entry.Scope, bidder: entry.Bidder}] = entry
+	return nil
+}
+
+func (m memoryStorage) DeleteEntry(list List, scope Scope, bidder auction.Bidder) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	key := entryKey{list: list, scope: scope, bidder: bidder}
+	if _, ok := m.entries[key]; !ok {
+		return &EntryNotFoundError{list: list, bidder: bidder}
+	}
+	delete(m.entries, key)
+	return nil
+}
+
+func (m memoryStorage) GetEntry(list List, scope Scope, bidder auction.Bidder) (Entry, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	entry, ok := m.entries[entryKey{list: list, scope: scope, bidder: bidder}]
+	if !ok {
+		return Entry{}, &EntryNotFoundError{list: list, bidder: bidder}
+	}
+	return entry, nil
+}
+
+func (m memoryStorage) GetEntries(list List, scope Scope) ([]Entry, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	entries := []Entry{}
+	for key, entry := range m.entries {
+		if key.list == list && key.scope == scope {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Bidder < entries[j].Bidder
+	})
+	return entries, nil
+}
+
+func (m memoryStorage) SaveInviteOnly(id auction.ID, inviteOnly bool) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.inviteOnly[id] = inviteOnly
+	return nil
+}
+
+func (m memoryStorage) GetInviteOnly(id auction.ID) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.inviteOnly[id], nil
+}
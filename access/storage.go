@@ -0,0 +1,17 @@
+package access
+
+import "auction/auction"
+
+// Storer stores the entries of the block and allow lists and which auctions are invite only
+type Storer interface {
+	// SaveEntry creates or replaces the entry of a bidder on a list
+	SaveEntry(entry Entry) error
+	// DeleteEntry returns an EntryNotFoundError if the bidder is not on the list
+	DeleteEntry(list List, scope Scope, bidder auction.Bidder) error
+	GetEntry(list List, scope Scope, bidder auction.Bidder) (Entry, error)
+	// GetEntries returns the entries of a list in the scope, ordered by bidder
+	GetEntries(list List, scope Scope) ([]Entry, error)
+	SaveInviteOnly(id auction.ID, inviteOnly bool) error
+	// GetInviteOnly returns false for an auction that has never been made invite only
+	GetInviteOnly(id auction.ID) (bool, error)
+}
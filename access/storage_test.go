@@ -0,0 +1,99 @@
+package access
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type storageTests struct {
+	storeFn func() Storer
+	t       *testing.T
+}
+
+func (g *storageTests) Run() {
+	tests := map[string]func(t *testing.T, store Storer){
+		"Test Entries":         testEntries,
+		"Test Entry Not Found": testEntryNotFound,
+		"Test Invite Only":     testInviteOnly,
+	}
+	for name, test := range tests {
+		g.t.Run(name, func(t *testing.T) {
+			test(t, g.storeFn())
+		})
+	}
+}
+
+func testEntries(t *testing.T, store Storer) {
+	entries := []Entry{
+		{List: ListBlock, Scope: AuctionScope("auction-1"), Bidder: "Pat", Reason: "non-payer", AddedAt: time.Unix(100, 0)},
+		{List: ListBlock, Scope: AuctionScope("auction-1"), Bidder: "John", AddedAt: time.Unix(200, 0)},
+		{List: ListBlock, Scope: GlobalScope(), Bidder: "Riley", AddedAt: time.Unix(300, 0)},
+		{List: ListAllow, Scope: AuctionScope("auction-1"), Bidder: "Sasha", AddedAt: time.Unix(400, 0)},
+		{List: ListBlock, Scope: AuctionScope("auction-2"), Bidder: "Morgan", AddedAt: time.Unix(500, 0)},
+	}
+	for _, entry := range entries {
+		err := store.SaveEntry(entry)
+		if err != nil {
+			t.Fatalf("Failed to save entry: %s", err.Error())
+		}
+	}
+
+	saved, err := store.GetEntry(ListBlock, GlobalScope(), "Riley")
+	if err != nil {
+		t.Fatalf("Failed to get entry: %s", err.Error())
+	}
+	if !reflect.DeepEqual(entries[2], saved) {
+		t.Fatalf("Entries do not match. Expected:\n%#v\nGot:\n%#v", entries[2], saved)
+	}
+
+	expected := []Entry{entries[1], entries[0]}
+	blocked, err := store.GetEntries(ListBlock, AuctionScope("auction-1"))
+	if err != nil {
+		t.Fatalf("Failed to get entries: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, blocked) {
+		t.Fatalf("Entries do not match. Expected:\n%#v\nGot:\n%#v", expected, blocked)
+	}
+
+	err = store.DeleteEntry(ListBlock, AuctionScope("auction-1"), "Pat")
+	if err != nil {
+		t.Fatalf("Failed to delete entry: %s", err.Error())
+	}
+	_, err = store.GetEntry(ListBlock, AuctionScope("auction-1"), "Pat")
+	if _, ok := err.(*EntryNotFoundError); !ok {
+		t.Fatalf("Expected EntryNotFoundError but got %#v", err)
+	}
+}
+
+func testEntryNotFound(t *testing.T, store Storer) {
+	_, err := store.GetEntry(ListBlock, GlobalScope(), "Sasha")
+	if _, ok := err.(*EntryNotFoundError); !ok {
+		t.Fatalf("Expected EntryNotFoundError but got %#v", err)
+	}
+	err = store.DeleteEntry(ListAllow, GlobalScope(), "Sasha")
+	if _, ok := err.(*EntryNotFoundError); !ok {
+		t.Fatalf("Expected EntryNotFoundError but got %#v", err)
+	}
+}
+
+func testInviteOnly(t *testing.T, store Storer) {
+	inviteOnly, err := store.GetInviteOnly("auction-1")
+	if err != nil {
+		t.Fatalf("Failed to get invite only: %s", err.Error())
+	}
+	if inviteOnly {
+		t.Fatalf("Expected a new auction not to be invite only")
+	}
+	err = store.SaveInviteOnly("auction-1", true)
+	if err != nil {
+		t.Fatalf("Failed to save invite only: %s", err.Error())
+	}
+	inviteOnly, err = store.GetInviteOnly("auction-1")
+	if err != nil {
+		t.Fatalf("Failed to get invite only: %s", err.Error())
+	}
+	if !inviteOnly {
+		t.Fatalf("Expected the auction to be invite only")
+	}
+}
@@ -0,0 +1,89 @@
+package analysis
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/events"
+	"auction/registry"
+	"time"
+)
+
+type Signal string
+
+const (
+	// SignalSellerConcentration is a bidder who has bid in several auctions, all of them of a single seller
+	SignalSellerConcentration Signal = "seller_concentration"
+	// SignalPriceProbing is a losing bidder whose max bid stopped just below the max bid of the winner, so that the
+	// winner pays close to their limit
+	SignalPriceProbing Signal = "price_probing"
+	// SignalAccountCluster is a group of bidders in the same auction whose accounts were registered close together
+	SignalAccountCluster Signal = "account_cluster"
+)
+
+// weights is how much each signal adds to the risk score of a bidder, out of 100
+var weights = map[Signal]int{
+	SignalSellerConcentration: 40,
+	SignalPriceProbing:        35,
+	SignalAccountCluster:      25,
+}
+
+// Auction is the data of an auction that is analyzed. Bids are the stored bids, and Events is the history of the
+// auction from its event log, which also holds the bids that were retracted. Events may be empty.
+type Auction struct {
+	ID     auction.ID
+	Seller string
+	Bids   auction.BidMap
+	Events []events.Event
+}
+
+// Finding is a signal raised against one or more bidders of an auction
+type Finding struct {
+	Signal      Signal           `json:"signal"`
+	Bidders     []auction.Bidder `json:"bidders"`
+	Description string           `json:"description"`
+}
+
+// BidderRisk is the risk score of a bidder in an auction, from 0 to 100, and the signals that raised it
+type BidderRisk struct {
+	Bidder  auction.Bidder `json:"bidder"`
+	Score   int            `json:"score"`
+	Signals []Signal       `json:"signals"`
+}
+
+// Report is the analysis of a single auction. Bidders holds every bidder with a score above zero, highest first, and
+// RiskScore is the highest score of any bidder.
+type Report struct {
+	Auction   auction.ID   `json:"auction"`
+	Seller    string       `json:"seller"`
+	RiskScore int          `json:"risk_score"`
+	Bidders   []BidderRisk `json:"bidders"`
+	Findings  []Finding    `json:"findings"`
+}
+
+// Analyzer looks for shill bidding and collusion across a set of auctions
+type Analyzer interface {
+	// Analyze returns a report for each auction, in the order they were given. Profiles are the registered bidders,
+	// which are needed to find accounts that were created together.
+	Analyze(auctions []Auction, profiles []registry.Profile) ([]Report, error)
+}
+
+// Config configures the thresholds of the signals. A zero value uses the default of the threshold.
+type Config struct {
+	// MinAuctions is how many auctions a bidder must have bid in before bidding with a single seller is suspicious.
+	// The default is 3.
+	MinAuctions int
+	// ProbingMargin is the percentage of the winner's max bid that a losing max bid must be within to be price
+	// probing. The default is 5%.
+	ProbingMargin currency.Rate
+	// ClusterWindow is how close together accounts must have been registered to be a cluster. The default is a day.
+	ClusterWindow time.Duration
+	// MinClusterSize is the smallest number of accounts that form a cluster. The default is 3.
+	MinClusterSize int
+}
+
+var (
+	defaultMinAuctions    = 3
+	defaultProbingMargin  = currency.NewRate(5, 0)
+	defaultClusterWindow  = 24 * time.Hour
+	defaultMinClusterSize = 3
+)
@@ -0,0 +1,238 @@
+package analysis
+
+import (
+	"auction/auction"
+	"auction/bid_manager"
+	"auction/currency"
+	"auction/events"
+	"auction/registry"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type defaultAnalyzer struct {
+	config Config
+}
+
+func NewAnalyzer(config Config) Analyzer {
+	if config.MinAuctions == 0 {
+		config.MinAuctions = defaultMinAuctions
+	}
+	if config.ProbingMargin.Sign() == 0 {
+		config.ProbingMargin = defaultProbingMargin
+	}
+	if config.ClusterWindow == 0 {
+		config.ClusterWindow = defaultClusterWindow
+	}
+	if config.MinClusterSize == 0 {
+		config.MinClusterSize = defaultMinClusterSize
+	}
+	return &defaultAnalyzer{config: config}
+}
+
+func (a *defaultAnalyzer) Analyze(auctions []Auction, profiles []registry.Profile) ([]Report, error) {
+	concentrated := a.sellerConcentration(auctions)
+	registered := map[auction.Bidder]registry.Profile{}
+	for _, profile := range profiles {
+		registered[profile.ID] = profile
+	}
+
+	reports := make([]Report, len(auctions))
+	for i, lot := range auctions {
+		findings := []Finding{}
+		for _, bidder := range sortedBidders(bidsOf(lot)) {
+			if concentrated[bidder] {
+				findings = append(findings, Finding{
+					Signal:      SignalSellerConcentration,
+					Bidders:     []auction.Bidder{bidder},
+					Description: fmt.Sprintf("%s has only bid in auctions of %s", bidder, lot.Seller),
+				})
+			}
+		}
+		probing, err := a.priceProbing(lot)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to analyze auction %s", lot.ID), err)
+		}
+		findings = append(findings, probing...)
+		findings = append(findings, a.accountClusters(lot, registered)...)
+		reports[i] = newReport(lot, findings)
+	}
+	return reports, nil
+}
+
+// sellerConcentration returns the bidders who have bid in at least MinAuctions auctions, all of the same seller
+func (a *defaultAnalyzer) sellerConcentration(auctions []Auction) map[auction.Bidder]bool {
+	sellers := map[auction.Bidder]map[string]bool{}
+	counts := map[auction.Bidder]int{}
+	for _, lot := range auctions {
+		for bidder := range bidsOf(lot) {
+			if sellers[bidder] == nil {
+				sellers[bidder] = map[string]bool{}
+			}
+			sellers[bidder][lot.Seller] = true
+			counts[bidder]++
+		}
+	}
+	concentrated := map[auction.Bidder]bool{}
+	for bidder, count := range counts {
+		if count >= a.config.MinAuctions && len(sellers[bidder]) == 1 {
+			concentrated[bidder] = true
+		}
+	}
+	return concentrated
+}
+
+// priceProbing finds the losing bidders, including those who retracted, whose max bid was just below the max bid of
+// the winner
+func (a *defaultAnalyzer) priceProbing(lot Auction) ([]Finding, error) {
+	if len(lot.Bids) == 0 {
+		return nil, nil
+	}
+	winner, err := bid_manager.CalculateWinner(lot.Bids)
+	if err != nil {
+		return nil, err
+	}
+	limit := lot.Bids[winner.Bidder].MaxBid
+	margin, err := limit.Percent(a.config.ProbingMargin, currency.RoundDown)
+	if err != nil {
+		return nil, err
+	}
+	floor, err := limit.Sub(margin)
+	if err != nil {
+		return nil, err
+	}
+
+	retracted := retractedBidders(lot.Events)
+	findings := []Finding{}
+	bids := bidsOf(lot)
+	for _, bidder := range sortedBidders(bids) {
+		bid := bids[bidder]
+		if bidder == winner.Bidder || !bid.MaxBid.Less(limit) || bid.MaxBid.Less(floor) {
+			continue
+		}
+		description := fmt.Sprintf("%s stopped at a max bid of %s, within %s%% of the winning max bid of %s", bidder, bid.MaxBid, a.config.ProbingMargin, limit)
+		if retracted[bidder] {
+			description += " and then retracted"
+		}
+		findings = append(findings, Finding{Signal: SignalPriceProbing, Bidders: []auction.Bidder{bidder}, Description: description})
+	}
+	return findings, nil
+}
+
+// accountClusters finds the groups of at least MinClusterSize bidders in the auction whose accounts were registered
+// within ClusterWindow of each other. Bidders without a profile are left out.
+func (a *defaultAnalyzer) accountClusters(lot Auction, registered map[auction.Bidder]registry.Profile) []Finding {
+	profiles := []registry.Profile{}
+	for bidder := range bidsOf(lot) {
+		if profile, ok := registered[bidder]; ok {
+			profiles = append(profiles, profile)
+		}
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		if profiles[i].RegisteredAt.Equal(profiles[j].RegisteredAt) {
+			return profiles[i].ID < profiles[j].ID
+		}
+		return profiles[i].RegisteredAt.Before(profiles[j].RegisteredAt)
+	})
+
+	// each cluster is grown from its first account for as long as the next account is within the window, and the
+	// next cluster starts after it, so that no bidder is in two clusters
+	findings := []Finding{}
+	for start := 0; start < len(profiles); {
+		end := start + 1
+		for end < len(profiles) && profiles[end].RegisteredAt.Sub(profiles[start].RegisteredAt) <= a.config.ClusterWindow {
+			end++
+		}
+		if end-start >= a.config.MinClusterSize {
+			bidders := []auction.Bidder{}
+			names := []string{}
+			for _, profile := range profiles[start:end] {
+				bidders = append(bidders, profile.ID)
+				names = append(names, string(profile.ID))
+			}
+			findings = append(findings, Finding{
+				Signal:      SignalAccountCluster,
+				Bidders:     bidders,
+				Description: fmt.Sprintf("the accounts of %s were registered within %s of each other", strings.Join(names, ", "), a.config.ClusterWindow),
+			})
+		}
+		start = end
+	}
+	return findings
+}
+
+// newReport scores the bidders of the auction from the findings
+func newReport(lot Auction, findings []Finding) Report {
+	signals := map[auction.Bidder]map[Signal]bool{}
+	for _, finding := range findings {
+		for _, bidder := range finding.Bidders {
+			if signals[bidder] == nil {
+				signals[bidder] = map[Signal]bool{}
+			}
+			signals[bidder][finding.Signal] = true
+		}
+	}
+
+	report := Report{Auction: lot.ID, Seller: lot.Seller, Bidders: []BidderRisk{}, Findings: findings}
+	for bidder, raised := range signals {
+		risk := BidderRisk{Bidder: bidder, Signals: []Signal{}}
+		for _, signal := range []Signal{SignalSellerConcentration, SignalPriceProbing, SignalAccountCluster} {
+			if raised[signal] {
+				risk.Score += weights[signal]
+				risk.Signals = append(risk.Signals, signal)
+			}
+		}
+		report.Bidders = append(report.Bidders, risk)
+		if risk.Score > report.RiskScore {
+			report.RiskScore = risk.Score
+		}
+	}
+	sort.Slice(report.Bidders, func(i, j int) bool {
+		if report.Bidders[i].Score == report.Bidders[j].Score {
+			return report.Bidders[i].Bidder < report.Bidders[j].Bidder
+		}
+		return report.Bidders[i].Score > report.Bidders[j].Score
+	})
+	return report
+}
+
+// bidsOf returns the last version of every bid placed in the auction. Bids that were retracted are only in the
+// events, so they are added to the stored bids from there.
+func bidsOf(lot Auction) auction.BidMap {
+	bids := auction.BidMap{}
+	for _, event := range lot.Events {
+		switch e := event.(type) {
+		case events.BidPlaced:
+			bids[e.Bid.Bidder] = e.Bid
+		case events.BidAmended:
+			bids[e.Bid.Bidder] = e.Bid
+		}
+	}
+	for bidder, bid := range lot.Bids {
+		bids[bidder] = bid
+	}
+	return bids
+}
+
+func retractedBidders(history []events.Event) map[auction.Bidder]bool {
+	retracted := map[auction.Bidder]bool{}
+	for _, event := range history {
+		if e, ok := event.(events.BidRetracted); ok {
+			retracted[e.Bidder] = true
+		}
+	}
+	return retracted
+}
+
+func sortedBidders(bids auction.BidMap) []auction.Bidder {
+	bidders := make([]auction.Bidder, 0, len(bids))
+	for bidder := range bids {
+		bidders = append(bidders, bidder)
+	}
+	sort.Slice(bidders, func(i, j int) bool {
+		return bidders[i] < bidders[j]
+	})
+	return bidders
+}
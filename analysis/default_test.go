@@ -0,0 +1,126 @@
+package analysis
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/events"
+	"auction/id_generator"
+	"auction/registry"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func usd(major int64) currency.Amount {
+	return currency.MustNew(major, 0, currency.USD)
+}
+
+func bid(bidder auction.Bidder, start, max, increment int64, id int) auction.Bid {
+	return auction.Bid{Bidder: bidder, StartingBid: usd(start), MaxBid: usd(max), Increment: usd(increment), ID: id_generator.EventID(id)}
+}
+
+/*
+Sock only bids on the lots of acme, and stops just below Sasha's max bid in a-1. Drew did the same in a-1 and then
+retracted. Sock, Drew and Pat registered within two hours of each other, while Sasha registered a month earlier.
+*/
+func mockAuctions() ([]Auction, []registry.Profile) {
+	drew := bid("Drew", 40, 99, 2, 4)
+	auctions := []Auction{
+		{
+			ID:     "a-1",
+			Seller: "acme",
+			Bids: auction.BidMap{
+				"Sasha": bid("Sasha", 50, 100, 5, 1),
+				"Sock":  bid("Sock", 40, 97, 1, 2),
+				"Pat":   bid("Pat", 10, 60, 5, 3),
+			},
+			Events: []events.Event{
+				events.BidPlaced{Header: events.Header{ID: 4}, Bid: drew},
+				events.BidRetracted{Header: events.Header{ID: 5}, Bidder: "Drew"},
+			},
+		},
+		{ID: "a-2", Seller: "acme", Bids: auction.BidMap{"Sock": bid("Sock", 10, 20, 1, 6), "Sasha": bid("Sasha", 10, 50, 1, 7)}},
+		{ID: "a-3", Seller: "acme", Bids: auction.BidMap{"Sock": bid("Sock", 10, 20, 1, 8)}},
+		{ID: "b-1", Seller: "bolt", Bids: auction.BidMap{"Sasha": bid("Sasha", 10, 20, 1, 9)}},
+	}
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	profiles := []registry.Profile{
+		{ID: "Sasha", RegisteredAt: start.AddDate(0, -1, 0)},
+		{ID: "Sock", RegisteredAt: start},
+		{ID: "Drew", RegisteredAt: start.Add(time.Hour)},
+		{ID: "Pat", RegisteredAt: start.Add(2 * time.Hour)},
+	}
+	return auctions, profiles
+}
+
+func TestAnalyze(t *testing.T) {
+	auctions, profiles := mockAuctions()
+	reports, err := NewAnalyzer(Config{}).Analyze(auctions, profiles)
+	if err != nil {
+		t.Fatalf("Failed to analyze auctions: %s", err.Error())
+	}
+	if len(reports) != len(auctions) {
+		t.Fatalf("Expected %d reports, got %d", len(auctions), len(reports))
+	}
+
+	type testCase struct {
+		auction   auction.ID
+		riskScore int
+		bidders   []BidderRisk
+	}
+	testCases := []testCase{
+		{
+			auction:   "a-1",
+			riskScore: 100,
+			bidders: []BidderRisk{
+				{Bidder: "Sock", Score: 100, Signals: []Signal{SignalSellerConcentration, SignalPriceProbing, SignalAccountCluster}},
+				{Bidder: "Drew", Score: 60, Signals: []Signal{SignalPriceProbing, SignalAccountCluster}},
+				{Bidder: "Pat", Score: 25, Signals: []Signal{SignalAccountCluster}},
+			},
+		},
+		{
+			auction:   "a-3",
+			riskScore: 40,
+			bidders:   []BidderRisk{{Bidder: "Sock", Score: 40, Signals: []Signal{SignalSellerConcentration}}},
+		},
+		{
+			auction:   "b-1",
+			riskScore: 0,
+			bidders:   []BidderRisk{},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(string(test.auction), func(t *testing.T) {
+			var report Report
+			for _, r := range reports {
+				if r.Auction == test.auction {
+					report = r
+				}
+			}
+			if report.RiskScore != test.riskScore {
+				t.Fatalf("Expected a risk score of %d, got %d", test.riskScore, report.RiskScore)
+			}
+			if !reflect.DeepEqual(test.bidders, report.Bidders) {
+				t.Fatalf("Expected %#v, got %#v", test.bidders, report.Bidders)
+			}
+		})
+	}
+}
+
+func TestAnalyze_Thresholds(t *testing.T) {
+	auctions, profiles := mockAuctions()
+	config := Config{MinAuctions: 4, ProbingMargin: currency.NewRate(1, 0), ClusterWindow: time.Hour}
+	reports, err := NewAnalyzer(config).Analyze(auctions, profiles)
+	if err != nil {
+		t.Fatalf("Failed to analyze auctions: %s", err.Error())
+	}
+	// only Drew is within 1% of Sasha's max bid, and only Sock and Drew registered within an hour, which is too few
+	// for a cluster
+	expected := []BidderRisk{{Bidder: "Drew", Score: 35, Signals: []Signal{SignalPriceProbing}}}
+	if !reflect.DeepEqual(expected, reports[0].Bidders) {
+		t.Fatalf("Expected %#v, got %#v", expected, reports[0].Bidders)
+	}
+	if len(reports[0].Findings) != 1 || reports[0].Findings[0].Description != "Drew stopped at a max bid of $99.00, within 1% of the winning max bid of $100.00 and then retracted" {
+		t.Fatalf("Expected a single price probing finding, got %#v", reports[0].Findings)
+	}
+}
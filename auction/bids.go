@@ -3,20 +3,95 @@ package auction
 import (
 	"auction/currency"
 	"auction/id_generator"
+	"time"
 )
 
 type Bidder string
-type BidMap map[Bidder]Bid
-
-type Bid struct {
-	Bidder      Bidder
-	StartingBid currency.Amount
-	MaxBid      currency.Amount
-	Increment   currency.Amount
-	ID          id_generator.EventID
+
+// BidMap holds the bid of each bidder in an auction
+type BidMap = BidMapOf[currency.Amount]
+
+// BidMapOf holds the bid of each bidder in an auction whose amounts are represented by M
+type BidMapOf[M currency.Money[M]] map[Bidder]BidOf[M]
+
+// Bid is encoded in JSON as
+//
+//	{
+//	  "bidder": "Sasha",
+//	  "starting_bid": "USD 50.00",
+//	  "max_bid": "USD 80.00",
+//	  "increment": "USD 3.00",
+//	  "id": 1,
+//	  "exchange_rate": {"from": "EUR", "to": "USD", "rate": "1.0842"}
+//	}
+//
+// where amounts are in the text form of currency.Amount and exchange_rate is left out when the bid was not converted.
+type Bid = BidOf[currency.Amount]
+
+// BidOf is a bid whose amounts are represented by M, such as a currency.BigAmount for an auction in ETH. It has the
+// same JSON schema as Bid.
+type BidOf[M currency.Money[M]] struct {
+	Bidder      Bidder               `json:"bidder"`
+	StartingBid M                    `json:"starting_bid"`
+	MaxBid      M                    `json:"max_bid"`
+	Increment   M                    `json:"increment"`
+	ID          id_generator.EventID `json:"id"`
+	// ExchangeRate is the rate used to convert the amounts of a bid that was placed in another currency into the
+	// currency of the auction. It is nil when the amounts were given in the currency of the auction.
+	ExchangeRate *currency.ExchangeRate `json:"exchange_rate,omitempty"`
 }
 
-type WinningBid struct {
-	Bidder Bidder
-	Amount currency.Amount
+// WinningBid is encoded in JSON as {"bidder": "Sasha", "amount": "USD 86.00"}
+type WinningBid = WinningBidOf[currency.Amount]
+
+// WinningBidOf is a winning bid whose amount is represented by M. It has the same JSON schema as WinningBid.
+type WinningBidOf[M currency.Money[M]] struct {
+	Bidder Bidder `json:"bidder"`
+	Amount M      `json:"amount"`
+}
+
+// Result is encoded in JSON as
+//
+//	{"bidder": "John", "amount": "USD 82.00", "rank": 2, "exhausted": true, "second_chance_price": "USD 82.00"}
+type Result = ResultOf[currency.Amount]
+
+// ResultOf is the final standing of a bidder in an auction whose amounts are represented by M. It has the same JSON
+// schema as Result.
+type ResultOf[M currency.Money[M]] struct {
+	Bidder Bidder `json:"bidder"`
+	// Amount is the bidders bid when the bidding finished
+	Amount M `json:"amount"`
+	// Rank is the order in which the lot is offered to the bidders, starting with the winner at 1
+	Rank int `json:"rank"`
+	// Exhausted is true when the bidder could not raise their bid again without exceeding their max bid
+	Exhausted bool `json:"exhausted"`
+	// SecondChancePrice is the price the bidder would pay if every bidder ranked above them defaulted. For the winner
+	// it is the amount of the winning bid.
+	SecondChancePrice M `json:"second_chance_price"`
 }
+
+// SecondChanceOffer is encoded in JSON as {"bidder": "John", "amount": "USD 82.00", "expires_at": "2024-06-03T12:00:00Z"}
+type SecondChanceOffer = SecondChanceOfferOf[currency.Amount]
+
+// SecondChanceOfferOf offers the lot to an underbidder after the winner defaulted, at the price the underbidder would
+// have won it at without the bidders ahead of them. It has the same JSON schema as SecondChanceOffer.
+type SecondChanceOfferOf[M currency.Money[M]] struct {
+	Bidder    Bidder    `json:"bidder"`
+	Amount    M         `json:"amount"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// BuyItNow is encoded in JSON as {"price": "USD 120.00", "threshold": "50"}
+type BuyItNow = BuyItNowOf[currency.Amount]
+
+// BuyItNowOf is a fixed price the lot can be bought at, which ends the auction immediately. It has the same JSON schema
+// as BuyItNow.
+type BuyItNowOf[M currency.Money[M]] struct {
+	Price M `json:"price"`
+	// Threshold is the percentage of the price that the leading bid must reach for the lot to no longer be available
+	// to buy
+	Threshold currency.Rate `json:"threshold"`
+}
+
+// ID identifies an auction among the auctions that share services, such as a bidder registry
+type ID string
@@ -0,0 +1,131 @@
+package auction
+
+import (
+	"auction/currency"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestBid_JSON(t *testing.T) {
+	type testCase struct {
+		name     string
+		bid      Bid
+		expected string
+	}
+	testCases := []testCase{
+		{
+			name: "Bid",
+			bid: Bid{
+				Bidder:      "Sasha",
+				StartingBid: currency.MustNew(50, 0, currency.USD),
+				MaxBid:      currency.MustNew(80, 0, currency.USD),
+				Increment:   currency.MustNew(3, 0, currency.USD),
+				ID:          1,
+			},
+			expected: `{"bidder":"Sasha","starting_bid":"USD 50.00","max_bid":"USD 80.00","increment":"USD 3.00","id":1}`,
+		},
+		{
+			name: "Converted Bid",
+			bid: Bid{
+				Bidder:       "Pat",
+				StartingBid:  currency.MustNew(54, 21, currency.USD),
+				MaxBid:       currency.MustNew(86, 73, currency.USD),
+				Increment:    currency.MustNew(3, 25, currency.USD),
+				ID:           2,
+				ExchangeRate: &currency.ExchangeRate{From: currency.EUR, To: currency.USD, Rate: currency.NewRate(10842, 4)},
+			},
+			expected: `{"bidder":"Pat","starting_bid":"USD 54.21","max_bid":"USD 86.73","increment":"USD 3.25","id":2,"exchange_rate":{"from":"EUR","to":"USD","rate":"1.0842"}}`,
+		},
+		{
+			name: "Yen Bid",
+			bid: Bid{
+				Bidder:      "Riley",
+				StartingBid: currency.MustNew(5000, 0, currency.JPY),
+				MaxBid:      currency.MustNew(8000, 0, currency.JPY),
+				Increment:   currency.MustNew(300, 0, currency.JPY),
+				ID:          3,
+			},
+			expected: `{"bidder":"Riley","starting_bid":"JPY 5000","max_bid":"JPY 8000","increment":"JPY 300","id":3}`,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.bid)
+			if err != nil {
+				t.Fatalf("Failed to marshal bid: %s", err.Error())
+			}
+			if string(data) != test.expected {
+				t.Fatalf("Expected %s, got %s", test.expected, data)
+			}
+
+			var decoded Bid
+			err = json.Unmarshal(data, &decoded)
+			if err != nil {
+				t.Fatalf("Failed to unmarshal bid: %s", err.Error())
+			}
+			if !reflect.DeepEqual(test.bid, decoded) {
+				t.Fatalf("Expected %#v, got %#v", test.bid, decoded)
+			}
+		})
+	}
+}
+
+func TestWinningBid_JSON(t *testing.T) {
+	winner := WinningBid{Bidder: "Sasha", Amount: currency.MustNew(86, 0, currency.USD)}
+	data, err := json.Marshal(winner)
+	if err != nil {
+		t.Fatalf("Failed to marshal winning bid: %s", err.Error())
+	}
+	expected := `{"bidder":"Sasha","amount":"USD 86.00"}`
+	if string(data) != expected {
+		t.Fatalf("Expected %s, got %s", expected, data)
+	}
+
+	var decoded WinningBid
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal winning bid: %s", err.Error())
+	}
+	if decoded != winner {
+		t.Fatalf("Expected %#v, got %#v", winner, decoded)
+	}
+}
+
+func TestBidMap_JSON(t *testing.T) {
+	bids := BidMap{
+		"Sasha": {Bidder: "Sasha", StartingBid: currency.MustNew(50, 0, currency.USD), MaxBid: currency.MustNew(80, 0, currency.USD), Increment: currency.MustNew(3, 0, currency.USD), ID: 1},
+	}
+	data, err := json.Marshal(bids)
+	if err != nil {
+		t.Fatalf("Failed to marshal bids: %s", err.Error())
+	}
+	var decoded BidMap
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal bids: %s", err.Error())
+	}
+	if !reflect.DeepEqual(bids, decoded) {
+		t.Fatalf("Expected %#v, got %#v", bids, decoded)
+	}
+}
+
+func TestBigBid_JSON(t *testing.T) {
+	data := `{"bidder":"Sasha","starting_bid":"ETH 10.000000000000000000","max_bid":"ETH 25.000000000000000001","increment":"ETH 1.000000000000000000","id":1}`
+	var bid BidOf[currency.BigAmount]
+	err := json.Unmarshal([]byte(data), &bid)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal bid: %s", err.Error())
+	}
+	if bid.MaxBid.MinorUnits().String() != "25000000000000000001" {
+		t.Fatalf("Expected max bid of 25000000000000000001 wei, got %s", bid.MaxBid.MinorUnits())
+	}
+
+	encoded, err := json.Marshal(bid)
+	if err != nil {
+		t.Fatalf("Failed to marshal bid: %s", err.Error())
+	}
+	if string(encoded) != data {
+		t.Fatalf("Expected %s, got %s", data, encoded)
+	}
+}
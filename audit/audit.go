@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"auction/events"
+	"errors"
+	"sync"
+)
+
+type Log interface {
+	// Record appends an entry for the event, chained to the last entry. Events that are not bid operations, such as
+	// a change of leader, are ignored.
+	Record(event events.Event) error
+	// RecordFailure appends an entry for an event that was recorded but could not be committed, so that the log does not
+	// show the change as made. The entry has the event's ID and the kind KindCommitFailed, with the cause as its detail.
+	RecordFailure(event events.Event, cause error) error
+	// Verify reads the whole log and checks that no entry has been changed, removed or reordered
+	Verify() error
+	// Head returns the sequence and hash of the last entry, to be kept outside the log and later passed to VerifyHead
+	Head() (Head, error)
+}
+
+type chainedLog struct {
+	store    EntryStorer
+	mtx      *sync.Mutex
+	loaded   bool
+	sequence uint64
+	lastHash string
+}
+
+func NewLog(store EntryStorer) Log {
+	return &chainedLog{
+		store: store,
+		mtx:   &sync.Mutex{},
+	}
+}
+
+// Record loads the end of the chain from the store the first time it is called, so that a log created over an
+// existing store continues its chain.
+func (l *chainedLog) Record(event events.Event) error {
+	details, ok := newDetails(event)
+	if !ok {
+		return nil
+	}
+	return l.append(event, event.Kind(), details)
+}
+
+func (l *chainedLog) RecordFailure(event events.Event, cause error) error {
+	details, ok := newDetails(event)
+	if !ok {
+		return nil
+	}
+	return l.append(event, KindCommitFailed, Details{Bidder: details.Bidder, Detail: cause.Error()})
+}
+
+// append chains an entry of the kind for the event to the last entry and adds it to the store
+func (l *chainedLog) append(event events.Event, kind events.Kind, details Details) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if !l.loaded {
+		entries, err := l.store.GetAll()
+		if err != nil {
+			return errors.Join(errors.New("failed to read audit log"), err)
+		}
+		if len(entries) > 0 {
+			last := entries[len(entries)-1]
+			l.sequence, l.lastHash = last.Sequence, last.Hash
+		}
+		l.loaded = true
+	}
+
+	entry := Entry{
+		Sequence: l.sequence + 1,
+		EventID:  event.EventID(),
+		Auction:  event.AuctionID(),
+		Kind:     kind,
+		Time:     event.OccurredAt().UTC(),
+		Details:  details,
+		PrevHash: l.lastHash,
+	}
+	hash, err := computeHash(entry)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	err = l.store.Append(entry)
+	if err != nil {
+		return errors.Join(errors.New("failed to append audit entry"), err)
+	}
+	l.sequence, l.lastHash = entry.Sequence, entry.Hash
+	return nil
+}
+
+func (l *chainedLog) Verify() error {
+	entries, err := l.store.GetAll()
+	if err != nil {
+		return errors.Join(errors.New("failed to read audit log"), err)
+	}
+	return VerifyChain(entries)
+}
+
+func (l *chainedLog) Head() (Head, error) {
+	entries, err := l.store.GetAll()
+	if err != nil {
+		return Head{}, errors.Join(errors.New("failed to read audit log"), err)
+	}
+	return headOf(entries), nil
+}
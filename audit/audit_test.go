@@ -0,0 +1,212 @@
+package audit
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/events"
+	"errors"
+	"testing"
+	"time"
+)
+
+func mockEvents() []events.Event {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	bid := auction.Bid{
+		Bidder:      auction.Bidder("Sasha"),
+		StartingBid: currency.MustNew(50, 0, currency.USD),
+		MaxBid:      currency.MustNew(80, 0, currency.USD),
+		Increment:   currency.MustNew(3, 0, currency.USD),
+		ID:          1,
+	}
+	amended := bid
+	amended.MaxBid = currency.MustNew(90, 0, currency.USD)
+	return []events.Event{
+		events.BidPlaced{Header: events.Header{ID: 1, Time: at}, Bid: bid},
+		events.LeaderChanged{Header: events.Header{ID: 2, Time: at}},
+		events.BidRejected{Header: events.Header{ID: 3, Time: at}, Bidder: "Pat", Reason: "bid increment $0.00 cannot be less than 1 cent"},
+		events.BidAmended{Header: events.Header{ID: 4, Time: at}, Bid: amended},
+		events.WinnerDetermined{Header: events.Header{ID: 5, Time: at}, Winner: auction.WinningBid{Bidder: "Sasha", Amount: bid.StartingBid}},
+	}
+}
+
+func newRecordedLog(t *testing.T) (Log, EntryStorer) {
+	store := NewMemoryEntryStorage()
+	log := NewLog(store)
+	for _, event := range mockEvents() {
+		err := log.Record(event)
+		if err != nil {
+			t.Fatalf("Failed to record event: %s", err.Error())
+		}
+	}
+	return log, store
+}
+
+func TestRecord(t *testing.T) {
+	log, store := newRecordedLog(t)
+
+	entries, err := store.GetAll()
+	if err != nil {
+		t.Fatalf("Failed to get entries: %s", err.Error())
+	}
+	expectedKinds := []events.Kind{events.KindBidPlaced, events.KindBidRejected, events.KindBidAmended, events.KindWinnerDetermined}
+	if len(entries) != len(expectedKinds) {
+		t.Fatalf("Expected %d entries, got %d", len(expectedKinds), len(entries))
+	}
+	for i, kind := range expectedKinds {
+		if entries[i].Kind != kind {
+			t.Fatalf("Expected entry %d to be %s, got %s", i+1, kind, entries[i].Kind)
+		}
+	}
+	if entries[1].Details.Reason == "" || entries[2].Details.MaxBid != "$90.00" {
+		t.Fatalf("Expected entries to contain the full details, got %#v", entries)
+	}
+
+	err = log.Verify()
+	if err != nil {
+		t.Fatalf("Expected untouched log to verify, got: %s", err.Error())
+	}
+}
+
+func TestContinueChain(t *testing.T) {
+	_, store := newRecordedLog(t)
+
+	log := NewLog(store)
+	err := log.Record(events.AuctionClosed{Header: events.Header{ID: 6}})
+	if err != nil {
+		t.Fatalf("Failed to record event: %s", err.Error())
+	}
+	err = log.Verify()
+	if err != nil {
+		t.Fatalf("Expected continued log to verify, got: %s", err.Error())
+	}
+}
+
+func TestRecordFailure(t *testing.T) {
+	log, store := newRecordedLog(t)
+
+	failed := events.BidRetracted{Header: events.Header{ID: 6, Auction: "auction-1"}, Bidder: "Sasha"}
+	err := errors.Join(log.Record(failed), log.RecordFailure(failed, errors.New("disk full")))
+	if err != nil {
+		t.Fatalf("Failed to record event: %s", err.Error())
+	}
+	entries, err := store.GetAll()
+	if err != nil {
+		t.Fatalf("Failed to get entries: %s", err.Error())
+	}
+	last := entries[len(entries)-1]
+	expected := Details{Bidder: "Sasha", Detail: "disk full"}
+	if last.Kind != KindCommitFailed || last.EventID != 6 || last.Auction != "auction-1" || last.Details != expected {
+		t.Fatalf("Expected an entry for the failure of the retraction, got %#v", last)
+	}
+	err = log.Verify()
+	if err != nil {
+		t.Fatalf("Expected log with a failure to verify, got: %s", err.Error())
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	type testCase struct {
+		name   string
+		tamper func(entries []Entry) []Entry
+	}
+	testCases := []testCase{
+		{"Changed Max Bid", func(entries []Entry) []Entry {
+			entries[2].Details.MaxBid = "$85.00"
+			return entries
+		}},
+		{"Changed Time", func(entries []Entry) []Entry {
+			entries[0].Time = entries[0].Time.Add(time.Hour)
+			return entries
+		}},
+		{"Rehashed Entry", func(entries []Entry) []Entry {
+			entries[1].Details.Reason = "changed"
+			entries[1].Hash, _ = computeHash(entries[1])
+			return entries
+		}},
+		{"Removed Entry", func(entries []Entry) []Entry {
+			return append(entries[:1], entries[2:]...)
+		}},
+		{"Reordered Entries", func(entries []Entry) []Entry {
+			entries[1], entries[2] = entries[2], entries[1]
+			return entries
+		}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, store := newRecordedLog(t)
+			entries, err := store.GetAll()
+			if err != nil {
+				t.Fatalf("Failed to get entries: %s", err.Error())
+			}
+
+			err = VerifyChain(test.tamper(entries))
+			var tampered *TamperedEntryError
+			if !errors.As(err, &tampered) {
+				t.Fatalf("Expected a tampered entry error and received a different error instead: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyHead(t *testing.T) {
+	log, store := newRecordedLog(t)
+	head, err := log.Head()
+	if err != nil {
+		t.Fatalf("Failed to get head: %s", err.Error())
+	}
+	if head.Sequence != 4 {
+		t.Fatalf("Expected the head to be entry 4, got %#v", head)
+	}
+	err = log.Record(events.AuctionClosed{Header: events.Header{ID: 6}})
+	if err != nil {
+		t.Fatalf("Failed to record event: %s", err.Error())
+	}
+
+	type testCase struct {
+		name   string
+		tamper func(entries []Entry) []Entry
+		err    bool
+	}
+	testCases := []testCase{
+		{name: "Appended After Head", tamper: func(entries []Entry) []Entry {
+			return entries
+		}},
+		{name: "Truncated", tamper: func(entries []Entry) []Entry {
+			return entries[:2]
+		}, err: true},
+		{name: "Emptied", tamper: func(entries []Entry) []Entry {
+			return nil
+		}, err: true},
+		{name: "Rewritten", tamper: func(entries []Entry) []Entry {
+			entries[0].Details.Bidder = "Pat"
+			for i := range entries {
+				if i > 0 {
+					entries[i].PrevHash = entries[i-1].Hash
+				}
+				entries[i].Hash, _ = computeHash(entries[i])
+			}
+			return entries
+		}, err: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			entries, err := store.GetAll()
+			if err != nil {
+				t.Fatalf("Failed to get entries: %s", err.Error())
+			}
+			entries = test.tamper(entries)
+			if err = VerifyChain(entries); err != nil {
+				t.Fatalf("Expected the chain itself to verify, got %s", err.Error())
+			}
+
+			err = VerifyHead(entries, head)
+			var tampered *TamperedEntryError
+			if test.err && !errors.As(err, &tampered) {
+				t.Fatalf("Expected a tampered entry error and received a different error instead: %v", err)
+			}
+			if !test.err && err != nil {
+				t.Fatalf("Expected log to verify, got %s", err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package audit
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/events"
+	"auction/id_generator"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// KindCommitFailed is the kind of the entry recorded when an event that was already recorded could not be committed.
+// The entry has the ID of the failed event.
+const KindCommitFailed events.Kind = "CommitFailed"
+
+// Entry is a single record in the audit log. Hash covers every other field, including the hash of the previous
+// entry, so changing, removing or reordering any entry breaks the chain from that point on.
+type Entry struct {
+	Sequence uint64               `json:"sequence"`
+	EventID  id_generator.EventID `json:"event_id"`
+	Auction  auction.ID           `json:"auction,omitempty"`
+	Kind     events.Kind          `json:"kind"`
+	Time     time.Time            `json:"time"`
+	Details  Details              `json:"details"`
+	PrevHash string               `json:"prev_hash"`
+	Hash     string               `json:"hash"`
+}
+
+// Details holds the full contents of the bid operation. Unlike the payloads sent to other bidders, max bids are
+// included so that the log can prove they were not changed.
+type Details struct {
+	Bidder      string `json:"bidder,omitempty"`
+	StartingBid string `json:"starting_bid,omitempty"`
+	MaxBid      string `json:"max_bid,omitempty"`
+	Increment   string `json:"increment,omitempty"`
+	// ExchangeRate is the rate used to convert a bid placed in another currency, such as "EUR/USD 1.0842"
+	ExchangeRate string `json:"exchange_rate,omitempty"`
+	Amount       string `json:"amount,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	// Detail is the full error of a rejected bid, which is only kept in the audit log
+	Detail string `json:"detail,omitempty"`
+	// ExpiresAt is when a second-chance offer expires, in RFC 3339
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// newDetails returns the details of an audited event and false if the event is not audited
+func newDetails(event events.Event) (Details, bool) {
+	switch e := event.(type) {
+	case events.BidPlaced:
+		return bidDetails(e.Bid), true
+	case events.BidPlacedOf[currency.BigAmount]:
+		return bidDetails(e.Bid), true
+	case events.BidAmended:
+		return bidDetails(e.Bid), true
+	case events.BidAmendedOf[currency.BigAmount]:
+		return bidDetails(e.Bid), true
+	case events.BidRejected:
+		return Details{Bidder: string(e.Bidder), Reason: e.Reason, Detail: e.Detail}, true
+	case events.BidRetracted:
+		return Details{Bidder: string(e.Bidder)}, true
+	case events.AuctionClosed:
+		return Details{}, true
+	case events.WinnerDetermined:
+		return winnerDetails(e.Winner), true
+	case events.WinnerDeterminedOf[currency.BigAmount]:
+		return winnerDetails(e.Winner), true
+	case events.BidderDefaulted:
+		return Details{Bidder: string(e.Bidder)}, true
+	case events.SecondChanceOffered:
+		return offerDetails(e.Offer), true
+	case events.SecondChanceOfferedOf[currency.BigAmount]:
+		return offerDetails(e.Offer), true
+	case events.SecondChanceAccepted:
+		return winnerDetails(e.Winner), true
+	case events.SecondChanceAcceptedOf[currency.BigAmount]:
+		return winnerDetails(e.Winner), true
+	case events.SecondChanceDeclined:
+		return Details{Bidder: string(e.Bidder)}, true
+	case events.BoughtItNow:
+		return winnerDetails(e.Winner), true
+	case events.BoughtItNowOf[currency.BigAmount]:
+		return winnerDetails(e.Winner), true
+	}
+	return Details{}, false
+}
+
+func bidDetails[M currency.Money[M]](bid auction.BidOf[M]) Details {
+	return Details{
+		Bidder:       string(bid.Bidder),
+		StartingBid:  bid.StartingBid.String(),
+		MaxBid:       bid.MaxBid.String(),
+		Increment:    bid.Increment.String(),
+		ExchangeRate: exchangeRate(bid.ExchangeRate),
+	}
+}
+
+func winnerDetails[M currency.Money[M]](winner auction.WinningBidOf[M]) Details {
+	return Details{Bidder: string(winner.Bidder), Amount: winner.Amount.String()}
+}
+
+func offerDetails[M currency.Money[M]](offer auction.SecondChanceOfferOf[M]) Details {
+	return Details{
+		Bidder:    string(offer.Bidder),
+		Amount:    offer.Amount.String(),
+		ExpiresAt: offer.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// exchangeRate describes the rate used to convert the bid, or is empty if it was not converted
+func exchangeRate(rate *currency.ExchangeRate) string {
+	if rate == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s %s", rate.From, rate.To, rate.Rate)
+}
+
+// Head identifies the last entry of a log. A chain only proves that its entries are consistent with each other, as a
+// log can be truncated or rewritten from the start with new hashes. A head kept somewhere the log cannot be changed
+// from, such as published or signed, proves the log held that entry at that point.
+type Head struct {
+	Sequence uint64 `json:"sequence"`
+	Hash     string `json:"hash"`
+}
+
+// headOf returns the head of the entries, which is the zero Head if there are none
+func headOf(entries []Entry) Head {
+	if len(entries) == 0 {
+		return Head{}
+	}
+	last := entries[len(entries)-1]
+	return Head{Sequence: last.Sequence, Hash: last.Hash}
+}
+
+// computeHash returns the SHA-256 of the JSON encoding of the entry without its own hash
+func computeHash(entry Entry) (string, error) {
+	entry.Hash = ""
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyChain checks that the entries form an unbroken chain starting from the first entry. It returns a
+// TamperedEntryError for the first entry that does not match.
+func VerifyChain(entries []Entry) error {
+	prevHash := ""
+	for i, entry := range entries {
+		sequence := uint64(i + 1)
+		if entry.Sequence != sequence {
+			return &TamperedEntryError{sequence: sequence, reason: "entry is missing or out of order"}
+		}
+		if entry.PrevHash != prevHash {
+			return &TamperedEntryError{sequence: sequence, reason: "previous hash does not match the previous entry"}
+		}
+		hash, err := computeHash(entry)
+		if err != nil {
+			return err
+		}
+		if entry.Hash != hash {
+			return &TamperedEntryError{sequence: sequence, reason: "hash does not match the contents of the entry"}
+		}
+		prevHash = entry.Hash
+	}
+	return nil
+}
+
+// VerifyHead checks that the entries form an unbroken chain that still contains the entry of the head. Entries
+// appended after the head are allowed. It returns a TamperedEntryError if the chain is broken, the log ends before
+// the head or the entry at the head has a different hash.
+func VerifyHead(entries []Entry, head Head) error {
+	err := VerifyChain(entries)
+	if err != nil {
+		return err
+	}
+	if head.Sequence == 0 {
+		return nil
+	}
+	if uint64(len(entries)) < head.Sequence {
+		return &TamperedEntryError{sequence: head.Sequence, reason: fmt.Sprintf("log ends after %d entries", len(entries))}
+	}
+	if entries[head.Sequence-1].Hash != head.Hash {
+		return &TamperedEntryError{sequence: head.Sequence, reason: "hash does not match the expected head"}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package audit
+
+import "fmt"
+
+type TamperedEntryError struct {
+	sequence uint64
+	reason   string
+}
+
+func (e *TamperedEntryError) Error() string {
+	return fmt.Sprintf("audit entry %d has been tampered with: %s", e.sequence, e.reason)
+}
+
+type CorruptEntryError struct {
+	line int
+}
+
+func (e *CorruptEntryError) Error() string {
+	return fmt.Sprintf("audit entry on line %d could not be read", e.line)
+}
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+)
+
+// fileEntryStorage stores one JSON encoded entry per line. The file is only ever opened for appending, and each entry
+// is synced to disk before Append returns.
+type fileEntryStorage struct {
+	path string
+	mtx  *sync.Mutex
+}
+
+func NewFileEntryStorage(path string) EntryStorer {
+	return &fileEntryStorage{
+		path: path,
+		mtx:  &sync.Mutex{},
+	}
+}
+
+func (f *fileEntryStorage) Append(entry Entry) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// GetAll returns no entries if the file has not been created yet
+func (f *fileEntryStorage) GetAll() ([]Entry, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	file, err := os.Open(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Entry{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var entry Entry
+		err = json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, errors.Join(&CorruptEntryError{line: line}, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
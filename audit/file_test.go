@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func WithFileEntryStorage() func(t *testing.T) EntryStorer {
+	return func(t *testing.T) EntryStorer {
+		return NewFileEntryStorage(filepath.Join(t.TempDir(), "audit.log"))
+	}
+}
+
+func TestFile(t *testing.T) {
+	tests := storageTests{
+		storeFn: WithFileEntryStorage(),
+		t:       t,
+	}
+	tests.Run()
+}
+
+func TestFileCorruptEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	err := os.WriteFile(path, []byte("{\"sequence\":1}\nnot json\n"), 0o644)
+	if err != nil {
+		t.Fatalf("Failed to write file: %s", err.Error())
+	}
+
+	_, err = NewFileEntryStorage(path).GetAll()
+	if err == nil {
+		t.Fatalf("Expected a corrupt entry error and did not receive one")
+	}
+}
@@ -0,0 +1,29 @@
+package audit
+
+import "sync"
+
+type memoryEntryStorage struct {
+	entries []Entry
+	mtx     *sync.Mutex
+}
+
+func NewMemoryEntryStorage() EntryStorer {
+	return &memoryEntryStorage{
+		entries: []Entry{},
+		mtx:     &sync.Mutex{},
+	}
+}
+
+func (m *memoryEntryStorage) Append(entry Entry) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+// GetAll returns a copy so that callers cannot change the stored entries
+func (m *memoryEntryStorage) GetAll() ([]Entry, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return append([]Entry{}, m.entries...), nil
+}
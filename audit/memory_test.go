@@ -0,0 +1,17 @@
+package audit
+
+import "testing"
+
+func WithMemoryEntryStorage() func(t *testing.T) EntryStorer {
+	return func(t *testing.T) EntryStorer {
+		return NewMemoryEntryStorage()
+	}
+}
+
+func Test(t *testing.T) {
+	tests := storageTests{
+		storeFn: WithMemoryEntryStorage(),
+		t:       t,
+	}
+	tests.Run()
+}
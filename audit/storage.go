@@ -0,0 +1,8 @@
+package audit
+
+type EntryStorer interface {
+	// Append adds the entry to the end of the log. Entries are never updated or removed.
+	Append(entry Entry) error
+	// GetAll returns every entry in the order they were appended
+	GetAll() ([]Entry, error)
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"auction/events"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type storageTests struct {
+	storeFn func(t *testing.T) EntryStorer
+	t       *testing.T
+}
+
+func (g *storageTests) Run() {
+	tests := map[string]func(t *testing.T, store EntryStorer){
+		"Test Append Get All": testAppendGetAll,
+		"Test Empty":          testEmpty,
+	}
+	for name, test := range tests {
+		g.t.Run(name, func(t *testing.T) {
+			test(t, g.storeFn(t))
+		})
+	}
+}
+
+func mockEntry(sequence uint64) Entry {
+	return Entry{
+		Sequence: sequence,
+		EventID:  1,
+		Kind:     events.KindBidPlaced,
+		Time:     time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Details:  Details{Bidder: "mockBidder", MaxBid: "$5.00"},
+		PrevHash: "prev",
+		Hash:     "hash",
+	}
+}
+
+func testAppendGetAll(t *testing.T, store EntryStorer) {
+	expected := []Entry{mockEntry(1), mockEntry(2), mockEntry(3)}
+	for _, entry := range expected {
+		err := store.Append(entry)
+		if err != nil {
+			t.Fatalf("Failed to append entry: %s", err.Error())
+		}
+	}
+
+	entries, err := store.GetAll()
+	if err != nil {
+		t.Fatalf("Failed to get entries: %s", err.Error())
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d", len(expected), len(entries))
+	}
+	for i := range expected {
+		if !entries[i].Time.Equal(expected[i].Time) {
+			t.Fatalf("Times do not match. Expected %s, got %s", expected[i].Time, entries[i].Time)
+		}
+		entries[i].Time = expected[i].Time
+	}
+	if !reflect.DeepEqual(expected, entries) {
+		t.Fatalf("Entries do not match. Expected:\n%#v\nGot:\n%#v", expected, entries)
+	}
+}
+
+func testEmpty(t *testing.T, store EntryStorer) {
+	entries, err := store.GetAll()
+	if err != nil {
+		t.Fatalf("Failed to get entries: %s", err.Error())
+	}
+	if len(entries) != 0 {
+		t.Fatalf("Expected no entries, got %d", len(entries))
+	}
+}
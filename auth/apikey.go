@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+// apiKeyPrefix marks API keys so that they can be told apart from tokens in logs and configuration
+const apiKeyPrefix = "ak_"
+
+// APIKeys issues and verifies long lived API keys
+type APIKeys interface {
+	Authenticator
+	// Issue creates a new API key for the principal. The key is only returned once, as only its hash is stored.
+	Issue(principal Principal) (string, error)
+	// Revoke stops the key from being accepted
+	Revoke(key string) error
+}
+
+type apiKeys struct {
+	store KeyStorer
+}
+
+func NewAPIKeys(store KeyStorer) APIKeys {
+	return &apiKeys{store: store}
+}
+
+func (a *apiKeys) Issue(principal Principal) (string, error) {
+	err := principal.validate()
+	if err != nil {
+		return "", err
+	}
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return "", errors.Join(errors.New("failed to generate API key"), err)
+	}
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+	err = a.store.SaveKey(hashKey(key), principal)
+	if err != nil {
+		return "", errors.Join(errors.New("failed to save API key"), err)
+	}
+	return key, nil
+}
+
+func (a *apiKeys) Revoke(key string) error {
+	return a.store.DeleteKey(hashKey(key))
+}
+
+func (a *apiKeys) Authenticate(credential string) (Principal, error) {
+	return a.store.GetKey(hashKey(credential))
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
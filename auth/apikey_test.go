@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"auction/auction"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAPIKeys(t *testing.T) {
+	keys := NewAPIKeys(NewMemoryKeyStorage())
+	principal := Principal{ID: "seller-1", Role: RoleSeller, Auctions: []auction.ID{"lot-1"}}
+
+	key, err := keys.Issue(principal)
+	if err != nil {
+		t.Fatalf("Failed to issue API key: %s", err.Error())
+	}
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		t.Fatalf("Expected API key to start with %s, got %s", apiKeyPrefix, key)
+	}
+	authenticated, err := keys.Authenticate(key)
+	if err != nil {
+		t.Fatalf("Failed to authenticate API key: %s", err.Error())
+	}
+	if !reflect.DeepEqual(authenticated, principal) {
+		t.Fatalf("Expected %#v, got %#v", principal, authenticated)
+	}
+
+	err = keys.Revoke(key)
+	if err != nil {
+		t.Fatalf("Failed to revoke API key: %s", err.Error())
+	}
+	_, err = keys.Authenticate(key)
+	if _, ok := err.(*UnauthenticatedError); !ok {
+		t.Fatalf("Expected UnauthenticatedError but got %#v", err)
+	}
+}
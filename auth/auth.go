@@ -0,0 +1,128 @@
+package auth
+
+import "auction/auction"
+
+type Role string
+
+const (
+	RoleBidder     Role = "bidder"
+	RoleSeller     Role = "seller"
+	RoleAuctioneer Role = "auctioneer"
+	RoleAdmin      Role = "admin"
+)
+
+type Permission string
+
+const (
+	// PermissionBid allows a bidder to place, amend and retract their own bid, buy the lot now and answer their own
+	// second-chance offer
+	PermissionBid Permission = "bid"
+	// PermissionBidForOthers allows the operations of PermissionBid on behalf of any bidder
+	PermissionBidForOthers Permission = "bid_for_others"
+	// PermissionManageAuction allows closing the auction, declaring that a winner defaulted and declining an offer on
+	// behalf of a bidder who let it expire
+	PermissionManageAuction Permission = "manage_auction"
+	// PermissionViewWinner allows calculating the winner
+	PermissionViewWinner Permission = "view_winner"
+	// PermissionViewResults allows calculating the results, which include the bid of every bidder
+	PermissionViewResults Permission = "view_results"
+	// PermissionManageLists allows changing and viewing the block and allow lists. A seller can only manage the lists
+	// of the auctions they own.
+	PermissionManageLists Permission = "manage_lists"
+)
+
+var permissions = map[Role][]Permission{
+	RoleBidder:     {PermissionBid, PermissionViewWinner},
+	RoleSeller:     {PermissionViewWinner, PermissionViewResults, PermissionManageLists},
+	RoleAuctioneer: {PermissionManageAuction, PermissionViewWinner, PermissionViewResults},
+	RoleAdmin:      {PermissionBid, PermissionBidForOthers, PermissionManageAuction, PermissionViewWinner, PermissionViewResults, PermissionManageLists},
+}
+
+// Can reports whether the role has the permission
+func (r Role) Can(permission Permission) bool {
+	for _, p := range permissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Principal is who a request was made by. A principal with the bidder role can only bid as Bidder, and a principal with
+// the seller or auctioneer role can only act on Auctions.
+type Principal struct {
+	ID       string         `json:"sub"`
+	Role     Role           `json:"role"`
+	Bidder   auction.Bidder `json:"bidder,omitempty"`
+	Auctions []auction.ID   `json:"auctions,omitempty"`
+}
+
+// owns reports whether the principal can act on the auction. Sellers and auctioneers are limited to their auctions,
+// while a bidder can bid in any auction and an admin acts on every auction.
+func (p Principal) owns(id auction.ID) bool {
+	if p.Role != RoleSeller && p.Role != RoleAuctioneer {
+		return true
+	}
+	for _, owned := range p.Auctions {
+		if owned == id {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks that the principal has a known role, and a bidder identity if it bids
+func (p Principal) validate() error {
+	if p.ID == "" {
+		return &InvalidPrincipalError{message: "a principal must have an ID"}
+	}
+	if _, ok := permissions[p.Role]; !ok {
+		return &InvalidPrincipalError{message: "unknown role " + string(p.Role)}
+	}
+	if p.Role == RoleBidder && p.Bidder == "" {
+		return &InvalidPrincipalError{message: "a bidder principal must have a bidder identity"}
+	}
+	return nil
+}
+
+// Authenticator verifies a credential sent with a request and returns the principal it belongs to
+type Authenticator interface {
+	Authenticate(credential string) (Principal, error)
+}
+
+type chain []Authenticator
+
+// Chain returns an Authenticator that accepts a credential accepted by any of the authenticators, such as either an
+// API key or a token
+func Chain(authenticators ...Authenticator) Authenticator {
+	return chain(authenticators)
+}
+
+func (c chain) Authenticate(credential string) (Principal, error) {
+	for _, authenticator := range c {
+		principal, err := authenticator.Authenticate(credential)
+		if err == nil {
+			return principal, nil
+		}
+	}
+	return Principal{}, &UnauthenticatedError{reason: "credential was not accepted"}
+}
+
+func authenticate(authenticator Authenticator, credential string) (Principal, error) {
+	if credential == "" {
+		return Principal{}, &UnauthenticatedError{reason: "no credential"}
+	}
+	return authenticator.Authenticate(credential)
+}
+
+// authorize authenticates the credential and checks that its principal has the permission
+func authorize(authenticator Authenticator, credential string, permission Permission) (Principal, error) {
+	principal, err := authenticate(authenticator, credential)
+	if err != nil {
+		return Principal{}, err
+	}
+	if !principal.Role.Can(permission) {
+		return Principal{}, &ForbiddenError{principal: principal.ID, permission: permission}
+	}
+	return principal, nil
+}
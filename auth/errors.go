@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"auction/auction"
+	"fmt"
+)
+
+type InvalidPrincipalError struct {
+	message string
+}
+
+func (e *InvalidPrincipalError) Error() string {
+	return e.message
+}
+
+type InvalidSecretError struct {
+	length int
+}
+
+func (e *InvalidSecretError) Error() string {
+	return fmt.Sprintf("a token secret must be at least %d bytes, got %d", minSecretLength, e.length)
+}
+
+type UnauthenticatedError struct {
+	reason string
+}
+
+func (e *UnauthenticatedError) Error() string {
+	return fmt.Sprintf("request is not authenticated: %s", e.reason)
+}
+
+type ForbiddenError struct {
+	principal  string
+	permission Permission
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("principal %s does not have the %s permission", e.principal, e.permission)
+}
+
+type NotOwnerError struct {
+	principal string
+	auction   auction.ID
+}
+
+func (e *NotOwnerError) Error() string {
+	if e.auction == "" {
+		return fmt.Sprintf("principal %s can only manage the lists of auctions they own", e.principal)
+	}
+	return fmt.Sprintf("principal %s does not own auction %s", e.principal, e.auction)
+}
+
+type PrincipalMismatchError struct {
+	principal string
+	bidder    auction.Bidder
+}
+
+func (e *PrincipalMismatchError) Error() string {
+	return fmt.Sprintf("principal %s cannot act as bidder %s", e.principal, e.bidder)
+}
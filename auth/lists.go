@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"auction/access"
+	"auction/auction"
+)
+
+// Lists manages access lists with the credential of the principal making each change. Only principals with
+// PermissionManageLists can view or change them, and a seller only the lists of the auctions they own.
+type Lists interface {
+	Block(credential string, scope access.Scope, bidder auction.Bidder, reason string) (access.Entry, error)
+	Unblock(credential string, scope access.Scope, bidder auction.Bidder) error
+	Allow(credential string, scope access.Scope, bidder auction.Bidder, reason string) (access.Entry, error)
+	Disallow(credential string, scope access.Scope, bidder auction.Bidder) error
+	SetInviteOnly(credential string, id auction.ID, inviteOnly bool) error
+	GetEntries(credential string, list access.List, scope access.Scope) ([]access.Entry, error)
+}
+
+type guardedLists struct {
+	authenticator Authenticator
+	lists         access.Lists
+}
+
+func NewLists(authenticator Authenticator, lists access.Lists) Lists {
+	return &guardedLists{
+		authenticator: authenticator,
+		lists:         lists,
+	}
+}
+
+func (g *guardedLists) Block(credential string, scope access.Scope, bidder auction.Bidder, reason string) (access.Entry, error) {
+	err := g.authorize(credential, scope)
+	if err != nil {
+		return access.Entry{}, err
+	}
+	return g.lists.Block(scope, bidder, reason)
+}
+
+func (g *guardedLists) Unblock(credential string, scope access.Scope, bidder auction.Bidder) error {
+	err := g.authorize(credential, scope)
+	if err != nil {
+		return err
+	}
+	return g.lists.Unblock(scope, bidder)
+}
+
+func (g *guardedLists) Allow(credential string, scope access.Scope, bidder auction.Bidder, reason string) (access.Entry, error) {
+	err := g.authorize(credential, scope)
+	if err != nil {
+		return access.Entry{}, err
+	}
+	return g.lists.Allow(scope, bidder, reason)
+}
+
+func (g *guardedLists) Disallow(credential string, scope access.Scope, bidder auction.Bidder) error {
+	err := g.authorize(credential, scope)
+	if err != nil {
+		return err
+	}
+	return g.lists.Disallow(scope, bidder)
+}
+
+func (g *guardedLists) SetInviteOnly(credential string, id auction.ID, inviteOnly bool) error {
+	err := g.authorize(credential, access.AuctionScope(id))
+	if err != nil {
+		return err
+	}
+	return g.lists.SetInviteOnly(id, inviteOnly)
+}
+
+func (g *guardedLists) GetEntries(credential string, list access.List, scope access.Scope) ([]access.Entry, error) {
+	err := g.authorize(credential, scope)
+	if err != nil {
+		return nil, err
+	}
+	return g.lists.GetEntries(list, scope)
+}
+
+// authorize checks that the principal of the credential can manage the lists of the scope. A global scope is not owned
+// by any seller.
+func (g *guardedLists) authorize(credential string, scope access.Scope) error {
+	principal, err := authorize(g.authenticator, credential, PermissionManageLists)
+	if err != nil {
+		return err
+	}
+	if scope.Global && principal.Role == RoleSeller {
+		return &NotOwnerError{principal: principal.ID}
+	}
+	if !scope.Global && !principal.owns(scope.Auction) {
+		return &NotOwnerError{principal: principal.ID, auction: scope.Auction}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"auction/auction"
+	"auction/bid_manager"
+	"auction/currency"
+)
+
+type BidManager = BidManagerOf[currency.Amount]
+
+// BidManagerOf is a bid manager where every operation is made with the credential of the principal making it. The
+// bidder given to an operation is checked against the principal, so a bidder can only bid as themselves.
+type BidManagerOf[M currency.Money[M]] interface {
+	AddBid(credential, bidder, startingBid, maxBid, incrementAmount string) error
+	AmendBid(credential, bidder, maxBid, incrementAmount string) error
+	RetractBid(credential, bidder string) error
+	BuyItNow(credential, bidder string) (auction.WinningBidOf[M], error)
+	Close(credential string) error
+	CalculateWinner(credential string) (auction.WinningBidOf[M], error)
+	CalculateResults(credential string) ([]auction.ResultOf[M], error)
+	DeclareDefault(credential, bidder string) (auction.SecondChanceOfferOf[M], error)
+	AcceptOffer(credential, bidder string) (auction.WinningBidOf[M], error)
+	DeclineOffer(credential, bidder string) (auction.SecondChanceOfferOf[M], error)
+}
+
+// guardedManager authenticates and authorizes each operation before passing it on to the manager of the auction with
+// the ID
+type guardedManager[M currency.Money[M]] struct {
+	authenticator Authenticator
+	id            auction.ID
+	manager       bid_manager.BidManagerOf[M]
+}
+
+func NewBidManager(authenticator Authenticator, id auction.ID, manager bid_manager.BidManager) BidManager {
+	return NewBidManagerOf[currency.Amount](authenticator, id, manager)
+}
+
+// NewBidManagerOf guards the manager of the auction with the given ID whose amounts are represented by M. Sellers and
+// auctioneers can only make the operations that do not bid if the auction is one of theirs.
+func NewBidManagerOf[M currency.Money[M]](authenticator Authenticator, id auction.ID, manager bid_manager.BidManagerOf[M]) BidManagerOf[M] {
+	return &guardedManager[M]{
+		authenticator: authenticator,
+		id:            id,
+		manager:       manager,
+	}
+}
+
+func (g *guardedManager[M]) AddBid(credential, bidder, startingBid, maxBid, incrementAmount string) error {
+	err := g.authorizeBidder(credential, bidder)
+	if err != nil {
+		return err
+	}
+	return g.manager.AddBid(bidder, startingBid, maxBid, incrementAmount)
+}
+
+func (g *guardedManager[M]) AmendBid(credential, bidder, maxBid, incrementAmount string) error {
+	err := g.authorizeBidder(credential, bidder)
+	if err != nil {
+		return err
+	}
+	return g.manager.AmendBid(bidder, maxBid, incrementAmount)
+}
+
+func (g *guardedManager[M]) RetractBid(credential, bidder string) error {
+	err := g.authorizeBidder(credential, bidder)
+	if err != nil {
+		return err
+	}
+	return g.manager.RetractBid(bidder)
+}
+
+func (g *guardedManager[M]) BuyItNow(credential, bidder string) (auction.WinningBidOf[M], error) {
+	err := g.authorizeBidder(credential, bidder)
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	return g.manager.BuyItNow(bidder)
+}
+
+func (g *guardedManager[M]) Close(credential string) error {
+	_, err := g.authorize(credential, PermissionManageAuction)
+	if err != nil {
+		return err
+	}
+	return g.manager.Close()
+}
+
+func (g *guardedManager[M]) CalculateWinner(credential string) (auction.WinningBidOf[M], error) {
+	_, err := g.authorize(credential, PermissionViewWinner)
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	return g.manager.CalculateWinner()
+}
+
+func (g *guardedManager[M]) CalculateResults(credential string) ([]auction.ResultOf[M], error) {
+	_, err := g.authorize(credential, PermissionViewResults)
+	if err != nil {
+		return nil, err
+	}
+	return g.manager.CalculateResults()
+}
+
+func (g *guardedManager[M]) DeclareDefault(credential, bidder string) (auction.SecondChanceOfferOf[M], error) {
+	_, err := g.authorize(credential, PermissionManageAuction)
+	if err != nil {
+		return auction.SecondChanceOfferOf[M]{}, err
+	}
+	return g.manager.DeclareDefault(bidder)
+}
+
+func (g *guardedManager[M]) AcceptOffer(credential, bidder string) (auction.WinningBidOf[M], error) {
+	err := g.authorizeBidder(credential, bidder)
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	return g.manager.AcceptOffer(bidder)
+}
+
+// DeclineOffer can also be made by a principal that manages the auction, so that an offer that expired without an
+// answer can be declined on the bidder's behalf
+func (g *guardedManager[M]) DeclineOffer(credential, bidder string) (auction.SecondChanceOfferOf[M], error) {
+	principal, err := authenticate(g.authenticator, credential)
+	if err != nil {
+		return auction.SecondChanceOfferOf[M]{}, err
+	}
+	if principal.Role.Can(PermissionManageAuction) {
+		err = g.checkOwner(principal)
+	} else {
+		err = checkBidder(principal, bidder)
+	}
+	if err != nil {
+		return auction.SecondChanceOfferOf[M]{}, err
+	}
+	return g.manager.DeclineOffer(bidder)
+}
+
+// authorizeBidder authenticates the credential and checks that its principal can act as the bidder
+func (g *guardedManager[M]) authorizeBidder(credential, bidder string) error {
+	principal, err := authenticate(g.authenticator, credential)
+	if err != nil {
+		return err
+	}
+	return checkBidder(principal, bidder)
+}
+
+// authorize authenticates the credential and checks that its principal has the permission in the auction
+func (g *guardedManager[M]) authorize(credential string, permission Permission) (Principal, error) {
+	principal, err := authorize(g.authenticator, credential, permission)
+	if err != nil {
+		return Principal{}, err
+	}
+	return principal, g.checkOwner(principal)
+}
+
+// checkOwner checks that the principal can act on the auction of the manager
+func (g *guardedManager[M]) checkOwner(principal Principal) error {
+	if !principal.owns(g.id) {
+		return &NotOwnerError{principal: principal.ID, auction: g.id}
+	}
+	return nil
+}
+
+// checkBidder allows a principal to act as their own bidder identity, or as any bidder with PermissionBidForOthers
+func checkBidder(principal Principal, bidder string) error {
+	if principal.Role.Can(PermissionBidForOthers) {
+		return nil
+	}
+	if !principal.Role.Can(PermissionBid) {
+		return &ForbiddenError{principal: principal.ID, permission: PermissionBid}
+	}
+	if principal.Bidder != auction.Bidder(bidder) {
+		return &PrincipalMismatchError{principal: principal.ID, bidder: auction.Bidder(bidder)}
+	}
+	return nil
+}
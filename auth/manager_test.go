@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"auction/access"
+	"auction/auction"
+	"auction/bid_manager"
+	"auction/id_generator"
+	"auction/storage"
+	"reflect"
+	"testing"
+)
+
+// newGuardedManager returns the managers of auction-1 and auction-2 that accept tokens and API keys, along with a
+// credential for each role. The bidder is Sasha, and the seller and auctioneer run auction-1.
+func newGuardedManager(t *testing.T) (BidManager, BidManager, map[Role]string) {
+	tokens := newTokens(t, TokenConfig{Secret: testSecret})
+	keys := NewAPIKeys(NewMemoryKeyStorage())
+	managers := []BidManager{}
+	for _, id := range []auction.ID{"auction-1", "auction-2"} {
+		manager, err := bid_manager.NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), bid_manager.WithAuctionID(id))
+		if err != nil {
+			t.Fatalf("could not initialize manager: %s", err.Error())
+		}
+		managers = append(managers, NewBidManager(Chain(tokens, keys), id, manager))
+	}
+
+	var err error
+	credentials := map[Role]string{}
+	for _, principal := range []Principal{
+		{ID: "user-1", Role: RoleBidder, Bidder: "Sasha"},
+		{ID: "seller-1", Role: RoleSeller, Auctions: []auction.ID{"auction-1"}},
+		{ID: "auctioneer-1", Role: RoleAuctioneer, Auctions: []auction.ID{"auction-1"}},
+	} {
+		credentials[principal.Role], err = tokens.Issue(principal)
+		if err != nil {
+			t.Fatalf("Failed to issue token: %s", err.Error())
+		}
+	}
+	credentials[RoleAdmin], err = keys.Issue(Principal{ID: "admin-1", Role: RoleAdmin})
+	if err != nil {
+		t.Fatalf("Failed to issue API key: %s", err.Error())
+	}
+	return managers[0], managers[1], credentials
+}
+
+func TestGuardedManager(t *testing.T) {
+	manager, other, credentials := newGuardedManager(t)
+
+	type testCase struct {
+		name       string
+		credential string
+		operation  func(credential string) error
+		err        error
+	}
+	addBid := func(bidder string) func(credential string) error {
+		return func(credential string) error {
+			return manager.AddBid(credential, bidder, "$50.00", "$80.00", "$3.00")
+		}
+	}
+	results := func(credential string) error {
+		_, err := manager.CalculateResults(credential)
+		return err
+	}
+	otherResults := func(credential string) error {
+		_, err := other.CalculateResults(credential)
+		return err
+	}
+	otherDefault := func(credential string) error {
+		_, err := other.DeclareDefault(credential, "Sasha")
+		return err
+	}
+	otherBid := func(credential string) error {
+		return other.AddBid(credential, "Sasha", "$50.00", "$80.00", "$3.00")
+	}
+	testCases := []testCase{
+		{name: "No Credential", credential: "", operation: addBid("Sasha"), err: &UnauthenticatedError{}},
+		{name: "Unknown Credential", credential: "ak_unknown", operation: addBid("Sasha"), err: &UnauthenticatedError{}},
+		{name: "Bid As Someone Else", credential: credentials[RoleBidder], operation: addBid("John"), err: &PrincipalMismatchError{}},
+		{name: "Seller Bids", credential: credentials[RoleSeller], operation: addBid("Sasha"), err: &ForbiddenError{}},
+		{name: "Bid As Self", credential: credentials[RoleBidder], operation: addBid("Sasha")},
+		{name: "Admin Bids For Others", credential: credentials[RoleAdmin], operation: addBid("John")},
+		{name: "Bidder Views Results", credential: credentials[RoleBidder], operation: results, err: &ForbiddenError{}},
+		{name: "Seller Views Results", credential: credentials[RoleSeller], operation: results},
+		{name: "Bidder Closes", credential: credentials[RoleBidder], operation: manager.Close, err: &ForbiddenError{}},
+		{name: "Auctioneer Closes", credential: credentials[RoleAuctioneer], operation: manager.Close},
+		{name: "Seller Views Other Results", credential: credentials[RoleSeller], operation: otherResults, err: &NotOwnerError{}},
+		{name: "Auctioneer Closes Other", credential: credentials[RoleAuctioneer], operation: other.Close, err: &NotOwnerError{}},
+		{name: "Auctioneer Defaults In Other", credential: credentials[RoleAuctioneer], operation: otherDefault, err: &NotOwnerError{}},
+		{name: "Bid In Other", credential: credentials[RoleBidder], operation: otherBid},
+		{name: "Admin Closes Other", credential: credentials[RoleAdmin], operation: other.Close},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.operation(test.credential)
+			if test.err == nil && err != nil {
+				t.Fatalf("Expected operation to succeed, got %s", err.Error())
+			}
+			if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+				t.Fatalf("Expected %T but got %#v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestGuardedLists(t *testing.T) {
+	tokens := newTokens(t, TokenConfig{Secret: testSecret})
+	lists := NewLists(tokens, access.NewLists(access.Config{}, access.NewMemoryStorage()))
+	auctioneer, err := tokens.Issue(Principal{ID: "auctioneer-1", Role: RoleAuctioneer})
+	if err != nil {
+		t.Fatalf("Failed to issue token: %s", err.Error())
+	}
+	admin, err := tokens.Issue(Principal{ID: "admin-1", Role: RoleAdmin})
+	if err != nil {
+		t.Fatalf("Failed to issue token: %s", err.Error())
+	}
+
+	_, err = lists.Block(auctioneer, access.GlobalScope(), "Pat", "")
+	if _, ok := err.(*ForbiddenError); !ok {
+		t.Fatalf("Expected ForbiddenError but got %#v", err)
+	}
+	_, err = lists.Block(admin, access.GlobalScope(), "Pat", "")
+	if err != nil {
+		t.Fatalf("Failed to block bidder: %s", err.Error())
+	}
+	entries, err := lists.GetEntries(admin, access.ListBlock, access.GlobalScope())
+	if err != nil {
+		t.Fatalf("Failed to get entries: %s", err.Error())
+	}
+	if len(entries) != 1 || entries[0].Bidder != "Pat" {
+		t.Fatalf("Expected Pat to be blocked, got %#v", entries)
+	}
+}
+
+func TestGuardedLists_Seller(t *testing.T) {
+	tokens := newTokens(t, TokenConfig{Secret: testSecret})
+	lists := NewLists(tokens, access.NewLists(access.Config{}, access.NewMemoryStorage()))
+	seller, err := tokens.Issue(Principal{ID: "seller-1", Role: RoleSeller, Auctions: []auction.ID{"lot-1"}})
+	if err != nil {
+		t.Fatalf("Failed to issue token: %s", err.Error())
+	}
+
+	type testCase struct {
+		name      string
+		operation func() error
+		err       error
+	}
+	block := func(scope access.Scope) func() error {
+		return func() error {
+			_, err := lists.Block(seller, scope, "Pat", "did not pay")
+			return err
+		}
+	}
+	entries := func(scope access.Scope) func() error {
+		return func() error {
+			_, err := lists.GetEntries(seller, access.ListBlock, scope)
+			return err
+		}
+	}
+	inviteOnly := func(id auction.ID) func() error {
+		return func() error {
+			return lists.SetInviteOnly(seller, id, true)
+		}
+	}
+	testCases := []testCase{
+		{name: "Block In Own Auction", operation: block(access.AuctionScope("lot-1"))},
+		{name: "Block In Other Auction", operation: block(access.AuctionScope("lot-2")), err: &NotOwnerError{}},
+		{name: "Block Globally", operation: block(access.GlobalScope()), err: &NotOwnerError{}},
+		{name: "View Own Auction", operation: entries(access.AuctionScope("lot-1"))},
+		{name: "View Other Auction", operation: entries(access.AuctionScope("lot-2")), err: &NotOwnerError{}},
+		{name: "View Global", operation: entries(access.GlobalScope()), err: &NotOwnerError{}},
+		{name: "Invite Only Own Auction", operation: inviteOnly("lot-1")},
+		{name: "Invite Only Other Auction", operation: inviteOnly("lot-2"), err: &NotOwnerError{}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.operation()
+			if test.err == nil && err != nil {
+				t.Fatalf("Expected operation to succeed, got %s", err.Error())
+			}
+			if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+				t.Fatalf("Expected %T but got %#v", test.err, err)
+			}
+		})
+	}
+}
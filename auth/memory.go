@@ -0,0 +1,39 @@
+package auth
+
+import "sync"
+
+type memoryKeyStorage struct {
+	keys map[string]Principal
+	mtx  *sync.Mutex
+}
+
+func NewMemoryKeyStorage() KeyStorer {
+	return &memoryKeyStorage{
+		keys: map[string]Principal{},
+		mtx:  &sync.Mutex{},
+	}
+}
+
+func (m memoryKeyStorage) SaveKey(hash string, principal Principal) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.keys[hash] = principal
+	return nil
+}
+
+func (m memoryKeyStorage) GetKey(hash string) (Principal, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	principal, ok := m.keys[hash]
+	if !ok {
+		return Principal{}, &UnauthenticatedError{reason: "unknown API key"}
+	}
+	return principal, nil
+}
+
+func (m memoryKeyStorage) DeleteKey(hash string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.keys, hash)
+	return nil
+}
@@ -0,0 +1,17 @@
+package auth
+
+import "testing"
+
+func WithMemoryKeyStorage() func() KeyStorer {
+	return func() KeyStorer {
+		return NewMemoryKeyStorage()
+	}
+}
+
+func TestMemoryKeyStorage(t *testing.T) {
+	tests := storageTests{
+		storeFn: WithMemoryKeyStorage(),
+		t:       t,
+	}
+	tests.Run()
+}
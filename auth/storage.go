@@ -0,0 +1,10 @@
+package auth
+
+// KeyStorer stores the principal of each API key by the SHA-256 of the key, so that the keys themselves are never
+// stored
+type KeyStorer interface {
+	SaveKey(hash string, principal Principal) error
+	// GetKey returns an UnauthenticatedError if there is no key with the hash
+	GetKey(hash string) (Principal, error)
+	DeleteKey(hash string) error
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"reflect"
+	"testing"
+)
+
+type storageTests struct {
+	storeFn func() KeyStorer
+	t       *testing.T
+}
+
+func (g *storageTests) Run() {
+	tests := map[string]func(t *testing.T, store KeyStorer){
+		"Test Keys":          testKeys,
+		"Test Key Not Found": testKeyNotFound,
+	}
+	for name, test := range tests {
+		g.t.Run(name, func(t *testing.T) {
+			test(t, g.storeFn())
+		})
+	}
+}
+
+func testKeys(t *testing.T, store KeyStorer) {
+	principal := Principal{ID: "user-1", Role: RoleBidder, Bidder: "Sasha"}
+	err := store.SaveKey("hash-1", principal)
+	if err != nil {
+		t.Fatalf("Failed to save key: %s", err.Error())
+	}
+	saved, err := store.GetKey("hash-1")
+	if err != nil {
+		t.Fatalf("Failed to get key: %s", err.Error())
+	}
+	if !reflect.DeepEqual(principal, saved) {
+		t.Fatalf("Principals do not match. Expected:\n%#v\nGot:\n%#v", principal, saved)
+	}
+
+	err = store.DeleteKey("hash-1")
+	if err != nil {
+		t.Fatalf("Failed to delete key: %s", err.Error())
+	}
+	_, err = store.GetKey("hash-1")
+	if _, ok := err.(*UnauthenticatedError); !ok {
+		t.Fatalf("Expected UnauthenticatedError but got %#v", err)
+	}
+}
+
+func testKeyNotFound(t *testing.T, store KeyStorer) {
+	_, err := store.GetKey("hash-1")
+	if _, ok := err.(*UnauthenticatedError); !ok {
+		t.Fatalf("Expected UnauthenticatedError but got %#v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTokenTTL = time.Hour
+	// minSecretLength is the shortest secret accepted, which is the size of the output of SHA-256
+	minSecretLength = 32
+)
+
+// TokenConfig configures signed tokens. Secret must be at least 32 bytes. TTL is how long a token is accepted after it
+// is issued, and is an hour if it is not set. Now is time.Now if it is not set.
+type TokenConfig struct {
+	Secret []byte
+	TTL    time.Duration
+	Now    func() time.Time
+}
+
+// Tokens issues and verifies short lived tokens signed with an HMAC-SHA256, which are verified without a lookup
+type Tokens interface {
+	Authenticator
+	Issue(principal Principal) (string, error)
+}
+
+type tokens struct {
+	config TokenConfig
+}
+
+// claims is the payload of a token
+type claims struct {
+	Principal
+	ExpiresAt int64 `json:"exp"`
+}
+
+// NewTokens returns an InvalidSecretError if the secret is shorter than 32 bytes, since tokens signed with a short or
+// empty secret can be forged
+func NewTokens(config TokenConfig) (Tokens, error) {
+	if len(config.Secret) < minSecretLength {
+		return nil, &InvalidSecretError{length: len(config.Secret)}
+	}
+	if config.TTL == 0 {
+		config.TTL = defaultTokenTTL
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &tokens{config: config}, nil
+}
+
+// Issue returns a token of the form payload.signature, where both parts are base64url encoded and the payload is the
+// JSON of the principal and the expiry of the token
+func (t *tokens) Issue(principal Principal) (string, error) {
+	err := principal.validate()
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims{Principal: principal, ExpiresAt: t.config.Now().Add(t.config.TTL).Unix()})
+	if err != nil {
+		return "", errors.Join(errors.New("failed to encode token"), err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + t.sign(encoded), nil
+}
+
+func (t *tokens) Authenticate(credential string) (Principal, error) {
+	encoded, signature, ok := strings.Cut(credential, ".")
+	if !ok {
+		return Principal{}, &UnauthenticatedError{reason: "malformed token"}
+	}
+	if !hmac.Equal([]byte(t.sign(encoded)), []byte(signature)) {
+		return Principal{}, &UnauthenticatedError{reason: "invalid token signature"}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Principal{}, &UnauthenticatedError{reason: "malformed token"}
+	}
+	var c claims
+	err = json.Unmarshal(payload, &c)
+	if err != nil {
+		return Principal{}, &UnauthenticatedError{reason: "malformed token"}
+	}
+	if !t.config.Now().Before(time.Unix(c.ExpiresAt, 0)) {
+		return Principal{}, &UnauthenticatedError{reason: "token has expired"}
+	}
+	return c.Principal, nil
+}
+
+// sign returns the base64url encoded HMAC-SHA256 of the encoded payload
+func (t *tokens) sign(encoded string) string {
+	mac := hmac.New(sha256.New, t.config.Secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
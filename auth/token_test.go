@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testSecret  = []byte("0123456789abcdef0123456789abcdef")
+	otherSecret = []byte("fedcba9876543210fedcba9876543210")
+)
+
+func newTokens(t *testing.T, config TokenConfig) Tokens {
+	tokens, err := NewTokens(config)
+	if err != nil {
+		t.Fatalf("could not initialize tokens: %s", err.Error())
+	}
+	return tokens
+}
+
+func TestTokens(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	tokens := newTokens(t, TokenConfig{Secret: testSecret, TTL: time.Hour, Now: clock})
+	principal := Principal{ID: "user-1", Role: RoleBidder, Bidder: "Sasha"}
+
+	token, err := tokens.Issue(principal)
+	if err != nil {
+		t.Fatalf("Failed to issue token: %s", err.Error())
+	}
+	authenticated, err := tokens.Authenticate(token)
+	if err != nil {
+		t.Fatalf("Failed to authenticate token: %s", err.Error())
+	}
+	if !reflect.DeepEqual(authenticated, principal) {
+		t.Fatalf("Expected %#v, got %#v", principal, authenticated)
+	}
+
+	payload, signature, _ := strings.Cut(token, ".")
+	other := newTokens(t, TokenConfig{Secret: otherSecret, Now: clock})
+	forged, err := other.Issue(Principal{ID: "user-1", Role: RoleAdmin})
+	if err != nil {
+		t.Fatalf("Failed to issue token: %s", err.Error())
+	}
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	type testCase struct {
+		name  string
+		token string
+		after time.Duration
+	}
+	testCases := []testCase{
+		{name: "Malformed", token: "not-a-token"},
+		{name: "Wrong Secret", token: forged},
+		{name: "Changed Payload", token: forgedPayload + "." + signature},
+		{name: "Changed Signature", token: payload + "." + signature[1:]},
+		{name: "Expired", token: token, after: time.Hour},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC).Add(test.after)
+			_, err := tokens.Authenticate(test.token)
+			if _, ok := err.(*UnauthenticatedError); !ok {
+				t.Fatalf("Expected UnauthenticatedError but got %#v", err)
+			}
+		})
+	}
+}
+
+func TestInvalidPrincipal(t *testing.T) {
+	tokens := newTokens(t, TokenConfig{Secret: testSecret})
+	keys := NewAPIKeys(NewMemoryKeyStorage())
+	principals := []Principal{
+		{Role: RoleAdmin},
+		{ID: "user-1", Role: "owner"},
+		{ID: "user-1", Role: RoleBidder},
+	}
+	for _, principal := range principals {
+		_, err := tokens.Issue(principal)
+		if _, ok := err.(*InvalidPrincipalError); !ok {
+			t.Fatalf("Expected InvalidPrincipalError but got %#v", err)
+		}
+		_, err = keys.Issue(principal)
+		if _, ok := err.(*InvalidPrincipalError); !ok {
+			t.Fatalf("Expected InvalidPrincipalError but got %#v", err)
+		}
+	}
+}
+
+func TestInvalidSecret(t *testing.T) {
+	secrets := [][]byte{nil, {}, []byte("secret"), testSecret[:31]}
+	for _, secret := range secrets {
+		_, err := NewTokens(TokenConfig{Secret: secret})
+		if _, ok := err.(*InvalidSecretError); !ok {
+			t.Fatalf("Expected InvalidSecretError for a secret of %d bytes but got %#v", len(secret), err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package bid_manager
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/events"
+	"auction/storage"
+	"errors"
+	"fmt"
+)
+
+// newBuyItNow parses the buy-it-now price of the config, returning nil if the lot has no buy-it-now price
+func newBuyItNow[M currency.Money[M]](c config) (*auction.BuyItNowOf[M], error) {
+	if c.buyItNowPrice == "" {
+		return nil, nil
+	}
+	price, err := currency.ParseMoneyIn[M](c.locale, c.buyItNowPrice, c.currency)
+	if err != nil {
+		return nil, errors.Join(&InvalidBuyItNowError{message: "failed to parse buy-it-now price"}, err)
+	}
+	minimum := currency.MinorUnitOf[M](c.currency)
+	if price.Less(minimum) {
+		return nil, &InvalidBuyItNowError{message: fmt.Sprintf("buy-it-now price %s cannot be less than %s", price.String(), minimum.String())}
+	}
+	if c.buyItNowThreshold.Sign() <= 0 {
+		return nil, &InvalidBuyItNowError{message: fmt.Sprintf("buy-it-now threshold %s%% must be greater than zero", c.buyItNowThreshold)}
+	}
+	return &auction.BuyItNowOf[M]{Price: price, Threshold: c.buyItNowThreshold}, nil
+}
+
+// BuyItNow closes the auction with the bidder as the winner at the buy-it-now price. The lot can only be bought until
+// the leading bid first reaches the threshold percentage of the price, after which it has to be won by bidding even if
+// the leading bid is retracted.
+func (m defaultBidManager[M]) BuyItNow(bidder string) (auction.WinningBidOf[M], error) {
+	m.state.mtx.Lock()
+	defer m.state.mtx.Unlock()
+	if m.state.closed {
+		return auction.WinningBidOf[M]{}, &AuctionClosedError{}
+	}
+	if m.buyItNow == nil {
+		return auction.WinningBidOf[M]{}, &BuyItNowUnavailableError{reason: "the lot has no buy-it-now price"}
+	}
+	err := m.checkAccess(auction.Bidder(bidder))
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	err = m.checkDeposit(auction.Bidder(bidder))
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+
+	// a manager over a store that already holds bids has not seen them reach the threshold yet
+	err = disableBuyItNow(m.storage, m.state, m.buyItNow)
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	if m.state.binDisabled {
+		return auction.WinningBidOf[M]{}, &BuyItNowUnavailableError{
+			reason: fmt.Sprintf("bidding has reached %s%% of the buy-it-now price", m.buyItNow.Threshold),
+		}
+	}
+
+	// the buyer is held to the price in the same way as a bidder is to their max bid
+	err = m.reserve(auction.BidOf[M]{Bidder: auction.Bidder(bidder), MaxBid: m.buyItNow.Price})
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	winner := auction.WinningBidOf[M]{Bidder: auction.Bidder(bidder), Amount: m.buyItNow.Price}
+	err = m.change(events.BoughtItNowOf[M]{Header: m.newHeader(), Winner: winner})
+	if err != nil {
+		return auction.WinningBidOf[M]{}, errors.Join(err, m.restore(winner.Bidder))
+	}
+	return winner, m.settle()
+}
+
+// disableBuyItNow marks the buy-it-now price as unavailable once the leading bid of an open auction has reached its
+// threshold. It is checked after every change, including those replayed from an event log, so that the price stays
+// unavailable after the leading bid falls back below the threshold.
+func disableBuyItNow[M currency.Money[M]](store storage.BidStorerOf[M], state *auctionState[M], buyItNow *auction.BuyItNowOf[M]) error {
+	if buyItNow == nil || state.binDisabled || state.closed {
+		return nil
+	}
+	bids, err := store.GetAllBids()
+	if err != nil {
+		return errors.Join(errors.New("failed to fetch bids"), err)
+	}
+	if len(bids) == 0 {
+		return nil
+	}
+	leader, err := CalculateWinnerOf(bids)
+	if err != nil {
+		return err
+	}
+	threshold, err := currency.PercentOf(buyItNow.Price, buyItNow.Threshold, currency.RoundUp)
+	if err != nil {
+		return err
+	}
+	state.binDisabled = !leader.Amount.Less(threshold)
+	return nil
+}
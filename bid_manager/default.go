@@ -1,81 +1,722 @@
 package bid_manager
 
 import (
+	"auction/access"
 	"auction/auction"
+	"auction/audit"
 	"auction/currency"
+	"auction/deposit"
+	"auction/events"
 	"auction/id_generator"
+	"auction/registry"
 	"auction/storage"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 )
 
 // bidState stores each bidders current bid value as the winner is determined
-type bidState map[auction.Bidder]currency.Amount
+type bidState[M currency.Money[M]] map[auction.Bidder]M
+
+// standing is the outcome of running the bidding rounds over a set of bids
+type standing[M currency.Money[M]] struct {
+	winner auction.WinningBidOf[M]
+	state  bidState[M]
+}
+
+// auctionState is shared between copies of the manager. The mutex serializes changes so that the standings compared
+// when publishing events are not interleaved with another change.
+type auctionState[M currency.Money[M]] struct {
+	mtx    sync.Mutex
+	closed bool
+	// excluded holds the bidders who defaulted or declined a second-chance offer, who can no longer win the lot
+	excluded map[auction.Bidder]bool
+	// offer is the second-chance offer waiting for a response, if there is one
+	offer *auction.SecondChanceOfferOf[M]
+	// purchase is the winner of an auction that was ended by buying the lot at its buy-it-now price
+	purchase *auction.WinningBidOf[M]
+	// binDisabled is set once the leading bid has reached the buy-it-now threshold, and is never cleared
+	binDisabled bool
+	// settled is set once the max bids and deposits of the closed auction have been settled
+	settled bool
+}
+
+func newAuctionState[M currency.Money[M]]() *auctionState[M] {
+	return &auctionState[M]{excluded: map[auction.Bidder]bool{}}
+}
 
 // defaultBidManager implements the BidManager interface and can be provided with different implementations for storage
-// and ID generation
-type defaultBidManager struct {
+// and ID generation. When it is given an event log, every change is appended to the log and storage is a projection of
+// it. M is the representation of the amounts of the auction, either currency.Amount or currency.BigAmount.
+type defaultBidManager[M currency.Money[M]] struct {
+	config
 	idGenerator id_generator.IDGenerator
-	storage     storage.BidStorer
+	storage     storage.BidStorerOf[M]
+	log         events.Log
+	state       *auctionState[M]
+	// buyItNow is nil when the lot cannot be bought at a fixed price
+	buyItNow *auction.BuyItNowOf[M]
+	// deposit is nil when bidders do not have to post a deposit
+	deposit *currency.Amount
+}
+
+// config holds the optional behaviour of the manager, which is the same for every representation of the amounts
+type config struct {
+	publisher events.Publisher
+	auditLog  audit.Log
+	currency  currency.Code
+	rates     currency.ExchangeRateProvider
+	locale    currency.Locale
+	now       func() time.Time
+	// offerWindow is how long an underbidder has to accept a second-chance offer
+	offerWindow time.Duration
+	// buyItNowPrice is parsed into the buy-it-now of the manager, in the representation of its amounts
+	buyItNowPrice     string
+	buyItNowThreshold currency.Rate
+	auctionID         auction.ID
+	registry          registry.Registry
+	lists             access.Lists
+	// depositAmount is parsed into the deposit of the manager, which bidders post to the deposit holds
+	depositAmount string
+	deposits      deposit.Holds
+}
+
+// Option configures optional behaviour of the default bid manager
+type Option func(c *config)
+
+// newConfig applies the options to the defaults and checks the currency of the auction, and that the auction has an ID
+// when it uses a service shared with other auctions
+func newConfig(opts []Option) (config, error) {
+	c := config{
+		currency:    currency.DefaultCurrency,
+		locale:      currency.DefaultLocale,
+		now:         time.Now,
+		offerWindow: defaultOfferWindow,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	info, err := currency.Lookup(c.currency)
+	if err != nil {
+		return config{}, err
+	}
+	c.currency = info.Code
+	// the holds are shared by every auction, so an auction without an ID would settle the deposits of every other one
+	if c.depositAmount != "" && c.auctionID == "" {
+		return config{}, &MissingAuctionIDError{option: "WithDeposit"}
+	}
+	// max bids are outstanding under the auction's ID, so an auction without one would share them with every other one
+	if c.registry != nil && c.auctionID == "" {
+		return config{}, &MissingAuctionIDError{option: "WithBidderRegistry"}
+	}
+	// the lists are shared by every auction, so an auction without an ID would share its block and allow lists
+	if c.lists != nil && c.auctionID == "" {
+		return config{}, &MissingAuctionIDError{option: "WithAccessLists"}
+	}
+	return c, nil
+}
+
+// WithPublisher publishes the events of the auction to the given publisher
+func WithPublisher(publisher events.Publisher) Option {
+	return func(c *config) {
+		c.publisher = publisher
+	}
+}
+
+// WithAuditLog records every bid operation, rejected bid and calculated winner in the audit log. An operation that
+// cannot be recorded returns an error.
+func WithAuditLog(log audit.Log) Option {
+	return func(c *config) {
+		c.auditLog = log
+	}
+}
+
+// WithClock sets the function used to timestamp events
+func WithClock(now func() time.Time) Option {
+	return func(c *config) {
+		c.now = now
+	}
+}
+
+// WithCurrency sets the currency of the auction. Bids must be given in the currency of the auction, which is the
+// DefaultCurrency if it is not set.
+func WithCurrency(code currency.Code) Option {
+	return func(c *config) {
+		c.currency = code
+	}
+}
+
+// WithExchangeRates allows bids to be placed in currencies other than the currency of the auction. The amounts of such
+// a bid are converted with the rate from the provider and rounded down, so that a bidder is never committed to more
+// than they offered, and the rate is recorded on the bid.
+func WithExchangeRates(provider currency.ExchangeRateProvider) Option {
+	return func(c *config) {
+		c.rates = provider
+	}
+}
+
+// WithLocale sets the locale used to parse the amounts of bids, so that bidders can write amounts such as "1.234,56 €".
+// Amounts are parsed in the DefaultLocale if it is not set.
+func WithLocale(locale currency.Locale) Option {
+	return func(c *config) {
+		c.locale = locale
+	}
 }
 
-func NewDefaultBidManager(idGenerator id_generator.IDGenerator, store storage.BidStorer) (BidManager, error) {
-	return &defaultBidManager{
+// WithOfferWindow sets how long an underbidder has to accept a second-chance offer before it expires. Offers expire
+// after 48 hours if it is not set.
+func WithOfferWindow(window time.Duration) Option {
+	return func(c *config) {
+		c.offerWindow = window
+	}
+}
+
+// WithBuyItNow allows the lot to be bought at a fixed price, given in the currency and locale of the auction, until the
+// leading bid reaches the threshold percentage of that price.
+func WithBuyItNow(price string, threshold currency.Rate) Option {
+	return func(c *config) {
+		c.buyItNowPrice = price
+		c.buyItNowThreshold = threshold
+	}
+}
+
+// WithAuctionID sets the ID of the auction in services shared with other auctions, such as a bidder registry
+func WithAuctionID(id auction.ID) Option {
+	return func(c *config) {
+		c.auctionID = id
+	}
+}
+
+// WithBidderRegistry only accepts bids from verified bidders in the registry whose max bids, across every auction
+// sharing the registry, fit in their credit limit. A bid's max bid is outstanding until it is retracted or the auction
+// closes without the bidder winning, and the max bid of the winner stays outstanding until it is released from the
+// registry once the lot has been paid for. Max bids are recorded under the ID set with WithAuctionID, which is required.
+func WithBidderRegistry(registry registry.Registry) Option {
+	return func(c *config) {
+		c.registry = registry
+	}
+}
+
+// WithAccessLists rejects bids from bidders who are blocked from the auction, or who have not been invited to an
+// auction that is invite only. The auction is identified by the ID set with WithAuctionID, which is required.
+func WithAccessLists(lists access.Lists) Option {
+	return func(c *config) {
+		c.lists = lists
+	}
+}
+
+// WithDeposit requires every bidder to hold a refundable deposit of the amount, given in the currency and locale of the
+// auction, in the deposit holds before they can bid or buy the lot. When the auction closes, or the lot is bought at its
+// buy-it-now price, the deposit of the winner is captured as payment and the deposits of every other bidder are
+// released, including every deposit of an auction that closes without bids. The auction is identified by the ID set
+// with WithAuctionID, which is required.
+func WithDeposit(amount string, holds deposit.Holds) Option {
+	return func(c *config) {
+		c.depositAmount = amount
+		c.deposits = holds
+	}
+}
+
+func NewDefaultBidManager(idGenerator id_generator.IDGenerator, store storage.BidStorer, opts ...Option) (BidManager, error) {
+	return NewDefaultBidManagerOf[currency.Amount](idGenerator, store, opts...)
+}
+
+// NewDefaultBidManagerOf creates a manager whose amounts are represented by M. Use currency.BigAmount for currencies
+// with many decimal places, such as ETH, or for amounts that do not fit in the int64 of a currency.Amount.
+func NewDefaultBidManagerOf[M currency.Money[M]](idGenerator id_generator.IDGenerator, store storage.BidStorerOf[M], opts ...Option) (BidManagerOf[M], error) {
+	c, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	buyItNow, err := newBuyItNow[M](c)
+	if err != nil {
+		return nil, err
+	}
+	required, err := newDeposit(c)
+	if err != nil {
+		return nil, err
+	}
+	return &defaultBidManager[M]{
+		config:      c,
 		idGenerator: idGenerator,
 		storage:     store,
+		state:       newAuctionState[M](),
+		buyItNow:    buyItNow,
+		deposit:     required,
 	}, nil
 }
 
 // AddBid takes a bid entry as strings, then parses and saves them to be used later to calculate the winning bid.
-func (m defaultBidManager) AddBid(bidder, startingBid, maxBid, incrementAmount string) error {
-	start, err := currency.ParseAmount(startingBid)
+func (m defaultBidManager[M]) AddBid(bidder, startingBid, maxBid, incrementAmount string) error {
+	m.state.mtx.Lock()
+	defer m.state.mtx.Unlock()
+
+	event, err := m.newBid(bidder, startingBid, maxBid, incrementAmount)
 	if err != nil {
-		return errors.Join(&InvalidBidError{message: "failed to parse starting bid"}, err)
+		return m.reject(auction.Bidder(bidder), err)
 	}
+	err = m.reserve(event.Bid)
+	if err != nil {
+		return m.reject(auction.Bidder(bidder), err)
+	}
+	err = m.change(event)
+	if err != nil {
+		return errors.Join(err, m.release(event.Bid.Bidder))
+	}
+	return nil
+}
 
-	maxB, err := currency.ParseAmount(maxBid)
+// newBid parses and validates a bid entry, returning the event that places it
+func (m defaultBidManager[M]) newBid(bidder, startingBid, maxBid, incrementAmount string) (events.BidPlacedOf[M], error) {
+	if m.state.closed {
+		return events.BidPlacedOf[M]{}, &AuctionClosedError{}
+	}
+	err := m.checkAccess(auction.Bidder(bidder))
+	if err != nil {
+		return events.BidPlacedOf[M]{}, err
+	}
+	err = m.checkDeposit(auction.Bidder(bidder))
 	if err != nil {
-		return errors.Join(&InvalidBidError{message: "failed to parse max bid"}, err)
+		return events.BidPlacedOf[M]{}, err
 	}
 
-	increment, err := currency.ParseAmount(incrementAmount)
+	start, err := m.parseAmount(startingBid)
 	if err != nil {
-		return errors.Join(&InvalidBidError{message: "failed to parse increment amount"}, err)
+		return events.BidPlacedOf[M]{}, errors.Join(&InvalidBidError{message: "failed to parse starting bid"}, err)
+	}
+
+	maxB, err := m.parseAmount(maxBid)
+	if err != nil {
+		return events.BidPlacedOf[M]{}, errors.Join(&InvalidBidError{message: "failed to parse max bid"}, err)
+	}
+
+	increment, err := m.parseAmount(incrementAmount)
+	if err != nil {
+		return events.BidPlacedOf[M]{}, errors.Join(&InvalidBidError{message: "failed to parse increment amount"}, err)
+	}
+
+	rate, err := m.normalize(&start, &maxB, &increment)
+	if err != nil {
+		return events.BidPlacedOf[M]{}, err
 	}
 
 	err = m.checkValidBid(start, maxB, increment)
+	if err != nil {
+		return events.BidPlacedOf[M]{}, err
+	}
+
+	// the bid is checked before the change is committed so that an event log never contains a bid that was rejected
+	_, err = m.storage.GetBid(auction.Bidder(bidder))
+	var notFound *storage.BidderNotFoundError
+	if err == nil {
+		return events.BidPlacedOf[M]{}, &DuplicateBidError{bidder: auction.Bidder(bidder)}
+	} else if !errors.As(err, &notFound) {
+		return events.BidPlacedOf[M]{}, errors.Join(errors.New("failed to fetch bid"), err)
+	}
+
+	bid := auction.BidOf[M]{
+		Bidder:       auction.Bidder(bidder),
+		StartingBid:  start,
+		MaxBid:       maxB,
+		Increment:    increment,
+		ID:           m.idGenerator.Next(),
+		ExchangeRate: rate,
+	}
+	return events.BidPlacedOf[M]{Header: events.Header{ID: bid.ID, Auction: m.auctionID, Time: m.now()}, Bid: bid}, nil
+}
+
+// AmendBid parses the new max bid and increment and replaces them on the bidders existing bid. The bid keeps its ID,
+// so it keeps its place when breaking ties.
+func (m defaultBidManager[M]) AmendBid(bidder, maxBid, incrementAmount string) error {
+	m.state.mtx.Lock()
+	defer m.state.mtx.Unlock()
+
+	event, err := m.newAmendment(bidder, maxBid, incrementAmount)
+	if err != nil {
+		return m.reject(auction.Bidder(bidder), err)
+	}
+	err = m.reserve(event.Bid)
+	if err != nil {
+		return m.reject(auction.Bidder(bidder), err)
+	}
+	err = m.change(event)
+	if err != nil {
+		return errors.Join(err, m.restore(event.Bid.Bidder))
+	}
+	return nil
+}
+
+// newAmendment parses and validates an amendment, returning the event that amends the bid
+func (m defaultBidManager[M]) newAmendment(bidder, maxBid, incrementAmount string) (events.BidAmendedOf[M], error) {
+	if m.state.closed {
+		return events.BidAmendedOf[M]{}, &AuctionClosedError{}
+	}
+	err := m.checkAccess(auction.Bidder(bidder))
+	if err != nil {
+		return events.BidAmendedOf[M]{}, err
+	}
+
+	bid, err := m.storage.GetBid(auction.Bidder(bidder))
+	if err != nil {
+		return events.BidAmendedOf[M]{}, errors.Join(errors.New("failed to fetch bid"), err)
+	}
+
+	maxB, err := m.parseAmount(maxBid)
+	if err != nil {
+		return events.BidAmendedOf[M]{}, errors.Join(&InvalidBidError{message: "failed to parse max bid"}, err)
+	}
+
+	increment, err := m.parseAmount(incrementAmount)
+	if err != nil {
+		return events.BidAmendedOf[M]{}, errors.Join(&InvalidBidError{message: "failed to parse increment amount"}, err)
+	}
+
+	rate, err := m.normalize(&maxB, &increment)
+	if err != nil {
+		return events.BidAmendedOf[M]{}, err
+	}
+
+	err = m.checkValidBid(bid.StartingBid, maxB, increment)
+	if err != nil {
+		return events.BidAmendedOf[M]{}, err
+	}
+
+	bid.MaxBid = maxB
+	bid.Increment = increment
+	if rate != nil {
+		bid.ExchangeRate = rate
+	}
+	return events.BidAmendedOf[M]{Header: m.newHeader(), Bid: bid}, nil
+}
+
+// parseAmount parses an amount of a bid. Amounts may only be given in another currency when the manager has exchange
+// rates to convert them.
+func (m defaultBidManager[M]) parseAmount(s string) (M, error) {
+	if m.rates == nil {
+		return currency.ParseMoneyIn[M](m.locale, s, m.currency)
+	}
+	return currency.ParseMoneyWithDefault[M](m.locale, s, m.currency)
+}
+
+// normalize converts the amounts of a bid to the currency of the auction, returning the rate used to convert them or
+// nil if they were already in the currency of the auction. The amounts must all be in the same currency.
+func (m defaultBidManager[M]) normalize(amounts ...*M) (*currency.ExchangeRate, error) {
+	code := (*amounts[0]).Code()
+	for _, amount := range amounts[1:] {
+		if (*amount).Code() != code {
+			return nil, &InvalidBidError{message: "the amounts of a bid must be in the same currency"}
+		}
+	}
+	if code == m.currency {
+		return nil, nil
+	}
+
+	rate, err := m.rates.Rate(code, m.currency)
+	if err != nil {
+		return nil, errors.Join(&InvalidBidError{message: "failed to find exchange rate"}, err)
+	}
+	for _, amount := range amounts {
+		*amount, err = currency.ConvertMoney(rate, *amount, currency.RoundDown)
+		if err != nil {
+			return nil, errors.Join(&InvalidBidError{message: "failed to convert bid"}, err)
+		}
+	}
+	return &rate, nil
+}
+
+// RetractBid removes the bidders bid from the auction
+func (m defaultBidManager[M]) RetractBid(bidder string) error {
+	m.state.mtx.Lock()
+	defer m.state.mtx.Unlock()
+	if m.state.closed {
+		return &AuctionClosedError{}
+	}
+
+	_, err := m.storage.GetBid(auction.Bidder(bidder))
+	if err != nil {
+		return errors.Join(errors.New("failed to fetch bid"), err)
+	}
+	err = m.change(events.BidRetracted{Header: m.newHeader(), Bidder: auction.Bidder(bidder)})
 	if err != nil {
 		return err
 	}
+	return m.release(auction.Bidder(bidder))
+}
 
-	bid := auction.Bid{
-		Bidder:      auction.Bidder(bidder),
-		StartingBid: start,
-		MaxBid:      maxB,
-		Increment:   increment,
-		ID:          m.idGenerator.Next(),
+// Close stops the auction from accepting any more bids
+func (m defaultBidManager[M]) Close() error {
+	m.state.mtx.Lock()
+	defer m.state.mtx.Unlock()
+	if m.state.closed && m.state.settled {
+		return &AuctionClosedError{}
+	} else if m.state.closed {
+		// the auction was closed but settling it failed, or the manager was rebuilt since, so it is settled again
+		return m.settle()
 	}
+	err := m.change(events.AuctionClosed{Header: m.newHeader()})
+	if err != nil {
+		return err
+	}
+	return m.settle()
+}
 
-	err = m.storage.SaveBid(bid)
+// checkAccess checks the bidder against the access lists, if they have been configured
+func (m defaultBidManager[M]) checkAccess(bidder auction.Bidder) error {
+	if m.lists == nil {
+		return nil
+	}
+	return m.lists.Check(m.auctionID, bidder)
+}
+
+// reserve records the max bid of the bid as outstanding in the bidder registry, if one has been configured
+func (m defaultBidManager[M]) reserve(bid auction.BidOf[M]) error {
+	if m.registry == nil {
+		return nil
+	}
+	maxBid, err := currency.AmountOf(bid.MaxBid)
+	if err != nil {
+		return errors.Join(&InvalidBidError{message: "max bid is too large for the bidder registry"}, err)
+	}
+	return m.registry.Reserve(bid.Bidder, m.auctionID, maxBid)
+}
+
+// release removes the outstanding max bid of the bidder from the bidder registry, if one has been configured
+func (m defaultBidManager[M]) release(bidder auction.Bidder) error {
+	if m.registry == nil {
+		return nil
+	}
+	err := m.registry.Release(bidder, m.auctionID)
+	if err != nil {
+		return errors.Join(errors.New("failed to release max bid"), err)
+	}
+	return nil
+}
+
+// restore puts back the max bid of the bidder's stored bid in the bidder registry after a change that reserved a new
+// max bid failed, or releases it if the bidder has no bid in the auction
+func (m defaultBidManager[M]) restore(bidder auction.Bidder) error {
+	if m.registry == nil {
+		return nil
+	}
+	bid, err := m.storage.GetBid(bidder)
+	var notFound *storage.BidderNotFoundError
+	if errors.As(err, &notFound) {
+		return m.release(bidder)
+	} else if err != nil {
+		return errors.Join(errors.New("failed to fetch bid"), err)
+	}
+	maxBid, err := currency.AmountOf(bid.MaxBid)
+	if err == nil {
+		err = m.registry.Restore(registry.Exposure{Bidder: bidder, Auction: m.auctionID, MaxBid: maxBid})
+	}
+	if err != nil {
+		return errors.Join(errors.New("failed to restore max bid"), err)
+	}
+	return nil
+}
+
+// settle releases the losers of the closed auction and marks it settled once they have all been released. Settling
+// again after a failure only changes what was left, as released max bids and settled deposits are left as they are.
+// It must be called with the state lock held.
+func (m defaultBidManager[M]) settle() error {
+	err := m.releaseLosers()
 	if err != nil {
-		return errors.Join(errors.New("failed to save bid"), err)
+		return err
 	}
+	m.state.settled = true
 	return nil
 }
 
+// releaseLosers releases the max bids and deposits of every bidder except the winner once the auction has closed, and
+// captures the deposit of the winner. An auction that closes without bids has no winner, so every deposit is released.
+func (m defaultBidManager[M]) releaseLosers() error {
+	winner, err := m.winner()
+	var empty *EmptyBidListError
+	if errors.As(err, &empty) {
+		err = nil
+	} else if err != nil {
+		return err
+	}
+	err = m.settleDeposits(winner.Bidder)
+	if m.registry == nil {
+		return err
+	}
+	bids, fetchErr := m.storage.GetAllBids()
+	if fetchErr != nil {
+		return errors.Join(err, errors.New("failed to fetch bids"), fetchErr)
+	}
+	for bidder := range bids {
+		if bidder != winner.Bidder {
+			err = errors.Join(err, m.release(bidder))
+		}
+	}
+	return err
+}
+
+// change records and commits the event and then publishes it, along with the changes it caused to the standing of the
+// auction. It must be called with the state lock held.
+func (m defaultBidManager[M]) change(event events.Event) error {
+	// the standings are only needed to publish events, so they are not calculated when nobody is listening
+	var previous standing[M]
+	var err error
+	if m.publisher != nil {
+		previous, err = m.currentStanding()
+		if err != nil {
+			return err
+		}
+	}
+
+	// the change is recorded before it is committed, so that a change that is stored always has an audit entry. A
+	// change that fails to commit after it was recorded is followed by an entry for the failure.
+	err = m.record(event)
+	if err != nil {
+		return err
+	}
+	err = m.commit(event)
+	if err != nil {
+		return errors.Join(err, m.recordFailure(event, err))
+	}
+	err = disableBuyItNow(m.storage, m.state, m.buyItNow)
+	if err != nil {
+		return err
+	}
+
+	if m.publisher != nil {
+		m.publisher.Publish(event)
+		bids, err := m.storage.GetAllBids()
+		if err != nil {
+			return errors.Join(errors.New("failed to fetch bids"), err)
+		}
+		current, err := m.calculate(bids)
+		if err != nil {
+			return err
+		}
+		m.publishStandingChanges(bids, previous, current)
+	}
+	return nil
+}
+
+// reject records and publishes that a bid was rejected, then returns the reason it was rejected. Only the audit log
+// gets the error itself, and the event that is published carries its reason code.
+func (m defaultBidManager[M]) reject(bidder auction.Bidder, reason error) error {
+	event := events.BidRejected{Header: m.newHeader(), Bidder: bidder, Reason: reasonOf(reason), Detail: reason.Error()}
+	err := m.record(event)
+	if err != nil {
+		return errors.Join(reason, err)
+	}
+	if m.publisher != nil {
+		event.Detail = ""
+		m.publisher.Publish(event)
+	}
+	return reason
+}
+
+// record adds the event to the audit log, if one has been configured
+func (m defaultBidManager[M]) record(event events.Event) error {
+	if m.auditLog == nil {
+		return nil
+	}
+	err := m.auditLog.Record(event)
+	if err != nil {
+		return errors.Join(errors.New("failed to record audit entry"), err)
+	}
+	return nil
+}
+
+// recordFailure adds an entry to the audit log, if one has been configured, for a recorded event that failed to commit
+func (m defaultBidManager[M]) recordFailure(event events.Event, cause error) error {
+	if m.auditLog == nil {
+		return nil
+	}
+	err := m.auditLog.RecordFailure(event, cause)
+	if err != nil {
+		return errors.Join(errors.New("failed to record audit entry"), err)
+	}
+	return nil
+}
+
+// commit applies the event. An event sourced manager appends it to the log before applying it, so that the storage
+// is only ever a projection of the log.
+func (m defaultBidManager[M]) commit(event events.Event) error {
+	if m.log != nil {
+		err := m.log.Append(event)
+		if err != nil {
+			return errors.Join(errors.New("failed to append event"), err)
+		}
+	}
+	return apply(m.storage, m.state, event)
+}
+
+// currentStanding calculates the standing of the bids that have been saved so far. It returns an empty standing if
+// there are no bids.
+func (m defaultBidManager[M]) currentStanding() (standing[M], error) {
+	bids, err := m.storage.GetAllBids()
+	if err != nil {
+		return standing[M]{}, errors.Join(errors.New("failed to fetch bids"), err)
+	}
+	if len(bids) == 0 {
+		return standing[M]{state: bidState[M]{}}, nil
+	}
+	return m.calculate(bids)
+}
+
+// publishStandingChanges must only be called when a publisher is configured. It compares the standing before and
+// after a bid was placed. A change in leader notifies the previous leader that they were outbid, a higher price for the
+// same leader is published as a price change, and every bidder that has become unable to beat the leader is exhausted.
+// Bidders that were already behind the leader were exhausted when the previous standing was calculated.
+func (m defaultBidManager[M]) publishStandingChanges(bids auction.BidMapOf[M], previous, current standing[M]) {
+	if previous.winner.Bidder != current.winner.Bidder {
+		m.publisher.Publish(events.LeaderChangedOf[M]{Header: m.newHeader(), Previous: previous.winner, Leader: current.winner})
+		// a leader that retracted their bid was not outbid
+		if _, ok := bids[previous.winner.Bidder]; ok {
+			m.publisher.Publish(events.BidderOutbidOf[M]{Header: m.newHeader(), Bidder: previous.winner.Bidder, Leader: current.winner})
+		}
+	} else if !previous.winner.Amount.Equals(current.winner.Amount) {
+		m.publisher.Publish(events.PriceChangedOf[M]{Header: m.newHeader(), Leader: current.winner})
+	}
+
+	exhausted := []auction.BidOf[M]{}
+	for bidder := range current.state {
+		_, wasBidding := previous.state[bidder]
+		if bidder != current.winner.Bidder && (!wasBidding || bidder == previous.winner.Bidder) {
+			exhausted = append(exhausted, bids[bidder])
+		}
+	}
+	sort.Slice(exhausted, func(i, j int) bool {
+		return exhausted[i].ID < exhausted[j].ID
+	})
+	for _, bid := range exhausted {
+		m.publisher.Publish(events.BidderExhaustedOf[M]{
+			Header: m.newHeader(),
+			Bidder: bid.Bidder,
+			Amount: current.state[bid.Bidder],
+			MaxBid: bid.MaxBid,
+		})
+	}
+}
+
+// newHeader creates the header for an event that is not a bid. Events draw their IDs from the same generator as bids,
+// so the IDs order every bid and event in the auction, and carry the ID of the auction.
+func (m defaultBidManager[M]) newHeader() events.Header {
+	return events.Header{ID: m.idGenerator.Next(), Auction: m.auctionID, Time: m.now()}
+}
+
 // checkValidBid ensures that valid, non-zero or negative values, are given for the bid
-func (m defaultBidManager) checkValidBid(startingBid, maxBid, incrementAmount currency.Amount) error {
+func (m defaultBidManager[M]) checkValidBid(startingBid, maxBid, incrementAmount M) error {
 	if maxBid.Less(startingBid) {
 		return &InvalidBidError{message: fmt.Sprintf("starting bid %s cannot be larger than max bid %s", startingBid.String(), maxBid.String())}
 	}
-	if incrementAmount.Less(currency.Amount{Dollars: 0, Cents: 1}) {
-		return &InvalidBidError{message: fmt.Sprintf("bid increment %s cannot be less than 1 cent", incrementAmount.String())}
+	minimum := currency.MinorUnitOf[M](m.currency)
+	if incrementAmount.Less(minimum) {
+		return &InvalidBidError{message: fmt.Sprintf("bid increment %s cannot be less than %s", incrementAmount.String(), minimum.String())}
 	}
-	if startingBid.Less(currency.Amount{Dollars: 0, Cents: 1}) {
-		return &InvalidBidError{message: fmt.Sprintf("starting bid %s cannot be less than 1 cent", incrementAmount.String())}
+	if startingBid.Less(minimum) {
+		return &InvalidBidError{message: fmt.Sprintf("starting bid %s cannot be less than %s", startingBid.String(), minimum.String())}
 	}
-	if maxBid.Less(currency.Amount{Dollars: 0, Cents: 1}) {
-		return &InvalidBidError{message: fmt.Sprintf("max increment %s cannot be less than 1 cent", incrementAmount.String())}
+	if maxBid.Less(minimum) {
+		return &InvalidBidError{message: fmt.Sprintf("max bid %s cannot be less than %s", maxBid.String(), minimum.String())}
 	}
 	return nil
 }
@@ -83,33 +724,186 @@ func (m defaultBidManager) checkValidBid(startingBid, maxBid, incrementAmount cu
 // CalculateWinner iterates through all of the provided bids to determine what the winning bid will be. It does so in
 // 'rounds', where each round the non-current winners have their bid incremented until it is greater than the winning
 // bid or until they can no longer bid without exceeding their max bid. Once no more bids can be incremented to beat the
-// current winner, it returns the WinningBid which contains the winners name and bid amount.
-func (m defaultBidManager) CalculateWinner() (auction.WinningBid, error) {
-	var currentWinner auction.WinningBid
+// current winner, it returns the WinningBid which contains the winners name and bid amount. Bidders who defaulted or
+// declined a second-chance offer are left out, so once an offer has been accepted its bidder is the winner. If the lot
+// was bought at its buy-it-now price, the buyer is the winner.
+func (m defaultBidManager[M]) CalculateWinner() (auction.WinningBidOf[M], error) {
+	m.state.mtx.Lock()
+	defer m.state.mtx.Unlock()
 
-	bids, err := m.storage.GetAllBids()
+	winner, err := m.winner()
 	if err != nil {
-		return auction.WinningBid{}, errors.Join(errors.New("failed to fetch bids"), err)
+		return auction.WinningBidOf[M]{}, err
 	}
+	event := events.WinnerDeterminedOf[M]{Header: m.newHeader(), Winner: winner}
+	err = m.record(event)
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	if m.publisher != nil {
+		m.publisher.Publish(event)
+	}
+	return winner, nil
+}
+
+// winner returns the buyer if the lot was bought at its buy-it-now price, and otherwise calculates the winner of the
+// bids of the bidders who can still win the lot. It must be called with the state lock held.
+func (m defaultBidManager[M]) winner() (auction.WinningBidOf[M], error) {
+	if m.state.purchase != nil {
+		return *m.state.purchase, nil
+	}
+	bids, err := m.remainingBids()
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	if len(bids) == 0 {
+		return auction.WinningBidOf[M]{}, &EmptyBidListError{}
+	}
+	result, err := m.calculate(bids)
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	return result.winner, nil
+}
 
+// CalculateWinner determines the winner of a set of bids in the same way as the CalculateWinner of a manager. It is for
+// bids that are no longer held by a manager, such as those of an auction that is being settled.
+func CalculateWinner(bids auction.BidMap) (auction.WinningBid, error) {
+	return CalculateWinnerOf(bids)
+}
+
+// CalculateWinnerOf is CalculateWinner for bids whose amounts are represented by M
+func CalculateWinnerOf[M currency.Money[M]](bids auction.BidMapOf[M]) (auction.WinningBidOf[M], error) {
 	if len(bids) == 0 {
-		return auction.WinningBid{}, &EmptyBidListError{}
+		return auction.WinningBidOf[M]{}, &EmptyBidListError{}
+	}
+	result, err := defaultBidManager[M]{}.calculate(bids)
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
 	}
+	return result.winner, nil
+}
+
+// CalculateResults ranks every bidder by recalculating the winner without the bidders ranked above them, which is the
+// order the lot would be offered in if each winner defaulted. The amount of each result is the bid from the
+// calculation of the winner. Bidders who defaulted or declined a second-chance offer are left out in the same way as
+// by CalculateWinner, and a buyer at the buy-it-now price ranks first.
+func (m defaultBidManager[M]) CalculateResults() ([]auction.ResultOf[M], error) {
+	m.state.mtx.Lock()
+	defer m.state.mtx.Unlock()
+
+	bids, err := m.remainingBids()
+	if err != nil {
+		return nil, err
+	}
+	return resultsOf(bids, m.state.purchase)
+}
+
+// resultsOf ranks the bids of the bidders who can still win the lot. When the lot was bought at its buy-it-now price,
+// the buyer ranks first and the bids of everyone else are ranked after them.
+func resultsOf[M currency.Money[M]](bids auction.BidMapOf[M], purchase *auction.WinningBidOf[M]) ([]auction.ResultOf[M], error) {
+	if purchase == nil {
+		return CalculateResultsOf(bids)
+	}
+	results := []auction.ResultOf[M]{{
+		Bidder:            purchase.Bidder,
+		Amount:            purchase.Amount,
+		Rank:              1,
+		SecondChancePrice: purchase.Amount,
+	}}
+	others := auction.BidMapOf[M]{}
+	for bidder, bid := range bids {
+		if bidder != purchase.Bidder {
+			others[bidder] = bid
+		}
+	}
+	if len(others) == 0 {
+		return results, nil
+	}
+	ranked, err := CalculateResultsOf(others)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range ranked {
+		result.Rank++
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// CalculateResults ranks a set of bids in the same way as the CalculateResults of a manager
+func CalculateResults(bids auction.BidMap) ([]auction.Result, error) {
+	return CalculateResultsOf(bids)
+}
+
+// CalculateResultsOf is CalculateResults for bids whose amounts are represented by M
+func CalculateResultsOf[M currency.Money[M]](bids auction.BidMapOf[M]) ([]auction.ResultOf[M], error) {
+	if len(bids) == 0 {
+		return nil, &EmptyBidListError{}
+	}
+	m := defaultBidManager[M]{}
+	final, err := m.calculate(bids)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]auction.ResultOf[M], 0, len(bids))
+	remaining := auction.BidMapOf[M]{}
+	for bidder, bid := range bids {
+		remaining[bidder] = bid
+	}
+	winner := final.winner
+	for len(remaining) > 0 {
+		if len(results) > 0 {
+			result, err := m.calculate(remaining)
+			if err != nil {
+				return nil, err
+			}
+			winner = result.winner
+		}
+		bid := bids[winner.Bidder]
+		amount := final.state[winner.Bidder]
+		canBid, err := m.canStillBid(bid.MaxBid, amount, bid.Increment)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, auction.ResultOf[M]{
+			Bidder:            winner.Bidder,
+			Amount:            amount,
+			Rank:              len(results) + 1,
+			Exhausted:         !canBid,
+			SecondChancePrice: winner.Amount,
+		})
+		delete(remaining, winner.Bidder)
+	}
+	return results, nil
+}
+
+// calculate runs the bidding rounds until no more bids can be incremented to beat the current winner
+func (m defaultBidManager[M]) calculate(bids map[auction.Bidder]auction.BidOf[M]) (standing[M], error) {
+	var currentWinner auction.WinningBidOf[M]
 
 	state := m.initializeCalculation(bids)
 
+	var err error
 	complete := false
 	for !complete {
-		state = m.calculateBids(bids, state, currentWinner)
+		state, err = m.calculateBids(bids, state, currentWinner)
+		if err != nil {
+			return standing[M]{}, err
+		}
 		currentWinner = m.currentWinner(bids, state, currentWinner)
-		complete = m.isFinished(bids, state, currentWinner)
+		complete, err = m.isFinished(bids, state, currentWinner)
+		if err != nil {
+			return standing[M]{}, err
+		}
 	}
-	return currentWinner, nil
+	return standing[M]{winner: currentWinner, state: state}, nil
 }
 
 // initializeCalculation sets the initial rounds of bids to each person starting bid amount.
-func (m defaultBidManager) initializeCalculation(bids map[auction.Bidder]auction.Bid) bidState {
-	state := bidState{}
+func (m defaultBidManager[M]) initializeCalculation(bids map[auction.Bidder]auction.BidOf[M]) bidState[M] {
+	state := bidState[M]{}
 
 	for bidder, bid := range bids {
 		state[bidder] = bid.StartingBid
@@ -119,44 +913,54 @@ func (m defaultBidManager) initializeCalculation(bids map[auction.Bidder]auction
 
 // calculateBids checks to see if each person is bidding under the current winner and is still able to bid. It will
 // then increment their current amount until it exceeds the winner but is still under their max bid amount.
-func (m defaultBidManager) calculateBids(bids map[auction.Bidder]auction.Bid, state bidState, currentWinner auction.WinningBid) bidState {
-	newState := bidState{}
+func (m defaultBidManager[M]) calculateBids(bids map[auction.Bidder]auction.BidOf[M], state bidState[M], currentWinner auction.WinningBidOf[M]) (bidState[M], error) {
+	newState := bidState[M]{}
 	for bidder, amount := range state {
 		newAmount := amount
 		bid := bids[bidder]
-		for m.isLessThanCurrentWinner(currentWinner.Amount, newAmount) && m.canStillBid(bid.MaxBid, newAmount, bid.Increment) && bidder != currentWinner.Bidder {
-			newAmount = newAmount.Add(bid.Increment)
+		for m.isLessThanCurrentWinner(currentWinner.Amount, newAmount) && bidder != currentWinner.Bidder {
+			canBid, err := m.canStillBid(bid.MaxBid, newAmount, bid.Increment)
+			if err != nil {
+				return nil, err
+			}
+			if !canBid {
+				break
+			}
+			newAmount, err = newAmount.Add(bid.Increment)
+			if err != nil {
+				return nil, err
+			}
 		}
 		newState[bidder] = newAmount
 	}
-	return newState
+	return newState, nil
 }
 
 // currentWinner checks to see which bidder is the current winner to be used for the next round of bids or as the final
 // winner
-func (m defaultBidManager) currentWinner(bids map[auction.Bidder]auction.Bid, state bidState, currentWinner auction.WinningBid) auction.WinningBid {
+func (m defaultBidManager[M]) currentWinner(bids map[auction.Bidder]auction.BidOf[M], state bidState[M], currentWinner auction.WinningBidOf[M]) auction.WinningBidOf[M] {
 	highestBidder := currentWinner.Bidder
 	for bidder, amount := range state {
-		highestBid := state[highestBidder]
-		if amount.Greater(highestBid) {
+		highestBid, leading := state[highestBidder]
+		if !leading || amount.Greater(highestBid) {
 			highestBidder = bidder
 		} else if m.isTied(amount, highestBid) {
 			highestBidder = m.breakTie(bids[bidder].ID, bids[highestBidder].ID, bidder, highestBidder)
 		}
 	}
-	return auction.WinningBid{
+	return auction.WinningBidOf[M]{
 		Bidder: highestBidder,
 		Amount: state[highestBidder],
 	}
 }
 
 // isTied checks to see if the persons bid is tied with the current highest bid
-func (m defaultBidManager) isTied(bid, highestBid currency.Amount) bool {
+func (m defaultBidManager[M]) isTied(bid, highestBid M) bool {
 	return bid.Equals(highestBid)
 }
 
 // breakTie breaks a tie based on who has the lowest ID, which signifies that they entered their bid first
-func (m defaultBidManager) breakTie(bidderID, highestBidderID id_generator.EventID, bidder, highestBidder auction.Bidder) auction.Bidder {
+func (m defaultBidManager[M]) breakTie(bidderID, highestBidderID id_generator.EventID, bidder, highestBidder auction.Bidder) auction.Bidder {
 	if bidderID < highestBidderID {
 		return bidder
 	}
@@ -164,24 +968,32 @@ func (m defaultBidManager) breakTie(bidderID, highestBidderID id_generator.Event
 }
 
 // isFinished checks to see if there are any bids that can still be placed without exceeding the persons max bid
-func (m defaultBidManager) isFinished(bids map[auction.Bidder]auction.Bid, state bidState, currentWinner auction.WinningBid) bool {
+func (m defaultBidManager[M]) isFinished(bids map[auction.Bidder]auction.BidOf[M], state bidState[M], currentWinner auction.WinningBidOf[M]) (bool, error) {
 	complete := true
 	for bidder, amount := range state {
 		bid := bids[bidder]
-		if m.canStillBid(bid.MaxBid, amount, bid.Increment) && bidder != currentWinner.Bidder {
+		canBid, err := m.canStillBid(bid.MaxBid, amount, bid.Increment)
+		if err != nil {
+			return false, err
+		}
+		if canBid && bidder != currentWinner.Bidder {
 			complete = false
 		}
 	}
-	return complete
+	return complete, nil
 }
 
 // isLessThanCurrentWinner checks to see if the current bidder is bidding less than the winning bid
-func (m defaultBidManager) isLessThanCurrentWinner(currentWinner, amount currency.Amount) bool {
+func (m defaultBidManager[M]) isLessThanCurrentWinner(currentWinner, amount M) bool {
 	return currentWinner.Greater(amount) || currentWinner.Equals(amount)
 }
 
 // canStillBid checks to see if the different between the person max bid is less than or equal to
 // the max amount the person wants to bid, signifying that their bid can still be increased
-func (m defaultBidManager) canStillBid(maxBid, amount, increment currency.Amount) bool {
-	return maxBid.Sub(amount).Greater(increment) || maxBid.Sub(amount).Equals(increment)
+func (m defaultBidManager[M]) canStillBid(maxBid, amount, increment M) (bool, error) {
+	remaining, err := maxBid.Sub(amount)
+	if err != nil {
+		return false, err
+	}
+	return remaining.Greater(increment) || remaining.Equals(increment), nil
 }
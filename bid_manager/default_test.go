@@ -1,12 +1,20 @@
 package bid_manager
 
 import (
+	"auction/access"
 	"auction/auction"
+	"auction/audit"
 	"auction/currency"
+	"auction/deposit"
+	"auction/events"
 	"auction/id_generator"
+	"auction/ledger"
+	"auction/registry"
 	"auction/storage"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func WithDefaultBidManager() func() (BidManager, error) {
@@ -25,38 +33,38 @@ func TestManager(t *testing.T) {
 }
 
 func TestInitializeCalculation(t *testing.T) {
-	manager := &defaultBidManager{
+	manager := &defaultBidManager[currency.Amount]{
 		idGenerator: id_generator.NewMemoryIDGenerator(),
 		storage:     storage.NewMemoryBidStorage(),
 	}
 
 	bids := map[auction.Bidder]auction.Bid{
 		auction.Bidder("bidder1"): {
-			auction.Bidder("bidder1"),
-			currency.Amount{Dollars: 1, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 1, Cents: 00},
-			id_generator.EventID(1),
+			Bidder:      auction.Bidder("bidder1"),
+			StartingBid: currency.MustNew(1, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(1, 0, currency.USD),
+			ID:          id_generator.EventID(1),
 		},
 		auction.Bidder("bidder2"): {
-			auction.Bidder("bidder2"),
-			currency.Amount{Dollars: 2, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 1, Cents: 00},
-			id_generator.EventID(2),
+			Bidder:      auction.Bidder("bidder2"),
+			StartingBid: currency.MustNew(2, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(1, 0, currency.USD),
+			ID:          id_generator.EventID(2),
 		},
 		auction.Bidder("bidder3"): {
-			auction.Bidder("bidder3"),
-			currency.Amount{Dollars: 3, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 1, Cents: 00},
-			id_generator.EventID(3),
+			Bidder:      auction.Bidder("bidder3"),
+			StartingBid: currency.MustNew(3, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(1, 0, currency.USD),
+			ID:          id_generator.EventID(3),
 		},
 	}
-	expectedState := bidState{
-		auction.Bidder("bidder1"): currency.Amount{Dollars: 1, Cents: 20},
-		auction.Bidder("bidder2"): currency.Amount{Dollars: 2, Cents: 20},
-		auction.Bidder("bidder3"): currency.Amount{Dollars: 3, Cents: 20},
+	expectedState := bidState[currency.Amount]{
+		auction.Bidder("bidder1"): currency.MustNew(1, 20, currency.USD),
+		auction.Bidder("bidder2"): currency.MustNew(2, 20, currency.USD),
+		auction.Bidder("bidder3"): currency.MustNew(3, 20, currency.USD),
 	}
 
 	state := manager.initializeCalculation(bids)
@@ -66,100 +74,106 @@ func TestInitializeCalculation(t *testing.T) {
 }
 
 func TestCalculateBids(t *testing.T) {
-	manager := &defaultBidManager{
+	manager := &defaultBidManager[currency.Amount]{
 		idGenerator: id_generator.NewMemoryIDGenerator(),
 		storage:     storage.NewMemoryBidStorage(),
 	}
 
 	bids := map[auction.Bidder]auction.Bid{
 		auction.Bidder("bidder1"): {
-			auction.Bidder("bidder1"),
-			currency.Amount{Dollars: 1, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 0, Cents: 75},
-			id_generator.EventID(1),
+			Bidder:      auction.Bidder("bidder1"),
+			StartingBid: currency.MustNew(1, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(0, 75, currency.USD),
+			ID:          id_generator.EventID(1),
 		},
 		auction.Bidder("bidder2"): {
-			auction.Bidder("bidder2"),
-			currency.Amount{Dollars: 2, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 0, Cents: 30},
-			id_generator.EventID(2),
+			Bidder:      auction.Bidder("bidder2"),
+			StartingBid: currency.MustNew(2, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(0, 30, currency.USD),
+			ID:          id_generator.EventID(2),
 		},
 		auction.Bidder("bidder3"): {
-			auction.Bidder("bidder3"),
-			currency.Amount{Dollars: 3, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 1, Cents: 00},
-			id_generator.EventID(3),
+			Bidder:      auction.Bidder("bidder3"),
+			StartingBid: currency.MustNew(3, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(1, 0, currency.USD),
+			ID:          id_generator.EventID(3),
 		},
 	}
-	expectedState := bidState{
-		auction.Bidder("bidder1"): currency.Amount{Dollars: 3, Cents: 45},
-		auction.Bidder("bidder2"): currency.Amount{Dollars: 3, Cents: 40},
-		auction.Bidder("bidder3"): currency.Amount{Dollars: 3, Cents: 20},
+	expectedState := bidState[currency.Amount]{
+		auction.Bidder("bidder1"): currency.MustNew(3, 45, currency.USD),
+		auction.Bidder("bidder2"): currency.MustNew(3, 40, currency.USD),
+		auction.Bidder("bidder3"): currency.MustNew(3, 20, currency.USD),
 	}
 
 	currentWinner := auction.WinningBid{
 		Bidder: auction.Bidder("bidder3"),
-		Amount: currency.Amount{Dollars: 3, Cents: 20},
+		Amount: currency.MustNew(3, 20, currency.USD),
 	}
 
 	state := manager.initializeCalculation(bids)
 
-	state = manager.calculateBids(bids, state, currentWinner)
+	state, err := manager.calculateBids(bids, state, currentWinner)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
 	if !reflect.DeepEqual(expectedState, state) {
 		t.Fatalf("Expected state to be \n%#v\ngot \n%#v", expectedState, state)
 	}
 }
 
 func TestCurrentWinner(t *testing.T) {
-	manager := &defaultBidManager{
+	manager := &defaultBidManager[currency.Amount]{
 		idGenerator: id_generator.NewMemoryIDGenerator(),
 		storage:     storage.NewMemoryBidStorage(),
 	}
 
 	bids := map[auction.Bidder]auction.Bid{
 		auction.Bidder("bidder1"): {
-			auction.Bidder("bidder1"),
-			currency.Amount{Dollars: 1, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 0, Cents: 75},
-			id_generator.EventID(1),
+			Bidder:      auction.Bidder("bidder1"),
+			StartingBid: currency.MustNew(1, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(0, 75, currency.USD),
+			ID:          id_generator.EventID(1),
 		},
 		auction.Bidder("bidder2"): {
-			auction.Bidder("bidder2"),
-			currency.Amount{Dollars: 2, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 0, Cents: 30},
-			id_generator.EventID(2),
+			Bidder:      auction.Bidder("bidder2"),
+			StartingBid: currency.MustNew(2, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(0, 30, currency.USD),
+			ID:          id_generator.EventID(2),
 		},
 		auction.Bidder("bidder3"): {
-			auction.Bidder("bidder3"),
-			currency.Amount{Dollars: 3, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 1, Cents: 00},
-			id_generator.EventID(3),
+			Bidder:      auction.Bidder("bidder3"),
+			StartingBid: currency.MustNew(3, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(1, 0, currency.USD),
+			ID:          id_generator.EventID(3),
 		},
 	}
-	expectedState := bidState{
-		auction.Bidder("bidder1"): currency.Amount{Dollars: 3, Cents: 45},
-		auction.Bidder("bidder2"): currency.Amount{Dollars: 3, Cents: 40},
-		auction.Bidder("bidder3"): currency.Amount{Dollars: 3, Cents: 20},
+	expectedState := bidState[currency.Amount]{
+		auction.Bidder("bidder1"): currency.MustNew(3, 45, currency.USD),
+		auction.Bidder("bidder2"): currency.MustNew(3, 40, currency.USD),
+		auction.Bidder("bidder3"): currency.MustNew(3, 20, currency.USD),
 	}
 
 	expectedWinner := auction.WinningBid{
-		Bidder: auction.Bidder("bidder1"), Amount: currency.Amount{Dollars: 3, Cents: 45},
+		Bidder: auction.Bidder("bidder1"), Amount: currency.MustNew(3, 45, currency.USD),
 	}
 
 	currentWinner := auction.WinningBid{
 		Bidder: auction.Bidder("bidder3"),
-		Amount: currency.Amount{Dollars: 3, Cents: 20},
+		Amount: currency.MustNew(3, 20, currency.USD),
 	}
 
 	state := manager.initializeCalculation(bids)
 
-	state = manager.calculateBids(bids, state, currentWinner)
+	state, err := manager.calculateBids(bids, state, currentWinner)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
 
 	currentWinner = manager.currentWinner(bids, state, currentWinner)
 
@@ -169,49 +183,1117 @@ func TestCurrentWinner(t *testing.T) {
 }
 
 func TestIfFinished(t *testing.T) {
-	manager := &defaultBidManager{
+	manager := &defaultBidManager[currency.Amount]{
 		idGenerator: id_generator.NewMemoryIDGenerator(),
 		storage:     storage.NewMemoryBidStorage(),
 	}
 
 	bids := map[auction.Bidder]auction.Bid{
 		auction.Bidder("bidder1"): {
-			auction.Bidder("bidder1"),
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 0, Cents: 75},
-			id_generator.EventID(1),
+			Bidder:      auction.Bidder("bidder1"),
+			StartingBid: currency.MustNew(5, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(0, 75, currency.USD),
+			ID:          id_generator.EventID(1),
 		},
 		auction.Bidder("bidder2"): {
-			auction.Bidder("bidder2"),
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 0, Cents: 30},
-			id_generator.EventID(2),
+			Bidder:      auction.Bidder("bidder2"),
+			StartingBid: currency.MustNew(5, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(0, 30, currency.USD),
+			ID:          id_generator.EventID(2),
 		},
 		auction.Bidder("bidder3"): {
-			auction.Bidder("bidder3"),
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 5, Cents: 20},
-			currency.Amount{Dollars: 1, Cents: 00},
-			id_generator.EventID(3),
+			Bidder:      auction.Bidder("bidder3"),
+			StartingBid: currency.MustNew(5, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 20, currency.USD),
+			Increment:   currency.MustNew(1, 0, currency.USD),
+			ID:          id_generator.EventID(3),
 		},
 	}
 
 	currentWinner := auction.WinningBid{
 		Bidder: auction.Bidder("bidder3"),
-		Amount: currency.Amount{Dollars: 3, Cents: 20},
+		Amount: currency.MustNew(3, 20, currency.USD),
 	}
 
 	state := manager.initializeCalculation(bids)
 
-	state = manager.calculateBids(bids, state, currentWinner)
+	state, err := manager.calculateBids(bids, state, currentWinner)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
 
 	currentWinner = manager.currentWinner(bids, state, currentWinner)
 
-	finished := manager.isFinished(bids, state, currentWinner)
+	finished, err := manager.isFinished(bids, state, currentWinner)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
 
 	if !finished {
 		t.Fatalf("Expected calculation to be complete and was not")
 	}
 }
+
+func TestPublishedEvents(t *testing.T) {
+	broker := events.NewMemoryBroker()
+	sub := broker.Subscribe(100, events.Block)
+	defer sub.Close()
+
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithPublisher(broker))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+
+	bids := [][]string{
+		{"Sasha", "$50.00", "$80.00", "$3.00"},
+		{"John", "$60.00", "$82.00", "$2.00"},
+		{"Pat", "$55.00", "$85.00", "$5.00"},
+	}
+	for _, bid := range bids {
+		err = manager.AddBid(bid[0], bid[1], bid[2], bid[3])
+		if err != nil {
+			t.Fatalf("Failed to add bid: %s", err.Error())
+		}
+	}
+	err = manager.Close()
+	if err != nil {
+		t.Fatalf("Failed to close auction: %s", err.Error())
+	}
+	_, err = manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+
+	type expectedEvent struct {
+		kind   events.Kind
+		bidder auction.Bidder
+	}
+	expected := []expectedEvent{
+		{events.KindBidPlaced, "Sasha"},
+		{events.KindLeaderChanged, "Sasha"},
+		{events.KindBidPlaced, "John"},
+		{events.KindLeaderChanged, "John"},
+		{events.KindBidderOutbid, "Sasha"},
+		{events.KindBidderExhausted, "Sasha"},
+		{events.KindBidPlaced, "Pat"},
+		{events.KindLeaderChanged, "Pat"},
+		{events.KindBidderOutbid, "John"},
+		{events.KindBidderExhausted, "John"},
+		{events.KindAuctionClosed, ""},
+		{events.KindWinnerDetermined, "Pat"},
+	}
+	if len(sub.Events()) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(sub.Events()))
+	}
+
+	var lastID id_generator.EventID
+	for _, exp := range expected {
+		event := <-sub.Events()
+		var bidder auction.Bidder
+		switch e := event.(type) {
+		case events.BidPlaced:
+			bidder = e.Bid.Bidder
+		case events.LeaderChanged:
+			bidder = e.Leader.Bidder
+		case events.BidderOutbid:
+			bidder = e.Bidder
+		case events.BidderExhausted:
+			bidder = e.Bidder
+		case events.WinnerDetermined:
+			bidder = e.Winner.Bidder
+		}
+		if event.Kind() != exp.kind || bidder != exp.bidder {
+			t.Fatalf("Expected %s event for %q, got %s event for %q", exp.kind, exp.bidder, event.Kind(), bidder)
+		}
+		if event.EventID() <= lastID {
+			t.Fatalf("Expected event IDs to increase, got %d after %d", event.EventID(), lastID)
+		}
+		lastID = event.EventID()
+	}
+}
+
+func TestPublishedPriceChange(t *testing.T) {
+	broker := events.NewMemoryBroker()
+	sub := broker.Subscribe(100, events.Block)
+	defer sub.Close()
+
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithPublisher(broker))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	err = manager.AddBid("Pat", "$55.00", "$85.00", "$5.00")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+	err = manager.AddBid("Sasha", "$50.00", "$60.00", "$3.00")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+
+	expected := []events.Kind{
+		events.KindBidPlaced,
+		events.KindLeaderChanged,
+		events.KindBidPlaced,
+		events.KindPriceChanged,
+		events.KindBidderExhausted,
+	}
+	if len(sub.Events()) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(sub.Events()))
+	}
+	for _, kind := range expected {
+		event := <-sub.Events()
+		if event.Kind() != kind {
+			t.Fatalf("Expected %s event, got %s", kind, event.Kind())
+		}
+		if priceChanged, ok := event.(events.PriceChanged); ok {
+			expectedLeader := auction.WinningBid{Bidder: "Pat", Amount: currency.MustNew(60, 0, currency.USD)}
+			if !reflect.DeepEqual(expectedLeader, priceChanged.Leader) {
+				t.Fatalf("Expected leader %#v, got %#v", expectedLeader, priceChanged.Leader)
+			}
+		}
+	}
+}
+
+func TestPublishedRetraction(t *testing.T) {
+	broker := events.NewMemoryBroker()
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithPublisher(broker))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	err = manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+	err = manager.AddBid("Pat", "$55.00", "$85.00", "$5.00")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+
+	sub := broker.Subscribe(100, events.Block)
+	defer sub.Close()
+	err = manager.RetractBid("Pat")
+	if err != nil {
+		t.Fatalf("Failed to retract bid: %s", err.Error())
+	}
+
+	expected := []events.Kind{events.KindBidRetracted, events.KindLeaderChanged}
+	if len(sub.Events()) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(sub.Events()))
+	}
+	for _, kind := range expected {
+		event := <-sub.Events()
+		if event.Kind() != kind {
+			t.Fatalf("Expected %s event, got %s", kind, event.Kind())
+		}
+		if leaderChanged, ok := event.(events.LeaderChanged); ok && leaderChanged.Leader.Bidder != auction.Bidder("Sasha") {
+			t.Fatalf("Expected Sasha to lead after the retraction, got %s", leaderChanged.Leader.Bidder)
+		}
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	store := audit.NewMemoryEntryStorage()
+	log := audit.NewLog(store)
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithAuditLog(log))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+
+	err = manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+	err = manager.AddBid("Pat", "$55.00", "$85.00", "$0")
+	if _, ok := err.(*InvalidBidError); !ok {
+		t.Fatalf("Expected InvalidBidError but got %#v", err)
+	}
+	err = manager.AmendBid("Sasha", "$90.00", "$3.00")
+	if err != nil {
+		t.Fatalf("Failed to amend bid: %s", err.Error())
+	}
+	_, err = manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+
+	entries, err := store.GetAll()
+	if err != nil {
+		t.Fatalf("Failed to get audit entries: %s", err.Error())
+	}
+	expected := []events.Kind{events.KindBidPlaced, events.KindBidRejected, events.KindBidAmended, events.KindWinnerDetermined}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d audit entries, got %d", len(expected), len(entries))
+	}
+	for i, kind := range expected {
+		if entries[i].Kind != kind {
+			t.Fatalf("Expected audit entry %d to be %s, got %s", i+1, kind, entries[i].Kind)
+		}
+	}
+	if entries[1].Details.Bidder != "Pat" || entries[1].Details.Reason == "" {
+		t.Fatalf("Expected the rejection to record the bidder and reason, got %#v", entries[1].Details)
+	}
+
+	err = log.Verify()
+	if err != nil {
+		t.Fatalf("Expected audit log to verify, got: %s", err.Error())
+	}
+}
+
+func TestCurrencyAuction(t *testing.T) {
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithCurrency(currency.JPY))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+
+	bids := [][]string{
+		{"Sasha", "¥5000", "¥8000", "¥300"},
+		{"Pat", "5500", "8500", "500"},
+	}
+	for _, bid := range bids {
+		err = manager.AddBid(bid[0], bid[1], bid[2], bid[3])
+		if err != nil {
+			t.Fatalf("Failed to add bid: %s", err.Error())
+		}
+	}
+
+	err = manager.AddBid("John", "$60.00", "$82.00", "$2.00")
+	var mismatch *currency.CurrencyMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected CurrencyMismatchError but got %#v", err)
+	}
+	err = manager.AddBid("John", "¥60.50", "¥82", "¥2")
+	var invalidFormat *currency.InvalidCurrencyFormatError
+	if !errors.As(err, &invalidFormat) {
+		t.Fatalf("Expected InvalidCurrencyFormatError but got %#v", err)
+	}
+
+	expected := auction.WinningBid{Bidder: "Pat", Amount: currency.MustNew(8500, 0, currency.JPY)}
+	winner, err := manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, winner) {
+		t.Fatalf("Expected %#v, got %#v", expected, winner)
+	}
+}
+
+func TestUnknownCurrency(t *testing.T) {
+	_, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithCurrency("XXX"))
+	if _, ok := err.(*currency.UnknownCurrencyError); !ok {
+		t.Fatalf("Expected UnknownCurrencyError but got %#v", err)
+	}
+}
+
+func TestForeignCurrencyBid(t *testing.T) {
+	rate := currency.ExchangeRate{From: currency.EUR, To: currency.USD, Rate: currency.NewRate(10842, 4)}
+	rates, err := currency.NewStaticRateProvider(rate)
+	if err != nil {
+		t.Fatalf("could not initialize rates: %s", err.Error())
+	}
+	store := storage.NewMemoryBidStorage()
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), store, WithExchangeRates(rates))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+
+	err = manager.AddBid("Sasha", "€50.00", "€80.00", "€3.00")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+	err = manager.AddBid("Pat", "55.00", "85.00", "5.00")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+
+	bid, err := store.GetBid("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to get bid: %s", err.Error())
+	}
+	expected := auction.Bid{
+		Bidder:       "Sasha",
+		StartingBid:  currency.MustNew(54, 21, currency.USD),
+		MaxBid:       currency.MustNew(86, 73, currency.USD),
+		Increment:    currency.MustNew(3, 25, currency.USD),
+		ID:           bid.ID,
+		ExchangeRate: &rate,
+	}
+	if !reflect.DeepEqual(expected, bid) {
+		t.Fatalf("Expected %#v, got %#v", expected, bid)
+	}
+
+	err = manager.AddBid("John", "£60.00", "£82.00", "£2.00")
+	var notFound *currency.RateNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected RateNotFoundError but got %#v", err)
+	}
+	err = manager.AddBid("John", "€60.00", "$82.00", "€2.00")
+	if _, ok := err.(*InvalidBidError); !ok {
+		t.Fatalf("Expected InvalidBidError but got %#v", err)
+	}
+
+	winner, err := manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if winner.Bidder != "Sasha" || winner.Amount.Code() != currency.USD {
+		t.Fatalf("Expected Sasha to win in USD, got %#v", winner)
+	}
+}
+
+func TestLocaleBids(t *testing.T) {
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithCurrency(currency.EUR), WithLocale(currency.DeDE))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+
+	err = manager.AddBid("Sasha", "1.000,00 €", "1.500,50 €", "25 €")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+	err = manager.AddBid("Pat", "EUR 1.100", "EUR 1.400", "EUR 50")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+	err = manager.AddBid("John", "1,000.00 €", "1,500.00 €", "25 €")
+	var invalidFormat *currency.InvalidCurrencyFormatError
+	if !errors.As(err, &invalidFormat) {
+		t.Fatalf("Expected InvalidCurrencyFormatError but got %#v", err)
+	}
+
+	expected := auction.WinningBid{Bidder: "Sasha", Amount: currency.MustNew(1425, 0, currency.EUR)}
+	winner, err := manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, winner) {
+		t.Fatalf("Expected %#v, got %#v", expected, winner)
+	}
+}
+
+func TestBigAmountAuction(t *testing.T) {
+	broker := events.NewMemoryBroker()
+	sub := broker.Subscribe(100, events.Block, events.KindLeaderChanged, events.KindPriceChanged)
+	defer sub.Close()
+	entries := audit.NewMemoryEntryStorage()
+	manager, err := NewDefaultBidManagerOf[currency.BigAmount](
+		id_generator.NewMemoryIDGenerator(),
+		storage.NewMemoryBidStorageOf[currency.BigAmount](),
+		WithCurrency(currency.ETH),
+		WithPublisher(broker),
+		WithAuditLog(audit.NewLog(entries)),
+	)
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+
+	// every amount is more wei than fits in an int64
+	bids := [][]string{
+		{"Sasha", "Ξ10", "Ξ25", "Ξ1"},
+		{"Pat", "ETH 12.5", "ETH 20.000000000000000001", "ETH 0.5"},
+	}
+	for _, bid := range bids {
+		err = manager.AddBid(bid[0], bid[1], bid[2], bid[3])
+		if err != nil {
+			t.Fatalf("Failed to add bid: %s", err.Error())
+		}
+	}
+	err = manager.AddBid("John", "Ξ10.0000000000000000001", "Ξ11", "Ξ1")
+	var invalidFormat *currency.InvalidCurrencyFormatError
+	if !errors.As(err, &invalidFormat) {
+		t.Fatalf("Expected InvalidCurrencyFormatError but got %#v", err)
+	}
+
+	winner, err := manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if winner.Bidder != "Sasha" || winner.Amount.Text() != "ETH 20.000000000000000000" {
+		t.Fatalf("Expected Sasha to win the tie with ETH 20, got %s with %s", winner.Bidder, winner.Amount.Text())
+	}
+
+	leaders := []string{}
+	for len(sub.Events()) > 0 {
+		payload := events.NewPayload(<-sub.Events())
+		leaders = append(leaders, string(payload.Leader)+" "+payload.LeaderAmount)
+	}
+	expectedLeaders := []string{"Sasha Ξ10.000000000000000000", "Sasha Ξ20.000000000000000000"}
+	if !reflect.DeepEqual(expectedLeaders, leaders) {
+		t.Fatalf("Expected leaders %v, got %v", expectedLeaders, leaders)
+	}
+
+	recorded, err := entries.GetAll()
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %s", err.Error())
+	}
+	last := recorded[len(recorded)-1]
+	if last.Kind != events.KindWinnerDetermined || last.Details.Amount != "Ξ20.000000000000000000" {
+		t.Fatalf("Expected the winner to be audited, got %#v", last)
+	}
+}
+
+func TestExpiredOffer(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithClock(clock), WithOfferWindow(time.Hour))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	err = errors.Join(
+		manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00"),
+		manager.AddBid("John", "$60.00", "$82.00", "$2.00"),
+		manager.AddBid("Pat", "$55.00", "$85.00", "$5.00"),
+		manager.Close(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to set up auction: %s", err.Error())
+	}
+
+	offer, err := manager.DeclareDefault("Pat")
+	if err != nil {
+		t.Fatalf("Failed to declare default: %s", err.Error())
+	}
+	if !offer.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("Expected the offer to expire in an hour, got %s", offer.ExpiresAt)
+	}
+
+	now = now.Add(2 * time.Hour)
+	_, err = manager.AcceptOffer("John")
+	if _, ok := err.(*OfferExpiredError); !ok {
+		t.Fatalf("Expected OfferExpiredError but got %#v", err)
+	}
+	offer, err = manager.DeclineOffer("John")
+	if err != nil {
+		t.Fatalf("Failed to decline expired offer: %s", err.Error())
+	}
+	if offer.Bidder != "Sasha" || !offer.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("Expected a new offer to Sasha, got %#v", offer)
+	}
+}
+
+func TestBuyItNow(t *testing.T) {
+	type bid struct {
+		bidder     string
+		initialBid string
+		maxBid     string
+		increment  string
+	}
+	type testCase struct {
+		name   string
+		opts   []Option
+		bids   []bid
+		winner auction.WinningBid
+		err    error
+	}
+	threshold := currency.NewRate(50, 0)
+	testCases := []testCase{
+		{
+			name:   "No Bids",
+			opts:   []Option{WithBuyItNow("$120.00", threshold)},
+			winner: auction.WinningBid{Bidder: "Riley", Amount: currency.MustNew(120, 0, currency.USD)},
+		},
+		{
+			name:   "Below Threshold",
+			opts:   []Option{WithBuyItNow("$120.00", threshold)},
+			bids:   []bid{{"Sasha", "$50.00", "$80.00", "$3.00"}},
+			winner: auction.WinningBid{Bidder: "Riley", Amount: currency.MustNew(120, 0, currency.USD)},
+		},
+		{
+			// Sasha and John bid each other up past $60.00
+			name: "Threshold Reached",
+			opts: []Option{WithBuyItNow("$120.00", threshold)},
+			bids: []bid{{"Sasha", "$50.00", "$80.00", "$3.00"}, {"John", "$60.00", "$82.00", "$2.00"}},
+			err:  &BuyItNowUnavailableError{},
+		},
+		{
+			name: "No Buy It Now Price",
+			err:  &BuyItNowUnavailableError{},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), test.opts...)
+			if err != nil {
+				t.Fatalf("could not initialize manager: %s", err.Error())
+			}
+			for _, bid := range test.bids {
+				err = manager.AddBid(bid.bidder, bid.initialBid, bid.maxBid, bid.increment)
+				if err != nil {
+					t.Fatalf("Failed to add bid: %s", err.Error())
+				}
+			}
+
+			winner, err := manager.BuyItNow("Riley")
+			if test.err != nil {
+				if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+					t.Fatalf("Expected %T but got %#v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to buy it now: %s", err.Error())
+			}
+			if !reflect.DeepEqual(test.winner, winner) {
+				t.Fatalf("Expected %#v, got %#v", test.winner, winner)
+			}
+
+			calculated, err := manager.CalculateWinner()
+			if err != nil {
+				t.Fatalf("Failed to calculate winner: %s", err.Error())
+			}
+			if !reflect.DeepEqual(test.winner, calculated) {
+				t.Fatalf("Expected the buyer to be the winner %#v, got %#v", test.winner, calculated)
+			}
+			results, err := manager.CalculateResults()
+			if err != nil {
+				t.Fatalf("Failed to calculate results: %s", err.Error())
+			}
+			if len(results) != len(test.bids)+1 || results[0].Bidder != test.winner.Bidder || results[0].Rank != 1 || results[0].Amount != test.winner.Amount {
+				t.Fatalf("Expected the buyer to rank first ahead of every bidder, got %#v", results)
+			}
+			err = manager.AddBid("Pat", "$55.00", "$85.00", "$5.00")
+			if _, ok := err.(*AuctionClosedError); !ok {
+				t.Fatalf("Expected AuctionClosedError but got %#v", err)
+			}
+		})
+	}
+}
+
+func TestInvalidBuyItNow(t *testing.T) {
+	opts := [][]Option{
+		{WithBuyItNow("$0.00", currency.NewRate(50, 0))},
+		{WithBuyItNow("€120.00", currency.NewRate(50, 0))},
+		{WithBuyItNow("$120.00", currency.NewRate(0, 0))},
+	}
+	for _, opt := range opts {
+		_, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), opt...)
+		var invalid *InvalidBuyItNowError
+		if !errors.As(err, &invalid) {
+			t.Fatalf("Expected InvalidBuyItNowError but got %#v", err)
+		}
+	}
+}
+
+func TestBidderRegistry(t *testing.T) {
+	bidders := registry.NewRegistry(registry.Config{}, registry.NewMemoryStorage())
+	profiles := []struct {
+		id       auction.Bidder
+		limit    int64
+		verified bool
+	}{
+		{"Sasha", 100, true},
+		{"John", 100, false},
+		{"Pat", 150, true},
+		{"Morgan", 100, true},
+	}
+	for _, profile := range profiles {
+		_, err := bidders.Register(profile.id, string(profile.id), currency.MustNew(profile.limit, 0, currency.USD))
+		if err == nil && profile.verified {
+			_, err = bidders.SetStatus(profile.id, registry.StatusVerified)
+		}
+		if err != nil {
+			t.Fatalf("Failed to register bidder: %s", err.Error())
+		}
+	}
+	// Pat already has $100.00 outstanding in another auction
+	err := bidders.Reserve("Pat", "auction-2", currency.MustNew(100, 0, currency.USD))
+	if err != nil {
+		t.Fatalf("Failed to reserve max bid: %s", err.Error())
+	}
+
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithAuctionID("auction-1"), WithBidderRegistry(bidders))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	err = errors.Join(
+		manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00"),
+		manager.AddBid("Morgan", "$40.00", "$70.00", "$5.00"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to add bids: %s", err.Error())
+	}
+
+	err = manager.AddBid("John", "$60.00", "$82.00", "$2.00")
+	if _, ok := err.(*registry.UnverifiedBidderError); !ok {
+		t.Fatalf("Expected UnverifiedBidderError but got %#v", err)
+	}
+	err = manager.AddBid("Pat", "$55.00", "$85.00", "$5.00")
+	if _, ok := err.(*registry.CreditLimitExceededError); !ok {
+		t.Fatalf("Expected CreditLimitExceededError but got %#v", err)
+	}
+	err = manager.AddBid("Riley", "$55.00", "$85.00", "$5.00")
+	if _, ok := err.(*registry.ProfileNotFoundError); !ok {
+		t.Fatalf("Expected ProfileNotFoundError but got %#v", err)
+	}
+	err = manager.AmendBid("Sasha", "$120.00", "$3.00")
+	if _, ok := err.(*registry.CreditLimitExceededError); !ok {
+		t.Fatalf("Expected CreditLimitExceededError but got %#v", err)
+	}
+
+	// Sasha wins, so only Morgan's max bid is released when the auction closes
+	err = manager.Close()
+	if err != nil {
+		t.Fatalf("Failed to close auction: %s", err.Error())
+	}
+	expected := map[auction.Bidder]currency.Amount{
+		"Sasha":  currency.MustNew(80, 0, currency.USD),
+		"Morgan": currency.MustNew(0, 0, currency.USD),
+	}
+	for bidder, amount := range expected {
+		outstanding, err := bidders.Outstanding(bidder)
+		if err != nil {
+			t.Fatalf("Failed to get outstanding max bids: %s", err.Error())
+		}
+		if outstanding != amount {
+			t.Fatalf("Expected %s to have %s outstanding, got %s", bidder, amount, outstanding)
+		}
+	}
+
+	// Sasha's max bid is released when they default, and Morgan is held to the price of the offer once they accept it
+	offer, err := manager.DeclareDefault("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to declare default: %s", err.Error())
+	}
+	_, err = manager.AcceptOffer(string(offer.Bidder))
+	if err != nil {
+		t.Fatalf("Failed to accept offer: %s", err.Error())
+	}
+	expected = map[auction.Bidder]currency.Amount{
+		"Sasha":  currency.MustNew(0, 0, currency.USD),
+		"Morgan": offer.Amount,
+	}
+	for bidder, amount := range expected {
+		outstanding, err := bidders.Outstanding(bidder)
+		if err != nil {
+			t.Fatalf("Failed to get outstanding max bids: %s", err.Error())
+		}
+		if outstanding != amount {
+			t.Fatalf("Expected %s to have %s outstanding, got %s", bidder, amount, outstanding)
+		}
+	}
+}
+
+func TestBidderRegistry_OtherCurrency(t *testing.T) {
+	bidders := registry.NewRegistry(registry.Config{}, registry.NewMemoryStorage())
+	_, err := bidders.Register("Sasha", "Sasha", currency.MustNew(100, 0, currency.EUR))
+	if err == nil {
+		_, err = bidders.SetStatus("Sasha", registry.StatusVerified)
+	}
+	if err != nil {
+		t.Fatalf("Failed to register bidder: %s", err.Error())
+	}
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithAuctionID("auction-1"), WithBidderRegistry(bidders))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	err = manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00")
+	if _, ok := err.(*registry.CurrencyMismatchError); !ok {
+		t.Fatalf("Expected CurrencyMismatchError but got %#v", err)
+	}
+}
+
+func TestAccessLists(t *testing.T) {
+	lists := access.NewLists(access.Config{}, access.NewMemoryStorage())
+	_, err := lists.Block(access.AuctionScope("auction-1"), "Pat", "non-payer")
+	if err != nil {
+		t.Fatalf("Failed to block bidder: %s", err.Error())
+	}
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithAuctionID("auction-1"), WithAccessLists(lists))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+
+	err = manager.AddBid("Pat", "$55.00", "$85.00", "$5.00")
+	if _, ok := err.(*access.AccessDeniedError); !ok {
+		t.Fatalf("Expected AccessDeniedError but got %#v", err)
+	}
+	err = manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+
+	// Sasha is blocked after bidding, so they can no longer amend their bid
+	_, err = lists.Block(access.GlobalScope(), "Sasha", "")
+	if err != nil {
+		t.Fatalf("Failed to block bidder: %s", err.Error())
+	}
+	err = manager.AmendBid("Sasha", "$90.00", "$3.00")
+	if _, ok := err.(*access.AccessDeniedError); !ok {
+		t.Fatalf("Expected AccessDeniedError but got %#v", err)
+	}
+}
+
+func newDepositHolds() deposit.Holds {
+	book := ledger.NewLedger(ledger.Config{}, id_generator.NewMemoryIDGenerator(), ledger.NewMemoryStorage())
+	return deposit.NewHolds(deposit.Config{}, book, deposit.NewMemoryStorage())
+}
+
+func TestDeposits(t *testing.T) {
+	holds := newDepositHolds()
+	for _, bidder := range []auction.Bidder{"Sasha", "John", "Pat"} {
+		_, err := holds.Place("auction-1", bidder, currency.MustNew(50, 0, currency.USD))
+		if err != nil {
+			t.Fatalf("Failed to place hold: %s", err.Error())
+		}
+	}
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithAuctionID("auction-1"), WithDeposit("$50.00", holds))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+
+	err = manager.AddBid("Riley", "$10.00", "$100.00", "$1.00")
+	if _, ok := err.(*deposit.InsufficientHoldError); !ok {
+		t.Fatalf("Expected InsufficientHoldError but got %#v", err)
+	}
+	for _, bid := range [][]string{{"Sasha", "$50.00", "$80.00", "$3.00"}, {"John", "$60.00", "$82.00", "$2.00"}} {
+		err = manager.AddBid(bid[0], bid[1], bid[2], bid[3])
+		if err != nil {
+			t.Fatalf("Failed to add bid: %s", err.Error())
+		}
+	}
+
+	// the deposits are only settled once the auction has closed
+	_, err = manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	hold, err := holds.GetHold("auction-1", "Sasha")
+	if err != nil || hold.Status != deposit.StatusHeld {
+		t.Fatalf("Expected Sasha's deposit to still be held, got %#v and %#v", hold, err)
+	}
+
+	err = manager.Close()
+	if err != nil {
+		t.Fatalf("Failed to close auction: %s", err.Error())
+	}
+	// Pat held a deposit without bidding, so it is released along with Sasha's
+	expected := map[auction.Bidder]deposit.Status{"Sasha": deposit.StatusReleased, "John": deposit.StatusCaptured, "Pat": deposit.StatusReleased}
+	for bidder, status := range expected {
+		hold, err := holds.GetHold("auction-1", bidder)
+		if err != nil {
+			t.Fatalf("Failed to get hold: %s", err.Error())
+		}
+		if hold.Status != status {
+			t.Fatalf("Expected the deposit of %s to be %s, got %s", bidder, status, hold.Status)
+		}
+	}
+
+	// John defaults, so the house keeps the deposit captured from them, and Sasha has nothing to forfeit if they do too
+	_, err = manager.DeclareDefault("John")
+	if err != nil {
+		t.Fatalf("Failed to declare default: %s", err.Error())
+	}
+	hold, err = holds.GetHold("auction-1", "John")
+	if err != nil || hold.Status != deposit.StatusForfeited {
+		t.Fatalf("Expected John's deposit to be forfeited, got %#v and %#v", hold, err)
+	}
+	_, err = manager.AcceptOffer("Sasha")
+	if err == nil {
+		_, err = manager.DeclareDefault("Sasha")
+	}
+	var noRunnerUp *NoRunnerUpError
+	if !errors.As(err, &noRunnerUp) {
+		t.Fatalf("Expected NoRunnerUpError but got %#v", err)
+	}
+	hold, err = holds.GetHold("auction-1", "Sasha")
+	if err != nil || hold.Status != deposit.StatusReleased {
+		t.Fatalf("Expected Sasha's deposit to stay released, got %#v and %#v", hold, err)
+	}
+}
+
+// unsettledHolds are holds that fail to settle while they are down
+type unsettledHolds struct {
+	deposit.Holds
+	down *bool
+}
+
+func (u unsettledHolds) Settle(id auction.ID, winner auction.Bidder) ([]deposit.Hold, error) {
+	if *u.down {
+		return nil, errors.New("storage is unavailable")
+	}
+	return u.Holds.Settle(id, winner)
+}
+
+func TestDeposits_SettleFailure(t *testing.T) {
+	down := true
+	holds := unsettledHolds{Holds: newDepositHolds(), down: &down}
+	for _, bidder := range []auction.Bidder{"Sasha", "John"} {
+		_, err := holds.Place("auction-1", bidder, currency.MustNew(50, 0, currency.USD))
+		if err != nil {
+			t.Fatalf("Failed to place hold: %s", err.Error())
+		}
+	}
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithAuctionID("auction-1"), WithDeposit("$50.00", holds))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	err = errors.Join(
+		manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00"),
+		manager.AddBid("John", "$60.00", "$82.00", "$2.00"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to add bids: %s", err.Error())
+	}
+
+	err = manager.Close()
+	if err == nil {
+		t.Fatalf("Expected an error when the deposits cannot be settled")
+	}
+	_, err = manager.DeclareDefault("John")
+	if err == nil {
+		t.Fatalf("Expected a default to wait until the deposits are settled")
+	}
+
+	// the auction stays closed, and closing it again settles the deposits once the holds recover
+	down = false
+	err = manager.AddBid("Pat", "$55.00", "$85.00", "$5.00")
+	if _, ok := err.(*AuctionClosedError); !ok {
+		t.Fatalf("Expected AuctionClosedError but got %#v", err)
+	}
+	err = manager.Close()
+	if err != nil {
+		t.Fatalf("Failed to settle the closed auction: %s", err.Error())
+	}
+	hold, err := holds.GetHold("auction-1", "John")
+	if err != nil || hold.Status != deposit.StatusCaptured {
+		t.Fatalf("Expected John's deposit to be captured, got %#v and %#v", hold, err)
+	}
+	if _, ok := manager.Close().(*AuctionClosedError); !ok {
+		t.Fatalf("Expected closing a settled auction to return AuctionClosedError")
+	}
+}
+
+func TestDeposits_NoBids(t *testing.T) {
+	holds := newDepositHolds()
+	_, err := holds.Place("auction-1", "Sasha", currency.MustNew(50, 0, currency.USD))
+	if err != nil {
+		t.Fatalf("Failed to place hold: %s", err.Error())
+	}
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithAuctionID("auction-1"), WithDeposit("$50.00", holds))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	err = manager.Close()
+	if err != nil {
+		t.Fatalf("Failed to close auction: %s", err.Error())
+	}
+	hold, err := holds.GetHold("auction-1", "Sasha")
+	if err != nil || hold.Status != deposit.StatusReleased {
+		t.Fatalf("Expected Sasha's deposit to be released when the auction closed without bids, got %#v and %#v", hold, err)
+	}
+}
+
+func TestInvalidDeposit(t *testing.T) {
+	holds := newDepositHolds()
+	deposits := []Option{WithDeposit("$0.00", holds), WithDeposit("fifty", holds), WithDeposit("$50.00", nil)}
+	for _, opt := range deposits {
+		_, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), WithAuctionID("auction-1"), opt)
+		var invalid *InvalidDepositError
+		if !errors.As(err, &invalid) {
+			t.Fatalf("Expected InvalidDepositError but got %#v", err)
+		}
+	}
+}
+
+func TestMissingAuctionID(t *testing.T) {
+	opts := []Option{
+		WithDeposit("$50.00", newDepositHolds()),
+		WithBidderRegistry(registry.NewRegistry(registry.Config{}, registry.NewMemoryStorage())),
+		WithAccessLists(access.NewLists(access.Config{}, access.NewMemoryStorage())),
+	}
+	for _, opt := range opts {
+		_, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), opt)
+		if _, ok := err.(*MissingAuctionIDError); !ok {
+			t.Fatalf("Expected MissingAuctionIDError but got %#v", err)
+		}
+	}
+}
+
+// failingEntryStorage is an audit store that cannot append entries
+type failingEntryStorage struct{}
+
+func (f failingEntryStorage) Append(entry audit.Entry) error {
+	return errors.New("disk full")
+}
+
+func (f failingEntryStorage) GetAll() ([]audit.Entry, error) {
+	return nil, nil
+}
+
+func TestAuditLog_Failure(t *testing.T) {
+	store := storage.NewMemoryBidStorage()
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), store, WithAuditLog(audit.NewLog(failingEntryStorage{})))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	err = manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00")
+	if err == nil {
+		t.Fatalf("Expected an error when the bid cannot be audited")
+	}
+
+	// a bid that could not be audited is never stored, so it can be placed again once the log recovers
+	bids, err := store.GetAllBids()
+	if err != nil {
+		t.Fatalf("Failed to get bids: %s", err.Error())
+	}
+	if len(bids) != 0 {
+		t.Fatalf("Expected no bids to be stored, got %#v", bids)
+	}
+}
+
+// failingBidStorage is a bid store that cannot save bids
+type failingBidStorage struct {
+	storage.BidStorer
+}
+
+func (f failingBidStorage) SaveBid(bid auction.Bid) error {
+	return errors.New("disk full")
+}
+
+func TestAuditLog_CommitFailure(t *testing.T) {
+	entries := audit.NewMemoryEntryStorage()
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), failingBidStorage{storage.NewMemoryBidStorage()},
+		WithAuctionID("auction-1"), WithAuditLog(audit.NewLog(entries)))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	err = manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00")
+	if err == nil {
+		t.Fatalf("Expected an error when the bid cannot be stored")
+	}
+
+	// the bid was recorded before it failed to be stored, so the log shows the failure of the same event after it
+	recorded, err := entries.GetAll()
+	if err != nil {
+		t.Fatalf("Failed to get entries: %s", err.Error())
+	}
+	if len(recorded) != 2 || recorded[0].Kind != events.KindBidPlaced || recorded[1].Kind != audit.KindCommitFailed {
+		t.Fatalf("Expected the bid to be followed by a failure, got %#v", recorded)
+	}
+	failure := recorded[1]
+	if failure.EventID != recorded[0].EventID || failure.Auction != "auction-1" || failure.Details.Bidder != "Sasha" || failure.Details.Detail == "" {
+		t.Fatalf("Expected the failure to refer to the bid, got %#v", failure)
+	}
+}
+
+// outageEntryStorage is an audit store that cannot append entries while it is down
+type outageEntryStorage struct {
+	audit.EntryStorer
+	down *bool
+}
+
+func (o outageEntryStorage) Append(entry audit.Entry) error {
+	if *o.down {
+		return errors.New("disk full")
+	}
+	return o.EntryStorer.Append(entry)
+}
+
+func TestBidderRegistry_FailedChange(t *testing.T) {
+	bidders := registry.NewRegistry(registry.Config{}, registry.NewMemoryStorage())
+	_, err := bidders.Register("Sasha", "Sasha", currency.MustNew(200, 0, currency.USD))
+	if err == nil {
+		_, err = bidders.SetStatus("Sasha", registry.StatusVerified)
+	}
+	if err != nil {
+		t.Fatalf("Failed to register bidder: %s", err.Error())
+	}
+	down := false
+	entries := outageEntryStorage{EntryStorer: audit.NewMemoryEntryStorage(), down: &down}
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(),
+		WithAuctionID("auction-1"), WithBidderRegistry(bidders), WithBuyItNow("$150.00", currency.NewRate(90, 0)), WithAuditLog(audit.NewLog(entries)))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	err = manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+
+	// Sasha keeps the $80.00 max bid they already had when the amendment or purchase cannot be made
+	down = true
+	err = manager.AmendBid("Sasha", "$120.00", "$3.00")
+	if err == nil {
+		t.Fatalf("Expected an error when the amendment cannot be audited")
+	}
+	outstanding, err := bidders.Outstanding("Sasha")
+	if err != nil || outstanding != currency.MustNew(80, 0, currency.USD) {
+		t.Fatalf("Expected $80.00 outstanding after the failed amendment, got %s and %#v", outstanding, err)
+	}
+	_, err = manager.BuyItNow("Sasha")
+	if err == nil {
+		t.Fatalf("Expected an error when the purchase cannot be audited")
+	}
+	outstanding, err = bidders.Outstanding("Sasha")
+	if err != nil || outstanding != currency.MustNew(80, 0, currency.USD) {
+		t.Fatalf("Expected $80.00 outstanding after the failed purchase, got %s and %#v", outstanding, err)
+	}
+}
+
+func TestRejectionReasons(t *testing.T) {
+	bidders := registry.NewRegistry(registry.Config{}, registry.NewMemoryStorage())
+	// Morgan's credit limit is in euros, so none of their bids in dollars can be reserved
+	limits := map[auction.Bidder]currency.Amount{
+		"Sasha":  currency.MustNew(100, 0, currency.USD),
+		"Morgan": currency.MustNew(100, 0, currency.EUR),
+	}
+	for bidder, limit := range limits {
+		_, err := bidders.Register(bidder, string(bidder), limit)
+		if err == nil {
+			_, err = bidders.SetStatus(bidder, registry.StatusVerified)
+		}
+		if err != nil {
+			t.Fatalf("Failed to register bidder: %s", err.Error())
+		}
+	}
+	lists := access.NewLists(access.Config{}, access.NewMemoryStorage())
+	_, err := lists.Block(access.AuctionScope("auction-1"), "Pat", "non-payer")
+	if err != nil {
+		t.Fatalf("Failed to block bidder: %s", err.Error())
+	}
+	broker := events.NewMemoryBroker()
+	sub := broker.Subscribe(100, events.Block)
+	defer sub.Close()
+	entries := audit.NewMemoryEntryStorage()
+	manager, err := NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(),
+		WithAuctionID("auction-1"), WithBidderRegistry(bidders), WithAccessLists(lists), WithPublisher(broker), WithAuditLog(audit.NewLog(entries)))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+
+	type testCase struct {
+		name   string
+		bidder string
+		maxBid string
+		reason string
+	}
+	testCases := []testCase{
+		{name: "Credit Limit", bidder: "Sasha", maxBid: "$150.00", reason: events.ReasonCreditLimit},
+		{name: "Currency Mismatch", bidder: "Morgan", maxBid: "$80.00", reason: events.ReasonCurrencyMismatch},
+		{name: "Blocked", bidder: "Pat", maxBid: "$80.00", reason: events.ReasonAccessDenied},
+		{name: "Unregistered", bidder: "John", maxBid: "$80.00", reason: events.ReasonUnverifiedBidder},
+		{name: "Invalid", bidder: "Sasha", maxBid: "$1.00", reason: events.ReasonInvalidBid},
+	}
+	for i, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			rejection := manager.AddBid(test.bidder, "$50.00", test.maxBid, "$3.00")
+			if rejection == nil {
+				t.Fatalf("Expected the bid to be rejected")
+			}
+			// the published event only carries the reason, and the audit log keeps the error
+			rejected, ok := (<-sub.Events()).(events.BidRejected)
+			if !ok || rejected.Reason != test.reason || rejected.Detail != "" {
+				t.Fatalf("Expected a rejection with the reason %s and no detail, got %#v", test.reason, rejected)
+			}
+			audited, err := entries.GetAll()
+			if err != nil {
+				t.Fatalf("Failed to read audit log: %s", err.Error())
+			}
+			details := audited[i].Details
+			if details.Reason != test.reason || details.Detail != rejection.Error() {
+				t.Fatalf("Expected the audit entry to record the reason and error, got %#v", details)
+			}
+		})
+	}
+}
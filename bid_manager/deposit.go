@@ -0,0 +1,72 @@
+package bid_manager
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/deposit"
+	"errors"
+	"fmt"
+)
+
+// newDeposit parses the deposit of the config, returning nil if bidders do not have to post a deposit. Deposits are
+// held in currency.Amount, so the deposit is one whatever the representation of the amounts of the auction.
+func newDeposit(c config) (*currency.Amount, error) {
+	if c.depositAmount == "" {
+		return nil, nil
+	}
+	if c.deposits == nil {
+		return nil, &InvalidDepositError{message: "a deposit requires holds to keep it"}
+	}
+	amount, err := currency.ParseMoneyIn[currency.Amount](c.locale, c.depositAmount, c.currency)
+	if err != nil {
+		return nil, errors.Join(&InvalidDepositError{message: "failed to parse deposit"}, err)
+	}
+	if amount.Sign() <= 0 {
+		return nil, &InvalidDepositError{message: fmt.Sprintf("deposit %s must be greater than zero", amount.String())}
+	}
+	return &amount, nil
+}
+
+// checkDeposit checks that the bidder holds the deposit of the auction, if it requires one
+func (m defaultBidManager[M]) checkDeposit(bidder auction.Bidder) error {
+	if m.deposit == nil {
+		return nil
+	}
+	return m.deposits.Check(m.auctionID, bidder, *m.deposit)
+}
+
+// settleDeposits captures the deposit of the winner and releases the others once the auction has closed. The winner is
+// empty when nobody bid, which releases every deposit.
+func (m defaultBidManager[M]) settleDeposits(winner auction.Bidder) error {
+	if m.deposit == nil {
+		return nil
+	}
+	_, err := m.deposits.Settle(m.auctionID, winner)
+	if err != nil {
+		return errors.Join(errors.New("failed to settle deposits"), err)
+	}
+	return nil
+}
+
+// forfeitDeposit keeps the deposit captured from a winner who defaulted, if the auction requires one. A second-chance
+// winner's deposit was released when the auction closed, so they have nothing to forfeit.
+func (m defaultBidManager[M]) forfeitDeposit(bidder auction.Bidder) error {
+	if m.deposit == nil {
+		return nil
+	}
+	hold, err := m.deposits.GetHold(m.auctionID, bidder)
+	var notFound *deposit.HoldNotFoundError
+	if errors.As(err, &notFound) {
+		return nil
+	} else if err != nil {
+		return errors.Join(errors.New("failed to fetch deposit"), err)
+	}
+	if hold.Status != deposit.StatusCaptured {
+		return nil
+	}
+	_, err = m.deposits.Forfeit(m.auctionID, bidder)
+	if err != nil {
+		return errors.Join(errors.New("failed to forfeit deposit"), err)
+	}
+	return nil
+}
@@ -1,6 +1,17 @@
 package bid_manager
 
-import "fmt"
+import (
+	"auction/access"
+	"auction/auction"
+	"auction/deposit"
+	"auction/events"
+	"auction/id_generator"
+	"auction/registry"
+	"auction/storage"
+	"errors"
+	"fmt"
+	"time"
+)
 
 type EmptyBidListError struct {
 }
@@ -16,3 +27,151 @@ type InvalidBidError struct {
 func (e *InvalidBidError) Error() string {
 	return e.message
 }
+
+type AuctionClosedError struct {
+}
+
+func (e *AuctionClosedError) Error() string {
+	return "auction is closed and no longer accepting bids"
+}
+
+type DuplicateBidError struct {
+	bidder auction.Bidder
+}
+
+func (e *DuplicateBidError) Error() string {
+	return fmt.Sprintf("bidder %s has already entered a bid", e.bidder)
+}
+
+type CorruptEventLogError struct {
+	id id_generator.EventID
+}
+
+func (e *CorruptEventLogError) Error() string {
+	return fmt.Sprintf("event %d in the event log could not be applied", e.id)
+}
+
+type StaleIDGeneratorError struct {
+	next id_generator.EventID
+	last id_generator.EventID
+}
+
+func (e *StaleIDGeneratorError) Error() string {
+	return fmt.Sprintf("the ID generator issued %d, which does not continue after the last event %d in the log", e.next, e.last)
+}
+
+type AuctionOpenError struct {
+}
+
+func (e *AuctionOpenError) Error() string {
+	return "auction must be closed before a winner can default"
+}
+
+type NotWinnerError struct {
+	bidder auction.Bidder
+}
+
+func (e *NotWinnerError) Error() string {
+	return fmt.Sprintf("bidder %s is not the winner of the auction", e.bidder)
+}
+
+type OfferPendingError struct {
+	bidder auction.Bidder
+}
+
+func (e *OfferPendingError) Error() string {
+	return fmt.Sprintf("the second-chance offer to bidder %s has not been accepted or declined", e.bidder)
+}
+
+type NoOfferError struct {
+	bidder auction.Bidder
+}
+
+func (e *NoOfferError) Error() string {
+	return fmt.Sprintf("bidder %s has no second-chance offer", e.bidder)
+}
+
+type OfferExpiredError struct {
+	bidder    auction.Bidder
+	expiredAt time.Time
+}
+
+func (e *OfferExpiredError) Error() string {
+	return fmt.Sprintf("the second-chance offer to bidder %s expired at %s", e.bidder, e.expiredAt.Format(time.RFC3339))
+}
+
+type NoRunnerUpError struct {
+}
+
+func (e *NoRunnerUpError) Error() string {
+	return "every bidder has defaulted or declined, so there is nobody left to offer the lot to"
+}
+
+type InvalidBuyItNowError struct {
+	message string
+}
+
+func (e *InvalidBuyItNowError) Error() string {
+	return e.message
+}
+
+type InvalidDepositError struct {
+	message string
+}
+
+func (e *InvalidDepositError) Error() string {
+	return e.message
+}
+
+type MissingAuctionIDError struct {
+	option string
+}
+
+func (e *MissingAuctionIDError) Error() string {
+	return fmt.Sprintf("%s requires the auction to have an ID set with WithAuctionID", e.option)
+}
+
+type BuyItNowUnavailableError struct {
+	reason string
+}
+
+func (e *BuyItNowUnavailableError) Error() string {
+	return fmt.Sprintf("the lot cannot be bought now: %s", e.reason)
+}
+
+// reasonOf returns the reason code that is published when a bid is rejected with the error
+func reasonOf(err error) string {
+	var (
+		invalid      *InvalidBidError
+		duplicate    *DuplicateBidError
+		notFound     *storage.BidderNotFoundError
+		closed       *AuctionClosedError
+		denied       *access.AccessDeniedError
+		unverified   *registry.UnverifiedBidderError
+		unregistered *registry.ProfileNotFoundError
+		creditLimit  *registry.CreditLimitExceededError
+		mismatch     *registry.CurrencyMismatchError
+		insufficient *deposit.InsufficientHoldError
+	)
+	switch {
+	case errors.As(err, &invalid):
+		return events.ReasonInvalidBid
+	case errors.As(err, &duplicate):
+		return events.ReasonDuplicateBid
+	case errors.As(err, &notFound):
+		return events.ReasonBidNotFound
+	case errors.As(err, &closed):
+		return events.ReasonAuctionClosed
+	case errors.As(err, &denied):
+		return events.ReasonAccessDenied
+	case errors.As(err, &unverified), errors.As(err, &unregistered):
+		return events.ReasonUnverifiedBidder
+	case errors.As(err, &creditLimit):
+		return events.ReasonCreditLimit
+	case errors.As(err, &mismatch):
+		return events.ReasonCurrencyMismatch
+	case errors.As(err, &insufficient):
+		return events.ReasonInsufficientDeposit
+	}
+	return events.ReasonRejected
+}
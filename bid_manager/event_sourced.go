@@ -0,0 +1,208 @@
+package bid_manager
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/events"
+	"auction/id_generator"
+	"auction/storage"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NewEventSourcedBidManager creates a manager where every change to the auction is an event appended to the log, and
+// the bids are a projection of the log held in memory. The log is replayed to rebuild the projection, so a manager
+// created from an existing log continues where the previous one stopped. The IDGenerator must continue from the last
+// ID in the log, such as one created with id_generator.NewMemoryIDGeneratorFrom and the LastEventID of a snapshot of
+// the log, or a StaleIDGeneratorError is returned.
+//
+// Only the changes to the auction are appended. If the events published by the manager are also being recorded for a
+// feed, they should be recorded to a different log.
+func NewEventSourcedBidManager(idGenerator id_generator.IDGenerator, log events.Log, opts ...Option) (BidManager, error) {
+	return NewEventSourcedBidManagerOf[currency.Amount](idGenerator, log, opts...)
+}
+
+// NewEventSourcedBidManagerOf creates an event sourced manager whose amounts are represented by M. The log must only
+// contain the events of an auction with the same representation.
+func NewEventSourcedBidManagerOf[M currency.Money[M]](idGenerator id_generator.IDGenerator, log events.Log, opts ...Option) (BidManagerOf[M], error) {
+	c, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	buyItNow, err := newBuyItNow[M](c)
+	if err != nil {
+		return nil, err
+	}
+	store, state, lastID, err := replay[M](log, buyItNow, func(event events.Event) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	// an ID is drawn to check the generator, which leaves a gap in the IDs but keeps them in order
+	if lastID > 0 {
+		next := idGenerator.Next()
+		if next <= lastID {
+			return nil, &StaleIDGeneratorError{next: next, last: lastID}
+		}
+	}
+
+	required, err := newDeposit(c)
+	if err != nil {
+		return nil, err
+	}
+	return &defaultBidManager[M]{
+		config:      c,
+		idGenerator: idGenerator,
+		storage:     store,
+		log:         log,
+		state:       state,
+		buyItNow:    buyItNow,
+		deposit:     required,
+	}, nil
+}
+
+// Snapshot is the state of an auction rebuilt from its event log
+type Snapshot = SnapshotOf[currency.Amount]
+
+// SnapshotOf is the state of an auction whose amounts are represented by M
+type SnapshotOf[M currency.Money[M]] struct {
+	// LastEventID is the ID of the last event applied to the snapshot
+	LastEventID id_generator.EventID
+	Bids        auction.BidMapOf[M]
+	Closed      bool
+	// Excluded holds the bidders who defaulted or declined a second-chance offer
+	Excluded map[auction.Bidder]bool
+	// Purchase is the buyer of a lot that was bought at its buy-it-now price
+	Purchase *auction.WinningBidOf[M]
+}
+
+// ReplayToEvent rebuilds the auction from the events in the log up to and including the event with the given ID
+func ReplayToEvent(log events.Log, id id_generator.EventID) (Snapshot, error) {
+	return ReplayToEventOf[currency.Amount](log, id)
+}
+
+// ReplayToEventOf is ReplayToEvent for an auction whose amounts are represented by M
+func ReplayToEventOf[M currency.Money[M]](log events.Log, id id_generator.EventID) (SnapshotOf[M], error) {
+	return newSnapshot(replay[M](log, nil, func(event events.Event) bool {
+		return event.EventID() <= id
+	}))
+}
+
+// ReplayToTime rebuilds the auction from the events in the log that occurred at or before t
+func ReplayToTime(log events.Log, t time.Time) (Snapshot, error) {
+	return ReplayToTimeOf[currency.Amount](log, t)
+}
+
+// ReplayToTimeOf is ReplayToTime for an auction whose amounts are represented by M
+func ReplayToTimeOf[M currency.Money[M]](log events.Log, t time.Time) (SnapshotOf[M], error) {
+	return newSnapshot(replay[M](log, nil, func(event events.Event) bool {
+		return !event.OccurredAt().After(t)
+	}))
+}
+
+// CalculateWinner recalculates the winner from the bids in the snapshot, as CalculateWinner would have at that time
+func (s SnapshotOf[M]) CalculateWinner() (auction.WinningBidOf[M], error) {
+	if s.Purchase != nil {
+		return *s.Purchase, nil
+	}
+	return CalculateWinnerOf(exclude(s.Bids, s.Excluded))
+}
+
+// CalculateResults ranks the bids in the snapshot, as CalculateResults would have at that time
+func (s SnapshotOf[M]) CalculateResults() ([]auction.ResultOf[M], error) {
+	return resultsOf(exclude(s.Bids, s.Excluded), s.Purchase)
+}
+
+func newSnapshot[M currency.Money[M]](store storage.BidStorerOf[M], state *auctionState[M], lastID id_generator.EventID, err error) (SnapshotOf[M], error) {
+	if err != nil {
+		return SnapshotOf[M]{}, err
+	}
+	bids, err := store.GetAllBids()
+	if err != nil {
+		return SnapshotOf[M]{}, errors.Join(errors.New("failed to fetch bids"), err)
+	}
+	return SnapshotOf[M]{
+		LastEventID: lastID,
+		Bids:        bids,
+		Closed:      state.closed,
+		Excluded:    state.excluded,
+		Purchase:    state.purchase,
+	}, nil
+}
+
+// replay applies the events in the log to a new projection until include returns false. Events are applied in the
+// order they were appended, which is also the order of their IDs. The buy-it-now price is disabled at the event that
+// first took bidding to its threshold, and buyItNow is nil when the lot has no buy-it-now price.
+func replay[M currency.Money[M]](log events.Log, buyItNow *auction.BuyItNowOf[M], include func(event events.Event) bool) (storage.BidStorerOf[M], *auctionState[M], id_generator.EventID, error) {
+	history, err := log.After(0)
+	if err != nil {
+		return nil, nil, 0, errors.Join(errors.New("failed to read event log"), err)
+	}
+
+	store := storage.NewMemoryBidStorageOf[M]()
+	state := newAuctionState[M]()
+	var lastID id_generator.EventID
+	for _, event := range history {
+		if !include(event) {
+			break
+		}
+		err = apply[M](store, state, event)
+		if err != nil {
+			return nil, nil, 0, errors.Join(&CorruptEventLogError{id: event.EventID()}, err)
+		}
+		err = disableBuyItNow(store, state, buyItNow)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		lastID = event.EventID()
+	}
+	return store, state, lastID, nil
+}
+
+// apply projects an event onto the storage and state of the auction. Only the changes a manager appends to its log
+// can be applied, so a log holding any other event, such as a change of leader from a feed, or the events of an
+// auction in another representation is corrupt.
+func apply[M currency.Money[M]](store storage.BidStorerOf[M], state *auctionState[M], event events.Event) error {
+	var err error
+	switch e := event.(type) {
+	case events.BidPlacedOf[M]:
+		err = store.SaveBid(e.Bid)
+		if err != nil {
+			return errors.Join(errors.New("failed to save bid"), err)
+		}
+	case events.BidAmendedOf[M]:
+		err = store.UpdateBid(e.Bid)
+		if err != nil {
+			return errors.Join(errors.New("failed to update bid"), err)
+		}
+	case events.BidRetracted:
+		err = store.DeleteBid(e.Bidder)
+		if err != nil {
+			return errors.Join(errors.New("failed to delete bid"), err)
+		}
+	case events.AuctionClosed:
+		state.closed = true
+	case events.BidderDefaulted:
+		// a default is only declared once the auction has been settled
+		state.settled = true
+		state.excluded[e.Bidder] = true
+		if state.purchase != nil && state.purchase.Bidder == e.Bidder {
+			state.purchase = nil
+		}
+	case events.SecondChanceOfferedOf[M]:
+		offer := e.Offer
+		state.offer = &offer
+	case events.SecondChanceAcceptedOf[M]:
+		state.offer = nil
+	case events.SecondChanceDeclined:
+		state.excluded[e.Bidder] = true
+		state.offer = nil
+	case events.BoughtItNowOf[M]:
+		winner := e.Winner
+		state.purchase = &winner
+		state.closed = true
+	default:
+		return fmt.Errorf("%T is not a change to an auction with amounts in %T", event, *new(M))
+	}
+	return nil
+}
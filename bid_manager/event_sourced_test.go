@@ -0,0 +1,375 @@
+package bid_manager
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/events"
+	"auction/id_generator"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func WithEventSourcedBidManager() func() (BidManager, error) {
+	return func() (BidManager, error) {
+		return NewEventSourcedBidManager(id_generator.NewMemoryIDGenerator(), events.NewMemoryLog())
+	}
+}
+
+func TestEventSourcedManager(t *testing.T) {
+	tests := managerTests{
+		managerFn: WithEventSourcedBidManager(),
+		t:         t,
+	}
+	tests.Run()
+}
+
+// mockClock returns a time one minute later on every call, starting from 12:00
+func mockClock() func() time.Time {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+}
+
+// newHistory creates an event log where Sasha leads, then Pat, and then Pat retracts and Sasha amends their bid
+func newHistory(t *testing.T) (events.Log, id_generator.IDGenerator) {
+	return newHistoryIn(t, events.NewMemoryLog())
+}
+
+// newHistoryIn appends the history of a closed auction to the log
+func newHistoryIn(t *testing.T, log events.Log) (events.Log, id_generator.IDGenerator) {
+	generator := id_generator.NewMemoryIDGenerator()
+	manager, err := NewEventSourcedBidManager(generator, log, WithClock(mockClock()))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+
+	steps := []func() error{
+		func() error { return manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00") },
+		func() error { return manager.AddBid("Pat", "$55.00", "$85.00", "$5.00") },
+		func() error { return manager.RetractBid("Pat") },
+		func() error { return manager.AmendBid("Sasha", "$90.00", "$3.00") },
+		manager.Close,
+	}
+	for _, step := range steps {
+		err = step()
+		if err != nil {
+			t.Fatalf("Failed to change auction: %s", err.Error())
+		}
+	}
+	return log, generator
+}
+
+func TestRebuildFromLog(t *testing.T) {
+	log, generator := newHistory(t)
+
+	manager, err := NewEventSourcedBidManager(generator, log)
+	if err != nil {
+		t.Fatalf("could not rebuild manager: %s", err.Error())
+	}
+
+	err = manager.AddBid("John", "$60.00", "$82.00", "$2.00")
+	if _, ok := err.(*AuctionClosedError); !ok {
+		t.Fatalf("Expected rebuilt auction to be closed, got %#v", err)
+	}
+
+	expected := auction.WinningBid{Bidder: auction.Bidder("Sasha"), Amount: currency.MustNew(50, 0, currency.USD)}
+	winner, err := manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, winner) {
+		t.Fatalf("Expected %#v, got %#v", expected, winner)
+	}
+}
+
+func TestRebuildFromFileLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	_, generator := newHistoryIn(t, events.NewFileLog(path))
+
+	// a new log over the same file reads back every change
+	manager, err := NewEventSourcedBidManager(generator, events.NewFileLog(path))
+	if err != nil {
+		t.Fatalf("could not rebuild manager: %s", err.Error())
+	}
+	expected := auction.WinningBid{Bidder: auction.Bidder("Sasha"), Amount: currency.MustNew(50, 0, currency.USD)}
+	winner, err := manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, winner) {
+		t.Fatalf("Expected %#v, got %#v", expected, winner)
+	}
+	snapshot, err := ReplayToEvent(events.NewFileLog(path), 2)
+	if err != nil {
+		t.Fatalf("Failed to replay log: %s", err.Error())
+	}
+	if len(snapshot.Bids) != 2 || snapshot.Closed {
+		t.Fatalf("Expected both bids of an open auction, got %#v", snapshot)
+	}
+}
+
+func TestReplayToEvent(t *testing.T) {
+	log, _ := newHistory(t)
+
+	type testCase struct {
+		name    string
+		eventID id_generator.EventID
+		bidders []auction.Bidder
+		winner  auction.Bidder
+		closed  bool
+	}
+	testCases := []testCase{
+		{"First Bid", 1, []auction.Bidder{"Sasha"}, "Sasha", false},
+		{"Second Bid", 2, []auction.Bidder{"Sasha", "Pat"}, "Pat", false},
+		{"Retracted", 3, []auction.Bidder{"Sasha"}, "Sasha", false},
+		{"Closed", 5, []auction.Bidder{"Sasha"}, "Sasha", true},
+		{"Past The End", 100, []auction.Bidder{"Sasha"}, "Sasha", true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot, err := ReplayToEvent(log, test.eventID)
+			if err != nil {
+				t.Fatalf("Failed to replay: %s", err.Error())
+			}
+			if len(snapshot.Bids) != len(test.bidders) {
+				t.Fatalf("Expected %d bids, got %d", len(test.bidders), len(snapshot.Bids))
+			}
+			for _, bidder := range test.bidders {
+				if _, ok := snapshot.Bids[bidder]; !ok {
+					t.Fatalf("Expected %s to have a bid", bidder)
+				}
+			}
+			if snapshot.Closed != test.closed {
+				t.Fatalf("Expected closed to be %v", test.closed)
+			}
+			winner, err := snapshot.CalculateWinner()
+			if err != nil {
+				t.Fatalf("Failed to calculate winner: %s", err.Error())
+			}
+			if winner.Bidder != test.winner {
+				t.Fatalf("Expected %s to win, got %s", test.winner, winner.Bidder)
+			}
+		})
+	}
+}
+
+func TestReplayToTime(t *testing.T) {
+	log, _ := newHistory(t)
+
+	snapshot, err := ReplayToTime(log, time.Date(2024, 6, 1, 12, 1, 59, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Failed to replay: %s", err.Error())
+	}
+	if snapshot.LastEventID != 1 || len(snapshot.Bids) != 1 {
+		t.Fatalf("Expected only the first bid, got %#v", snapshot)
+	}
+
+	snapshot, err = ReplayToTime(log, time.Date(2024, 6, 1, 11, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Failed to replay: %s", err.Error())
+	}
+	_, err = snapshot.CalculateWinner()
+	if _, ok := err.(*EmptyBidListError); !ok {
+		t.Fatalf("Expected EmptyBidListError but got: %#v", err)
+	}
+}
+
+func TestCorruptEventLog(t *testing.T) {
+	big := currency.MustNew(50, 0, currency.USD).Big()
+	testCases := map[string]events.Event{
+		"Retraction Without Bid": events.BidRetracted{Header: events.Header{ID: 1}, Bidder: auction.Bidder("Sasha")},
+		"Feed Event":             events.LeaderChanged{Header: events.Header{ID: 1}},
+		"Other Representation": events.BidPlacedOf[currency.BigAmount]{
+			Header: events.Header{ID: 1},
+			Bid:    auction.BidOf[currency.BigAmount]{Bidder: "Sasha", StartingBid: big, MaxBid: big, Increment: big, ID: 1},
+		},
+	}
+	for name, event := range testCases {
+		t.Run(name, func(t *testing.T) {
+			log := events.NewMemoryLog()
+			err := log.Append(event)
+			if err != nil {
+				t.Fatalf("Failed to append event: %s", err.Error())
+			}
+
+			_, err = NewEventSourcedBidManager(id_generator.NewMemoryIDGenerator(), log)
+			var corrupt *CorruptEventLogError
+			if !errors.As(err, &corrupt) {
+				t.Fatalf("Expected CorruptEventLogError but got %#v", err)
+			}
+		})
+	}
+}
+
+func TestReplayBigAmounts(t *testing.T) {
+	log := events.NewMemoryLog()
+	generator := id_generator.NewMemoryIDGenerator()
+	manager, err := NewEventSourcedBidManagerOf[currency.BigAmount](generator, log, WithCurrency(currency.ETH))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	err = manager.AddBid("Sasha", "Ξ10", "Ξ25", "Ξ1")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+	err = manager.AddBid("Pat", "Ξ12.5", "Ξ30", "Ξ0.5")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+
+	rebuilt, err := NewEventSourcedBidManagerOf[currency.BigAmount](generator, log, WithCurrency(currency.ETH))
+	if err != nil {
+		t.Fatalf("could not rebuild manager: %s", err.Error())
+	}
+	winner, err := rebuilt.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if winner.Bidder != "Pat" || winner.Amount.Text() != "ETH 25.500000000000000000" {
+		t.Fatalf("Expected Pat to win with ETH 25.5, got %s with %s", winner.Bidder, winner.Amount.Text())
+	}
+
+	snapshot, err := ReplayToEventOf[currency.BigAmount](log, 1)
+	if err != nil {
+		t.Fatalf("Failed to replay log: %s", err.Error())
+	}
+	if len(snapshot.Bids) != 1 || snapshot.Bids["Sasha"].MaxBid.Text() != "ETH 25.000000000000000000" {
+		t.Fatalf("Expected only Sasha's bid, got %#v", snapshot.Bids)
+	}
+}
+
+func TestRebuildSecondChance(t *testing.T) {
+	log, generator := newHistory(t)
+	manager, err := NewEventSourcedBidManager(generator, log)
+	if err != nil {
+		t.Fatalf("could not rebuild manager: %s", err.Error())
+	}
+	_, err = manager.DeclareDefault("Sasha")
+	if _, ok := err.(*NoRunnerUpError); !ok {
+		t.Fatalf("Expected NoRunnerUpError but got %#v", err)
+	}
+
+	manager, err = NewEventSourcedBidManager(generator, log)
+	if err != nil {
+		t.Fatalf("could not rebuild manager: %s", err.Error())
+	}
+	_, err = manager.CalculateWinner()
+	if _, ok := err.(*EmptyBidListError); !ok {
+		t.Fatalf("Expected the defaulted bidder to be excluded from the rebuilt auction, got %#v", err)
+	}
+	snapshot, err := ReplayToEvent(log, generator.Next())
+	if err != nil {
+		t.Fatalf("Failed to replay log: %s", err.Error())
+	}
+	_, err = snapshot.CalculateResults()
+	if _, ok := err.(*EmptyBidListError); !ok {
+		t.Fatalf("Expected the defaulted bidder to be excluded from the results, got %#v", err)
+	}
+}
+
+func TestRebuildBuyItNow(t *testing.T) {
+	log := events.NewMemoryLog()
+	generator := id_generator.NewMemoryIDGenerator()
+	manager, err := NewEventSourcedBidManager(generator, log, WithBuyItNow("$120.00", currency.NewRate(50, 0)))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	_, err = manager.BuyItNow("Riley")
+	if err != nil {
+		t.Fatalf("Failed to buy it now: %s", err.Error())
+	}
+
+	manager, err = NewEventSourcedBidManager(generator, log)
+	if err != nil {
+		t.Fatalf("could not rebuild manager: %s", err.Error())
+	}
+	expected := auction.WinningBid{Bidder: "Riley", Amount: currency.MustNew(120, 0, currency.USD)}
+	winner, err := manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, winner) {
+		t.Fatalf("Expected %#v, got %#v", expected, winner)
+	}
+
+	snapshot, err := ReplayToEvent(log, generator.Next())
+	if err != nil {
+		t.Fatalf("Failed to replay log: %s", err.Error())
+	}
+	results, err := snapshot.CalculateResults()
+	if err != nil {
+		t.Fatalf("Failed to calculate results: %s", err.Error())
+	}
+	expectedResults := []auction.Result{{Bidder: "Riley", Amount: expected.Amount, Rank: 1, SecondChancePrice: expected.Amount}}
+	if !reflect.DeepEqual(expectedResults, results) {
+		t.Fatalf("Expected %#v, got %#v", expectedResults, results)
+	}
+}
+
+func TestRestart(t *testing.T) {
+	log, _ := newHistory(t)
+
+	// a new process starts a fresh generator, which would reissue the IDs already in the log
+	_, err := NewEventSourcedBidManager(id_generator.NewMemoryIDGenerator(), log)
+	if _, ok := err.(*StaleIDGeneratorError); !ok {
+		t.Fatalf("Expected StaleIDGeneratorError but got %#v", err)
+	}
+
+	snapshot, err := ReplayToTime(log, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to replay log: %s", err.Error())
+	}
+	manager, err := NewEventSourcedBidManager(id_generator.NewMemoryIDGeneratorFrom(snapshot.LastEventID), log)
+	if err != nil {
+		t.Fatalf("could not rebuild manager: %s", err.Error())
+	}
+	_, err = manager.DeclareDefault("Sasha")
+	if _, ok := err.(*NoRunnerUpError); !ok {
+		t.Fatalf("Expected NoRunnerUpError but got %#v", err)
+	}
+	history, err := log.After(snapshot.LastEventID)
+	if err != nil {
+		t.Fatalf("Failed to read log: %s", err.Error())
+	}
+	if len(history) != 1 || history[0].EventID() <= snapshot.LastEventID {
+		t.Fatalf("Expected the default to continue after event %d, got %#v", snapshot.LastEventID, history)
+	}
+}
+
+func TestBuyItNowStaysUnavailable(t *testing.T) {
+	log := events.NewMemoryLog()
+	generator := id_generator.NewMemoryIDGenerator()
+	opts := []Option{WithBuyItNow("$120.00", currency.NewRate(50, 0))}
+	manager, err := NewEventSourcedBidManager(generator, log, opts...)
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	// Sasha and John bid each other up past $60.00, and John retracts, leaving Sasha leading at $50.00
+	err = errors.Join(
+		manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00"),
+		manager.AddBid("John", "$60.00", "$82.00", "$2.00"),
+		manager.RetractBid("John"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to change auction: %s", err.Error())
+	}
+	_, err = manager.BuyItNow("Riley")
+	if _, ok := err.(*BuyItNowUnavailableError); !ok {
+		t.Fatalf("Expected BuyItNowUnavailableError but got %#v", err)
+	}
+
+	// the rebuilt manager replays the bid that reached the threshold
+	rebuilt, err := NewEventSourcedBidManager(generator, log, opts...)
+	if err != nil {
+		t.Fatalf("could not rebuild manager: %s", err.Error())
+	}
+	_, err = rebuilt.BuyItNow("Riley")
+	if _, ok := err.(*BuyItNowUnavailableError); !ok {
+		t.Fatalf("Expected BuyItNowUnavailableError but got %#v", err)
+	}
+}
@@ -1,10 +1,33 @@
 package bid_manager
 
-import "auction/auction"
+import (
+	"auction/auction"
+	"auction/currency"
+)
 
-type BidManager interface {
+type BidManager = BidManagerOf[currency.Amount]
+
+// BidManagerOf is a bid manager whose amounts are represented by M. Bids are given as strings in the same way whatever
+// the representation, and only the winning bid carries it.
+type BidManagerOf[M currency.Money[M]] interface {
 	// AddBid creates a bid entry for a person. A person can only enter a single bid entry
 	AddBid(bidder, startingBid, maxBid, incrementAmount string) error
+	// AmendBid changes the max bid and increment of a person's existing bid entry
+	AmendBid(bidder, maxBid, incrementAmount string) error
+	// RetractBid removes a person's bid entry
+	RetractBid(bidder string) error
+	// Close stops the auction from accepting any more bids
+	Close() error
+	// BuyItNow buys the lot at its buy-it-now price for a person, which closes the auction
+	BuyItNow(bidder string) (auction.WinningBidOf[M], error)
 	// CalculateWinner returns the winning bid based on the bids that have been added
-	CalculateWinner() (auction.WinningBid, error)
+	CalculateWinner() (auction.WinningBidOf[M], error)
+	// CalculateResults returns the final standing of every bidder, ordered by rank
+	CalculateResults() ([]auction.ResultOf[M], error)
+	// DeclareDefault records that the winner of a closed auction did not pay and offers the lot to the next bidder
+	DeclareDefault(bidder string) (auction.SecondChanceOfferOf[M], error)
+	// AcceptOffer accepts the second-chance offer made to a person, making them the winner
+	AcceptOffer(bidder string) (auction.WinningBidOf[M], error)
+	// DeclineOffer declines the second-chance offer made to a person and offers the lot to the next bidder
+	DeclineOffer(bidder string) (auction.SecondChanceOfferOf[M], error)
 }
@@ -3,6 +3,8 @@ package bid_manager
 import (
 	"auction/auction"
 	"auction/currency"
+	"auction/storage"
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -19,6 +21,14 @@ func (g *managerTests) Run() {
 		"Test Empty Bid List":                testEmptyBidList,
 		"Test Starting Bid Greater Than Max": testStartingBidGreaterThanMax,
 		"Test Zero Increment":                testZeroIncrement,
+		"Test Bid After Close":               testBidAfterClose,
+		"Test Duplicate Bid":                 testDuplicateBid,
+		"Test Amend Bid":                     testAmendBid,
+		"Test Invalid Amendment":             testInvalidAmendment,
+		"Test Retract Bid":                   testRetractBid,
+		"Test Change Missing Bid":            testChangeMissingBid,
+		"Test Calculate Results":             testCalculateResults,
+		"Test Second Chance":                 testSecondChance,
 	}
 	for name, test := range tests {
 		g.t.Run(name, func(t *testing.T) {
@@ -99,7 +109,7 @@ func testGeneralCases(t *testing.T, manager BidManager) {
 			},
 			winner: auction.WinningBid{
 				Bidder: auction.Bidder("Pat"),
-				Amount: currency.Amount{Dollars: 85, Cents: 00},
+				Amount: currency.MustNew(85, 0, currency.USD),
 			},
 		},
 		{
@@ -111,7 +121,7 @@ func testGeneralCases(t *testing.T, manager BidManager) {
 			},
 			winner: auction.WinningBid{
 				Bidder: auction.Bidder("Riley"),
-				Amount: currency.Amount{Dollars: 722, Cents: 00},
+				Amount: currency.MustNew(722, 0, currency.USD),
 			},
 		},
 		{
@@ -123,7 +133,7 @@ func testGeneralCases(t *testing.T, manager BidManager) {
 			},
 			winner: auction.WinningBid{
 				Bidder: auction.Bidder("Jesse"),
-				Amount: currency.Amount{Dollars: 3001, Cents: 00},
+				Amount: currency.MustNew(3001, 0, currency.USD),
 			},
 		},
 	}
@@ -168,7 +178,7 @@ func testTieCases(t *testing.T, manager BidManager) {
 			},
 			winner: auction.WinningBid{
 				Bidder: auction.Bidder("Sasha"),
-				Amount: currency.Amount{Dollars: 80, Cents: 00},
+				Amount: currency.MustNew(80, 0, currency.USD),
 			},
 		},
 	}
@@ -219,3 +229,241 @@ func testZeroIncrement(t *testing.T, manager BidManager) {
 	}
 
 }
+
+func testBidAfterClose(t *testing.T, manager BidManager) {
+	err := manager.AddBid("mockBidder", "$5", "$20", "$1")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+	err = manager.Close()
+	if err != nil {
+		t.Fatalf("Failed to close auction: %s", err.Error())
+	}
+
+	err = manager.AddBid("mockBidder2", "$5", "$20", "$1")
+	if _, ok := err.(*AuctionClosedError); !ok {
+		t.Fatalf("Expected AuctionClosedError but got %#v", err)
+	}
+	if _, ok := manager.Close().(*AuctionClosedError); !ok {
+		t.Fatalf("Expected closing twice to return AuctionClosedError")
+	}
+
+	winner, err := manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if winner.Bidder != auction.Bidder("mockBidder") {
+		t.Fatalf("Expected mockBidder to win, got %s", winner.Bidder)
+	}
+}
+
+func testDuplicateBid(t *testing.T, manager BidManager) {
+	err := manager.AddBid("mockBidder", "$5", "$20", "$1")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+	err = manager.AddBid("mockBidder", "$6", "$20", "$1")
+	if _, ok := err.(*DuplicateBidError); !ok {
+		t.Fatalf("Expected DuplicateBidError but got %#v", err)
+	}
+}
+
+func testAmendBid(t *testing.T, manager BidManager) {
+	bids := [][]string{
+		{"Sasha", "$50.00", "$80.00", "$3.00"},
+		{"Pat", "$55.00", "$85.00", "$5.00"},
+	}
+	for _, bid := range bids {
+		err := manager.AddBid(bid[0], bid[1], bid[2], bid[3])
+		if err != nil {
+			t.Fatalf("Failed to add bid: %s", err.Error())
+		}
+	}
+
+	err := manager.AmendBid("Sasha", "$100.00", "$3.00")
+	if err != nil {
+		t.Fatalf("Failed to amend bid: %s", err.Error())
+	}
+
+	expected := auction.WinningBid{
+		Bidder: auction.Bidder("Sasha"),
+		Amount: currency.MustNew(86, 0, currency.USD),
+	}
+	winner, err := manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, winner) {
+		t.Fatalf("Expected %#v, got %#v", expected, winner)
+	}
+}
+
+func testInvalidAmendment(t *testing.T, manager BidManager) {
+	err := manager.AddBid("mockBidder", "$5", "$20", "$1")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+	err = manager.AmendBid("mockBidder", "$4", "$1")
+	if _, ok := err.(*InvalidBidError); !ok {
+		t.Fatalf("Expected InvalidBidError but got %#v", err)
+	}
+}
+
+func testRetractBid(t *testing.T, manager BidManager) {
+	bids := [][]string{
+		{"Sasha", "$50.00", "$80.00", "$3.00"},
+		{"Pat", "$55.00", "$85.00", "$5.00"},
+	}
+	for _, bid := range bids {
+		err := manager.AddBid(bid[0], bid[1], bid[2], bid[3])
+		if err != nil {
+			t.Fatalf("Failed to add bid: %s", err.Error())
+		}
+	}
+
+	err := manager.RetractBid("Pat")
+	if err != nil {
+		t.Fatalf("Failed to retract bid: %s", err.Error())
+	}
+
+	expected := auction.WinningBid{
+		Bidder: auction.Bidder("Sasha"),
+		Amount: currency.MustNew(50, 0, currency.USD),
+	}
+	winner, err := manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, winner) {
+		t.Fatalf("Expected %#v, got %#v", expected, winner)
+	}
+
+	err = manager.RetractBid("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to retract bid: %s", err.Error())
+	}
+	_, err = manager.CalculateWinner()
+	if _, ok := err.(*EmptyBidListError); !ok {
+		t.Fatalf("Expected EmptyBidListError but got: %#v", err)
+	}
+}
+
+func testChangeMissingBid(t *testing.T, manager BidManager) {
+	var notFound *storage.BidderNotFoundError
+	err := manager.AmendBid("mockBidder", "$20", "$1")
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected BidderNotFoundError but got %#v", err)
+	}
+	err = manager.RetractBid("mockBidder")
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected BidderNotFoundError but got %#v", err)
+	}
+}
+
+func testCalculateResults(t *testing.T, manager BidManager) {
+	_, err := manager.CalculateResults()
+	if _, ok := err.(*EmptyBidListError); !ok {
+		t.Fatalf("Expected EmptyBidListError but got: %#v", err)
+	}
+
+	// Provided Test Case 2. Without Riley, Charlie beats Morgan at $721.00, and Morgan is left alone at their starting
+	// bid.
+	err = errors.Join(
+		manager.AddBid("Riley", "$700.00", "$725.00", "$2.00"),
+		manager.AddBid("Morgan", "$599.00", "$725.00", "$15.00"),
+		manager.AddBid("Charlie", "$625.00", "$725.00", "$8.00"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to add bids: %s", err.Error())
+	}
+	expected := []auction.Result{
+		{Bidder: "Riley", Amount: currency.MustNew(722, 0, currency.USD), Rank: 1, Exhausted: false, SecondChancePrice: currency.MustNew(722, 0, currency.USD)},
+		{Bidder: "Charlie", Amount: currency.MustNew(721, 0, currency.USD), Rank: 2, Exhausted: true, SecondChancePrice: currency.MustNew(721, 0, currency.USD)},
+		{Bidder: "Morgan", Amount: currency.MustNew(719, 0, currency.USD), Rank: 3, Exhausted: true, SecondChancePrice: currency.MustNew(599, 0, currency.USD)},
+	}
+	results, err := manager.CalculateResults()
+	if err != nil {
+		t.Fatalf("Failed to calculate results: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Expected %#v, got %#v", expected, results)
+	}
+}
+
+func testSecondChance(t *testing.T, manager BidManager) {
+	// Provided Test Case 1. Without Pat, John wins at $82.00, and without John as well, Sasha is left alone at their
+	// starting bid.
+	err := errors.Join(
+		manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00"),
+		manager.AddBid("John", "$60.00", "$82.00", "$2.00"),
+		manager.AddBid("Pat", "$55.00", "$85.00", "$5.00"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to add bids: %s", err.Error())
+	}
+	_, err = manager.DeclareDefault("Pat")
+	if _, ok := err.(*AuctionOpenError); !ok {
+		t.Fatalf("Expected AuctionOpenError but got: %#v", err)
+	}
+	err = manager.Close()
+	if err != nil {
+		t.Fatalf("Failed to close auction: %s", err.Error())
+	}
+	_, err = manager.DeclareDefault("John")
+	if _, ok := err.(*NotWinnerError); !ok {
+		t.Fatalf("Expected NotWinnerError but got: %#v", err)
+	}
+
+	offer, err := manager.DeclareDefault("Pat")
+	if err != nil {
+		t.Fatalf("Failed to declare default: %s", err.Error())
+	}
+	if offer.Bidder != "John" || offer.Amount != currency.MustNew(82, 0, currency.USD) {
+		t.Fatalf("Expected the lot to be offered to John at $82.00, got %#v", offer)
+	}
+	_, err = manager.DeclareDefault("John")
+	if _, ok := err.(*OfferPendingError); !ok {
+		t.Fatalf("Expected OfferPendingError but got: %#v", err)
+	}
+	_, err = manager.AcceptOffer("Sasha")
+	if _, ok := err.(*NoOfferError); !ok {
+		t.Fatalf("Expected NoOfferError but got: %#v", err)
+	}
+
+	offer, err = manager.DeclineOffer("John")
+	if err != nil {
+		t.Fatalf("Failed to decline offer: %s", err.Error())
+	}
+	if offer.Bidder != "Sasha" || offer.Amount != currency.MustNew(50, 0, currency.USD) {
+		t.Fatalf("Expected the lot to be offered to Sasha at $50.00, got %#v", offer)
+	}
+	expected := auction.WinningBid{Bidder: "Sasha", Amount: currency.MustNew(50, 0, currency.USD)}
+	winner, err := manager.AcceptOffer("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to accept offer: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, winner) {
+		t.Fatalf("Expected %#v, got %#v", expected, winner)
+	}
+	winner, err = manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, winner) {
+		t.Fatalf("Expected the accepted offer to be the winner %#v, got %#v", expected, winner)
+	}
+	// Pat defaulted and John declined, so only Sasha is ranked
+	results, err := manager.CalculateResults()
+	if err != nil {
+		t.Fatalf("Failed to calculate results: %s", err.Error())
+	}
+	expectedResults := []auction.Result{{Bidder: "Sasha", Amount: expected.Amount, Rank: 1, SecondChancePrice: expected.Amount}}
+	if !reflect.DeepEqual(expectedResults, results) {
+		t.Fatalf("Expected %#v, got %#v", expectedResults, results)
+	}
+
+	_, err = manager.DeclareDefault("Sasha")
+	if _, ok := err.(*NoRunnerUpError); !ok {
+		t.Fatalf("Expected NoRunnerUpError but got: %#v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package bid_manager
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/events"
+	"errors"
+	"time"
+)
+
+const defaultOfferWindow = 48 * time.Hour
+
+// DeclareDefault records that the winner of a closed auction did not pay, including a bidder who bought the lot at its
+// buy-it-now price, releases their max bid from the bidder registry and forfeits the deposit captured from them. The winner is recalculated from the stored
+// bids without every bidder who has defaulted or declined, and the lot is offered to the new winner at their price. An
+// offer must be accepted or declined before another bidder can default.
+func (m defaultBidManager[M]) DeclareDefault(bidder string) (auction.SecondChanceOfferOf[M], error) {
+	m.state.mtx.Lock()
+	defer m.state.mtx.Unlock()
+	if !m.state.closed {
+		return auction.SecondChanceOfferOf[M]{}, &AuctionOpenError{}
+	}
+	if m.state.offer != nil {
+		return auction.SecondChanceOfferOf[M]{}, &OfferPendingError{bidder: m.state.offer.Bidder}
+	}
+	if !m.state.settled {
+		err := m.settle()
+		if err != nil {
+			return auction.SecondChanceOfferOf[M]{}, err
+		}
+	}
+
+	winner, err := m.winner()
+	var empty *EmptyBidListError
+	if errors.As(err, &empty) || (err == nil && winner.Bidder != auction.Bidder(bidder)) {
+		return auction.SecondChanceOfferOf[M]{}, &NotWinnerError{bidder: auction.Bidder(bidder)}
+	} else if err != nil {
+		return auction.SecondChanceOfferOf[M]{}, err
+	}
+
+	err = m.change(events.BidderDefaulted{Header: m.newHeader(), Bidder: winner.Bidder})
+	if err != nil {
+		return auction.SecondChanceOfferOf[M]{}, err
+	}
+	err = errors.Join(m.release(winner.Bidder), m.forfeitDeposit(winner.Bidder))
+	if err != nil {
+		return auction.SecondChanceOfferOf[M]{}, err
+	}
+	return m.offerNext()
+}
+
+// AcceptOffer accepts the second-chance offer made to the bidder, making them the winner at the price of the offer.
+// The price is recorded as outstanding in the bidder registry until the lot is paid for, since the bidder's max bid was
+// released when they lost, so an offer the bidder can no longer afford cannot be accepted. An offer can no longer be
+// accepted once it has expired.
+func (m defaultBidManager[M]) AcceptOffer(bidder string) (auction.WinningBidOf[M], error) {
+	m.state.mtx.Lock()
+	defer m.state.mtx.Unlock()
+
+	offer, err := m.pendingOffer(auction.Bidder(bidder))
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	if m.now().After(offer.ExpiresAt) {
+		return auction.WinningBidOf[M]{}, &OfferExpiredError{bidder: offer.Bidder, expiredAt: offer.ExpiresAt}
+	}
+
+	err = m.reserve(auction.BidOf[M]{Bidder: offer.Bidder, MaxBid: offer.Amount})
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	winner := auction.WinningBidOf[M]{Bidder: offer.Bidder, Amount: offer.Amount}
+	err = m.change(events.SecondChanceAcceptedOf[M]{Header: m.newHeader(), Winner: winner})
+	if err != nil {
+		return auction.WinningBidOf[M]{}, errors.Join(err, m.release(winner.Bidder))
+	}
+	return winner, nil
+}
+
+// DeclineOffer declines the second-chance offer made to the bidder and offers the lot to the next bidder. An expired
+// offer can be declined on the bidder's behalf so that the lot moves on.
+func (m defaultBidManager[M]) DeclineOffer(bidder string) (auction.SecondChanceOfferOf[M], error) {
+	m.state.mtx.Lock()
+	defer m.state.mtx.Unlock()
+
+	offer, err := m.pendingOffer(auction.Bidder(bidder))
+	if err != nil {
+		return auction.SecondChanceOfferOf[M]{}, err
+	}
+	err = m.change(events.SecondChanceDeclined{Header: m.newHeader(), Bidder: offer.Bidder})
+	if err != nil {
+		return auction.SecondChanceOfferOf[M]{}, err
+	}
+	return m.offerNext()
+}
+
+// offerNext offers the lot to the winner of the remaining bids. It must be called with the state lock held.
+func (m defaultBidManager[M]) offerNext() (auction.SecondChanceOfferOf[M], error) {
+	bids, err := m.remainingBids()
+	if err != nil {
+		return auction.SecondChanceOfferOf[M]{}, err
+	}
+	if len(bids) == 0 {
+		return auction.SecondChanceOfferOf[M]{}, &NoRunnerUpError{}
+	}
+	result, err := m.calculate(bids)
+	if err != nil {
+		return auction.SecondChanceOfferOf[M]{}, err
+	}
+
+	offer := auction.SecondChanceOfferOf[M]{
+		Bidder:    result.winner.Bidder,
+		Amount:    result.winner.Amount,
+		ExpiresAt: m.now().Add(m.offerWindow),
+	}
+	err = m.change(events.SecondChanceOfferedOf[M]{Header: m.newHeader(), Offer: offer})
+	if err != nil {
+		return auction.SecondChanceOfferOf[M]{}, err
+	}
+	return offer, nil
+}
+
+// pendingOffer returns the offer waiting for a response from the bidder
+func (m defaultBidManager[M]) pendingOffer(bidder auction.Bidder) (auction.SecondChanceOfferOf[M], error) {
+	if m.state.offer == nil || m.state.offer.Bidder != bidder {
+		return auction.SecondChanceOfferOf[M]{}, &NoOfferError{bidder: bidder}
+	}
+	return *m.state.offer, nil
+}
+
+// remainingBids returns the stored bids of the bidders who can still win the lot
+func (m defaultBidManager[M]) remainingBids() (auction.BidMapOf[M], error) {
+	bids, err := m.storage.GetAllBids()
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to fetch bids"), err)
+	}
+	return exclude(bids, m.state.excluded), nil
+}
+
+// exclude returns the bids without those of the excluded bidders
+func exclude[M currency.Money[M]](bids auction.BidMapOf[M], excluded map[auction.Bidder]bool) auction.BidMapOf[M] {
+	if len(excluded) == 0 {
+		return bids
+	}
+	remaining := auction.BidMapOf[M]{}
+	for bidder, bid := range bids {
+		if !excluded[bidder] {
+			remaining[bidder] = bid
+		}
+	}
+	return remaining
+}
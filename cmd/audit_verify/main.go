@@ -0,0 +1,52 @@
+// audit_verify checks that an audit log written by audit.NewFileEntryStorage has not been tampered with. It exits with
+// a non-zero status if any entry has been changed, removed or reordered.
+//
+// The chain alone cannot tell a log that was truncated, or rewritten from the start, from an untouched one. Pass the
+// head printed by an earlier run, or returned by audit.Log.Head, to also check that the log still holds that entry.
+//
+// Usage:
+//
+//	go run ./cmd/audit_verify [-count <entries> -head <hash>] <path to audit log>
+package main
+
+import (
+	"auction/audit"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	count := flag.Uint64("count", 0, "sequence of the expected head entry")
+	hash := flag.String("head", "", "hash of the expected head entry")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: audit_verify [-count <entries> -head <hash>] <path to audit log>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || (*count == 0) != (*hash == "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	store := audit.NewFileEntryStorage(flag.Arg(0))
+	entries, err := store.GetAll()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read audit log: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	err = audit.VerifyHead(entries, audit.Head{Sequence: *count, Hash: *hash})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	if *count == 0 {
+		fmt.Fprintln(os.Stderr, "warning: no head given, so a truncated or rewritten log cannot be detected")
+	}
+	fmt.Printf("verified %d entries\n", len(entries))
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		fmt.Printf("head: -count %d -head %s\n", last.Sequence, last.Hash)
+	}
+}
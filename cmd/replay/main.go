@@ -0,0 +1,112 @@
+// replay rebuilds an auction from an event log written by events.NewFileLog, as an event sourced bid manager would
+// have seen it after a given event or at a given time, and prints the bids, the winner and the results as JSON. Without
+// -event or -time the whole log is replayed.
+//
+// Usage:
+//
+//	go run ./cmd/replay [-event <id> | -time <RFC 3339 time>] [-big] <path to event log>
+package main
+
+import (
+	"auction/auction"
+	"auction/bid_manager"
+	"auction/currency"
+	"auction/events"
+	"auction/id_generator"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"time"
+)
+
+// state is the replayed auction. Winner and Results are omitted when there were no bids.
+type state[M currency.Money[M]] struct {
+	LastEventID id_generator.EventID     `json:"last_event_id"`
+	Closed      bool                     `json:"closed"`
+	Bids        auction.BidMapOf[M]      `json:"bids"`
+	Excluded    []auction.Bidder         `json:"excluded,omitempty"`
+	Winner      *auction.WinningBidOf[M] `json:"winner,omitempty"`
+	Results     []auction.ResultOf[M]    `json:"results,omitempty"`
+}
+
+func main() {
+	event := flag.Uint64("event", 0, "ID of the last event to replay")
+	at := flag.String("time", "", "replay the events that occurred at or before this RFC 3339 time")
+	big := flag.Bool("big", false, "read the amounts of the log as currency.BigAmount")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: replay [-event <id> | -time <RFC 3339 time>] [-big] <path to event log>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || (*event != 0 && *at != "") || *event > math.MaxUint32 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var replayed any
+	var err error
+	if *big {
+		replayed, err = replay[currency.BigAmount](flag.Arg(0), id_generator.EventID(*event), *at)
+	} else {
+		replayed, err = replay[currency.Amount](flag.Arg(0), id_generator.EventID(*event), *at)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	body, err := json.MarshalIndent(replayed, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode the auction: %s\n", err.Error())
+		os.Exit(1)
+	}
+	fmt.Println(string(body))
+}
+
+// replay rebuilds the auction up to the event with the ID, or up to the time if it is set
+func replay[M currency.Money[M]](path string, id id_generator.EventID, at string) (state[M], error) {
+	log := events.NewFileLogOf[M](path)
+	var snapshot bid_manager.SnapshotOf[M]
+	var err error
+	if at != "" {
+		t, parseErr := time.Parse(time.RFC3339, at)
+		if parseErr != nil {
+			return state[M]{}, errors.Join(errors.New("failed to parse time"), parseErr)
+		}
+		snapshot, err = bid_manager.ReplayToTimeOf[M](log, t)
+	} else {
+		if id == 0 {
+			id = math.MaxUint32
+		}
+		snapshot, err = bid_manager.ReplayToEventOf[M](log, id)
+	}
+	if err != nil {
+		return state[M]{}, err
+	}
+
+	replayed := state[M]{
+		LastEventID: snapshot.LastEventID,
+		Closed:      snapshot.Closed,
+		Bids:        snapshot.Bids,
+	}
+	for bidder := range snapshot.Excluded {
+		replayed.Excluded = append(replayed.Excluded, bidder)
+	}
+	slices.Sort(replayed.Excluded)
+	winner, err := snapshot.CalculateWinner()
+	var empty *bid_manager.EmptyBidListError
+	if errors.As(err, &empty) {
+		return replayed, nil
+	} else if err != nil {
+		return state[M]{}, errors.Join(errors.New("failed to calculate winner"), err)
+	}
+	replayed.Winner = &winner
+	replayed.Results, err = snapshot.CalculateResults()
+	if err != nil {
+		return state[M]{}, errors.Join(errors.New("failed to calculate results"), err)
+	}
+	return replayed, nil
+}
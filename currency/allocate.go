@@ -0,0 +1,98 @@
+package currency
+
+import (
+	"math/big"
+	"sort"
+)
+
+// Allocate splits the amount into parts in proportion to the ratios, so that the parts always add up to the amount.
+// Each part is first rounded towards zero, and the minor units that are left over are given one at a time to the parts
+// with the largest remainders (the largest remainder method). Parts with equal remainders are given minor units in the
+// order of their ratios, so the same amount and ratios are always split the same way.
+func (a Amount) Allocate(ratios ...int64) ([]Amount, error) {
+	parts, err := allocate(a.bigUnits(), ratios)
+	if err != nil {
+		return nil, err
+	}
+	amounts := make([]Amount, len(parts))
+	for i, units := range parts {
+		amounts[i] = FromMinorUnits(units.Int64(), a.Code())
+	}
+	return amounts, nil
+}
+
+// allocate splits a number of minor units in proportion to the ratios with the largest remainder method
+func allocate(units *big.Int, ratios []int64) ([]*big.Int, error) {
+	if len(ratios) == 0 {
+		return nil, &InvalidRatiosError{reason: "at least one ratio is required"}
+	}
+	total := new(big.Int)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, &InvalidRatiosError{reason: "ratios cannot be negative"}
+		}
+		total.Add(total, big.NewInt(ratio))
+	}
+	if total.Sign() == 0 {
+		return nil, &InvalidRatiosError{reason: "at least one ratio must be greater than zero"}
+	}
+
+	// the size of the amount is allocated and the sign is applied to each part afterwards, so that negative amounts
+	// are split the same way as positive ones
+	size := new(big.Int).Abs(units)
+
+	type part struct {
+		units     *big.Int
+		remainder *big.Int
+	}
+	parts := make([]part, len(ratios))
+	allocated := new(big.Int)
+	for i, ratio := range ratios {
+		share := new(big.Int).Mul(size, big.NewInt(ratio))
+		units, remainder := share.QuoRem(share, total, new(big.Int))
+		parts[i] = part{units: units, remainder: remainder}
+		allocated.Add(allocated, units)
+	}
+
+	// fewer minor units are left over than there are parts
+	left := new(big.Int).Sub(size, allocated).Int64()
+	order := make([]part, len(parts))
+	copy(order, parts)
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].remainder.Cmp(order[j].remainder) > 0
+	})
+	for i := int64(0); i < left; i++ {
+		order[i].units.Add(order[i].units, big.NewInt(1))
+	}
+
+	allocation := make([]*big.Int, len(parts))
+	for i, p := range parts {
+		if units.Sign() < 0 {
+			p.units.Neg(p.units)
+		}
+		allocation[i] = p.units
+	}
+	return allocation, nil
+}
+
+// Split divides the amount into n parts that are as even as possible and add up to the amount. The minor units that
+// cannot be divided evenly are given to the first parts.
+func (a Amount) Split(n int) ([]Amount, error) {
+	ratios, err := even(n)
+	if err != nil {
+		return nil, err
+	}
+	return a.Allocate(ratios...)
+}
+
+// even returns n equal ratios
+func even(n int) ([]int64, error) {
+	if n <= 0 {
+		return nil, &InvalidRatiosError{reason: "an amount must be split into at least one part"}
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return ratios, nil
+}
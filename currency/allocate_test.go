@@ -0,0 +1,69 @@
+package currency
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAmount_Allocate(t *testing.T) {
+	type testCase struct {
+		name     string
+		a        Amount
+		ratios   []int64
+		expected []Amount
+	}
+	testCases := []testCase{
+		{"Even", MustNew(100, 0, USD), []int64{1, 1}, []Amount{MustNew(50, 0, USD), MustNew(50, 0, USD)}},
+		{"Ratio", MustNew(100, 0, USD), []int64{70, 30}, []Amount{MustNew(70, 0, USD), MustNew(30, 0, USD)}},
+		{"Remainder To First", MustNew(0, 5, USD), []int64{3, 7}, []Amount{MustNew(0, 2, USD), MustNew(0, 3, USD)}},
+		{"Largest Remainder", MustNew(1, 0, USD), []int64{1, 1, 1}, []Amount{MustNew(0, 34, USD), MustNew(0, 33, USD), MustNew(0, 33, USD)}},
+		{"Largest Remainder Not First", MustNew(0, 10, USD), []int64{21, 36, 43}, []Amount{MustNew(0, 2, USD), MustNew(0, 4, USD), MustNew(0, 4, USD)}},
+		{"Zero Ratio", MustNew(10, 0, USD), []int64{0, 1, 1}, []Amount{MustNew(0, 0, USD), MustNew(5, 0, USD), MustNew(5, 0, USD)}},
+		{"Negative", MustNew(-1, 0, USD), []int64{1, 1, 1}, []Amount{MustNew(0, -34, USD), MustNew(0, -33, USD), MustNew(0, -33, USD)}},
+		{"Yen", MustNew(1000, 0, JPY), []int64{1, 2}, []Amount{MustNew(333, 0, JPY), MustNew(667, 0, JPY)}},
+		{"Large", FromMinorUnits(math.MaxInt64, USD), []int64{math.MaxInt64, 1}, []Amount{FromMinorUnits(math.MaxInt64-1, USD), FromMinorUnits(1, USD)}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			parts, err := test.a.Allocate(test.ratios...)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if !reflect.DeepEqual(parts, test.expected) {
+				t.Errorf("Expected: %v, got: %v", test.expected, parts)
+			}
+		})
+	}
+}
+
+func TestAmount_AllocateInvalid(t *testing.T) {
+	for name, ratios := range map[string][]int64{
+		"None":     {},
+		"Negative": {1, -1},
+		"Zero":     {0, 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := MustNew(10, 0, USD).Allocate(ratios...)
+			if _, ok := err.(*InvalidRatiosError); !ok {
+				t.Errorf("Expected InvalidRatiosError but got %#v", err)
+			}
+		})
+	}
+}
+
+func TestAmount_Split(t *testing.T) {
+	parts, err := MustNew(10, 0, USD).Split(3)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	expected := []Amount{MustNew(3, 34, USD), MustNew(3, 33, USD), MustNew(3, 33, USD)}
+	if !reflect.DeepEqual(parts, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, parts)
+	}
+
+	_, err = MustNew(10, 0, USD).Split(0)
+	if _, ok := err.(*InvalidRatiosError); !ok {
+		t.Errorf("Expected InvalidRatiosError but got %#v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package currency
+
+import (
+	"math"
+	"math/big"
+)
+
+// MulInt returns the amount multiplied by n, such as the price of n identical lots
+func (a Amount) MulInt(n int64) (Amount, error) {
+	units, ok := mul(a.units, n)
+	if !ok {
+		return Amount{}, &OverflowError{}
+	}
+	return FromMinorUnits(units, a.Code()), nil
+}
+
+// MulRate returns the amount multiplied by the rate, rounded to a minor unit with the rounding mode
+func (a Amount) MulRate(r Rate, mode RoundingMode) (Amount, error) {
+	numerator, denominator := r.fraction()
+	numerator.Mul(numerator, big.NewInt(a.units))
+	return a.round(numerator, denominator, mode)
+}
+
+// Percent returns the given percentage of the amount, such as a 12.5% buyer's premium, rounded to a minor unit with
+// the rounding mode
+func (a Amount) Percent(percent Rate, mode RoundingMode) (Amount, error) {
+	numerator, denominator := percent.fraction()
+	numerator.Mul(numerator, big.NewInt(a.units))
+	denominator.Mul(denominator, big.NewInt(100))
+	return a.round(numerator, denominator, mode)
+}
+
+// Div returns the amount divided by n, rounded to a minor unit with the rounding mode. Use Allocate to split an
+// amount into parts that add up to the whole.
+func (a Amount) Div(n int64, mode RoundingMode) (Amount, error) {
+	if n == 0 {
+		return Amount{}, &DivisionByZeroError{}
+	}
+	return a.round(big.NewInt(a.units), big.NewInt(n), mode)
+}
+
+// round returns numerator / denominator minor units in the currency of the amount
+func (a Amount) round(numerator, denominator *big.Int, mode RoundingMode) (Amount, error) {
+	return a.fromBig(mode.divide(numerator, denominator), a.Code())
+}
+
+func (a Amount) bigUnits() *big.Int {
+	return big.NewInt(a.units)
+}
+
+// fromBig creates an amount from a number of minor units, returning an OverflowError if it does not fit in an int64
+func (a Amount) fromBig(units *big.Int, code Code) (Amount, error) {
+	if !units.IsInt64() {
+		return Amount{}, &OverflowError{}
+	}
+	return FromMinorUnits(units.Int64(), code), nil
+}
+
+// maxSize allows every size of an int64 to be parsed, so that an amount that is too large is reported where it starts
+// to overflow
+func (a Amount) maxSize() *big.Int {
+	return new(big.Int).SetUint64(math.MaxUint64)
+}
@@ -0,0 +1,148 @@
+package currency
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAmount_MulInt(t *testing.T) {
+	type testCase struct {
+		name     string
+		a        Amount
+		n        int64
+		expected Amount
+	}
+	testCases := []testCase{
+		{"Zero", MustNew(5, 25, USD), 0, MustNew(0, 0, USD)},
+		{"Simple", MustNew(5, 25, USD), 3, MustNew(15, 75, USD)},
+		{"Negative", MustNew(5, 25, USD), -2, MustNew(-10, -50, USD)},
+		{"Yen", MustNew(1500, 0, JPY), 4, MustNew(6000, 0, JPY)},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			product, err := test.a.MulInt(test.n)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if !reflect.DeepEqual(product, test.expected) {
+				t.Errorf("Expected: %s, got: %s", test.expected, product)
+			}
+		})
+	}
+
+	_, err := FromMinorUnits(math.MaxInt64/2+1, USD).MulInt(2)
+	if _, ok := err.(*OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %#v", err)
+	}
+}
+
+func TestAmount_MulRate(t *testing.T) {
+	type testCase struct {
+		name     string
+		a        Amount
+		rate     Rate
+		mode     RoundingMode
+		expected Amount
+	}
+	testCases := []testCase{
+		{"Exact", MustNew(10, 0, USD), NewRate(125, 3), RoundHalfEven, MustNew(1, 25, USD)},
+		{"Half Even Down", MustNew(0, 25, USD), NewRate(5, 1), RoundHalfEven, MustNew(0, 12, USD)},
+		{"Half Even Up", MustNew(0, 35, USD), NewRate(5, 1), RoundHalfEven, MustNew(0, 18, USD)},
+		{"Half Up", MustNew(0, 25, USD), NewRate(5, 1), RoundHalfUp, MustNew(0, 13, USD)},
+		{"Down", MustNew(0, 99, USD), NewRate(1, 1), RoundDown, MustNew(0, 9, USD)},
+		{"Up", MustNew(0, 91, USD), NewRate(1, 1), RoundUp, MustNew(0, 10, USD)},
+		{"Negative Half Up", MustNew(0, -25, USD), NewRate(5, 1), RoundHalfUp, MustNew(0, -13, USD)},
+		{"Large Rate", MustNew(3, 0, USD), NewRate(12, -2), RoundHalfEven, MustNew(3600, 0, USD)},
+		{"Yen", MustNew(999, 0, JPY), NewRate(1, 1), RoundHalfEven, MustNew(100, 0, JPY)},
+		{"Dinar", MustNew(1, 5, KWD), NewRate(5, 1), RoundHalfEven, MustNew(0, 502, KWD)},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			product, err := test.a.MulRate(test.rate, test.mode)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if !reflect.DeepEqual(product, test.expected) {
+				t.Errorf("Expected: %s, got: %s", test.expected, product)
+			}
+		})
+	}
+
+	_, err := FromMinorUnits(math.MaxInt64, USD).MulRate(NewRate(2, 0), RoundHalfEven)
+	if _, ok := err.(*OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %#v", err)
+	}
+}
+
+func TestAmount_Percent(t *testing.T) {
+	type testCase struct {
+		name     string
+		a        Amount
+		percent  Rate
+		mode     RoundingMode
+		expected Amount
+	}
+	testCases := []testCase{
+		{"Premium", MustNew(1000, 0, USD), NewRate(25, 0), RoundHalfEven, MustNew(250, 0, USD)},
+		{"Fractional Percent", MustNew(1234, 56, USD), NewRate(125, 1), RoundHalfEven, MustNew(154, 32, USD)},
+		{"Sales Tax Half Up", MustNew(19, 90, USD), NewRate(725, 2), RoundHalfUp, MustNew(1, 44, USD)},
+		{"Sales Tax Up", MustNew(19, 90, USD), NewRate(725, 2), RoundUp, MustNew(1, 45, USD)},
+		{"Commission Half Even", MustNew(0, 50, USD), NewRate(5, 0), RoundHalfEven, MustNew(0, 2, USD)},
+		{"Commission Half Up", MustNew(0, 50, USD), NewRate(5, 0), RoundHalfUp, MustNew(0, 3, USD)},
+		{"Commission Up", MustNew(0, 41, USD), NewRate(5, 0), RoundUp, MustNew(0, 3, USD)},
+		{"Hundred Percent", MustNew(7, 77, USD), NewRate(100, 0), RoundDown, MustNew(7, 77, USD)},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			part, err := test.a.Percent(test.percent, test.mode)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if !reflect.DeepEqual(part, test.expected) {
+				t.Errorf("Expected: %s, got: %s", test.expected, part)
+			}
+		})
+	}
+}
+
+func TestAmount_Div(t *testing.T) {
+	type testCase struct {
+		name     string
+		a        Amount
+		n        int64
+		mode     RoundingMode
+		expected Amount
+	}
+	testCases := []testCase{
+		{"Exact", MustNew(9, 0, USD), 3, RoundHalfEven, MustNew(3, 0, USD)},
+		{"Third Half Even", MustNew(10, 0, USD), 3, RoundHalfEven, MustNew(3, 33, USD)},
+		{"Third Up", MustNew(10, 0, USD), 3, RoundUp, MustNew(3, 34, USD)},
+		{"Half Even", MustNew(0, 5, USD), 2, RoundHalfEven, MustNew(0, 2, USD)},
+		{"Half Up", MustNew(0, 5, USD), 2, RoundHalfUp, MustNew(0, 3, USD)},
+		{"Negative Divisor", MustNew(0, 5, USD), -2, RoundHalfUp, MustNew(0, -3, USD)},
+		{"Negative Down", MustNew(-10, 0, USD), 3, RoundDown, MustNew(-3, -33, USD)},
+		{"Negative Up", MustNew(-10, 0, USD), 3, RoundUp, MustNew(-3, -34, USD)},
+		{"Smallest", FromMinorUnits(math.MinInt64, USD), 2, RoundDown, FromMinorUnits(math.MinInt64/2, USD)},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			quotient, err := test.a.Div(test.n, test.mode)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if !reflect.DeepEqual(quotient, test.expected) {
+				t.Errorf("Expected: %s, got: %s", test.expected, quotient)
+			}
+		})
+	}
+
+	_, err := MustNew(1, 0, USD).Div(0, RoundHalfEven)
+	if _, ok := err.(*DivisionByZeroError); !ok {
+		t.Errorf("Expected DivisionByZeroError but got %#v", err)
+	}
+	_, err = FromMinorUnits(math.MinInt64, USD).Div(-1, RoundHalfEven)
+	if _, ok := err.(*OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %#v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package currency
+
+import (
+	"math/big"
+	"strings"
+)
+
+// BigAmount is an amount of money held as an arbitrarily large whole number of minor units. It is for currencies with
+// many decimal places, such as the 18 of ETH, and for amounts that do not fit in the int64 of an Amount, and it
+// implements the same Money interface. Arithmetic never overflows. A BigAmount is immutable, so it is safe to copy, but
+// it holds a pointer and must be compared with Equals or Cmp rather than ==. The zero BigAmount is zero in the
+// DefaultCurrency.
+type BigAmount struct {
+	// units is nil for zero
+	units    *big.Int
+	currency Code
+}
+
+// BigFromMinorUnits creates an amount from a number of minor units of the currency. The units are copied, so changing
+// them afterwards does not change the amount.
+func BigFromMinorUnits(units *big.Int, code Code) BigAmount {
+	if code == DefaultCurrency {
+		code = ""
+	}
+	return BigAmount{units: new(big.Int).Set(units), currency: code}
+}
+
+// Big returns the amount as a BigAmount
+func (a Amount) Big() BigAmount {
+	return BigFromMinorUnits(a.bigUnits(), a.Code())
+}
+
+// Amount returns the amount as an Amount, or an OverflowError if it does not fit in an int64 of minor units
+func (b BigAmount) Amount() (Amount, error) {
+	return Amount{}.fromBig(b.bigUnits(), b.Code())
+}
+
+// Code returns the currency of the amount
+func (b BigAmount) Code() Code {
+	if b.currency == "" {
+		return DefaultCurrency
+	}
+	return b.currency
+}
+
+// MinorUnits returns a copy of the amount as a number of minor units of its currency
+func (b BigAmount) MinorUnits() *big.Int {
+	return b.bigUnits()
+}
+
+// Sign returns -1, 0 or 1 for a negative, zero or positive amount
+func (b BigAmount) Sign() int {
+	if b.units == nil {
+		return 0
+	}
+	return b.units.Sign()
+}
+
+// IsZero reports whether the amount is zero
+func (b BigAmount) IsZero() bool {
+	return b.Sign() == 0
+}
+
+func (b BigAmount) info() Currency {
+	return infoOf(b.Code())
+}
+
+// split returns the sign and the digits of the major and minor units of the size of the amount. The minor units are
+// padded to the number of decimal places of the currency.
+func (b BigAmount) split() (negative bool, major, minor string) {
+	c := b.info()
+	size := new(big.Int).Abs(b.bigUnits())
+	quotient, remainder := size.QuoRem(size, pow10(c.Exponent), new(big.Int))
+	if c.Exponent > 0 {
+		minor = remainder.String()
+		minor = strings.Repeat("0", c.Exponent-len(minor)) + minor
+	}
+	return b.Sign() < 0, quotient.String(), minor
+}
+
+func (b BigAmount) String() string {
+	c := b.info()
+	negative, major, minor := b.split()
+	var s strings.Builder
+	if negative {
+		s.WriteByte('-')
+	}
+	s.WriteString(c.Symbol)
+	s.WriteString(major)
+	if c.Exponent > 0 {
+		s.WriteByte('.')
+		s.WriteString(minor)
+	}
+	return s.String()
+}
+
+// Text returns the lossless text form of the amount, which is the same as the text form of an Amount
+func (b BigAmount) Text() string {
+	negative, major, minor := b.split()
+	return text(b.info(), negative, major, minor)
+}
+
+// Abs returns the size of the amount
+func (b BigAmount) Abs() BigAmount {
+	return b.with(new(big.Int).Abs(b.bigUnits()))
+}
+
+// Neg returns the amount with the opposite sign
+func (b BigAmount) Neg() BigAmount {
+	return b.with(new(big.Int).Neg(b.bigUnits()))
+}
+
+// checkCurrency returns a CurrencyMismatchError if the amounts are not in the same currency
+func (b BigAmount) checkCurrency(amt BigAmount) error {
+	if b.Code() != amt.Code() {
+		return &CurrencyMismatchError{expected: b.Code(), actual: amt.Code()}
+	}
+	return nil
+}
+
+// Add returns the sum of the amounts, or an error if they are in different currencies
+func (b BigAmount) Add(amt BigAmount) (BigAmount, error) {
+	err := b.checkCurrency(amt)
+	if err != nil {
+		return BigAmount{}, err
+	}
+	return b.with(new(big.Int).Add(b.bigUnits(), amt.bigUnits())), nil
+}
+
+// Sub returns the difference of the amounts, or an error if they are in different currencies
+func (b BigAmount) Sub(amt BigAmount) (BigAmount, error) {
+	err := b.checkCurrency(amt)
+	if err != nil {
+		return BigAmount{}, err
+	}
+	return b.with(new(big.Int).Sub(b.bigUnits(), amt.bigUnits())), nil
+}
+
+// Cmp compares the amounts, returning -1 if b is less than amt, 0 if they are equal and 1 if b is greater. Amounts in
+// different currencies cannot be compared without converting them and return a CurrencyMismatchError.
+func (b BigAmount) Cmp(amt BigAmount) (int, error) {
+	err := b.checkCurrency(amt)
+	if err != nil {
+		return 0, err
+	}
+	return b.bigUnits().Cmp(amt.bigUnits()), nil
+}
+
+// Equals is false for amounts in different currencies
+func (b BigAmount) Equals(amt BigAmount) bool {
+	cmp, err := b.Cmp(amt)
+	return err == nil && cmp == 0
+}
+
+// Less is false for amounts in different currencies, as they cannot be ordered without converting them
+func (b BigAmount) Less(amt BigAmount) bool {
+	cmp, err := b.Cmp(amt)
+	return err == nil && cmp < 0
+}
+
+// Greater is false for amounts in different currencies, as they cannot be ordered without converting them
+func (b BigAmount) Greater(amt BigAmount) bool {
+	cmp, err := b.Cmp(amt)
+	return err == nil && cmp > 0
+}
+
+// MulInt returns the amount multiplied by n
+func (b BigAmount) MulInt(n int64) BigAmount {
+	return b.with(new(big.Int).Mul(b.bigUnits(), big.NewInt(n)))
+}
+
+// MulRate returns the amount multiplied by the rate, rounded to a minor unit with the rounding mode
+func (b BigAmount) MulRate(r Rate, mode RoundingMode) BigAmount {
+	numerator, denominator := r.fraction()
+	numerator.Mul(numerator, b.bigUnits())
+	return b.with(mode.divide(numerator, denominator))
+}
+
+// Percent returns the given percentage of the amount, rounded to a minor unit with the rounding mode
+func (b BigAmount) Percent(percent Rate, mode RoundingMode) BigAmount {
+	numerator, denominator := percent.fraction()
+	numerator.Mul(numerator, b.bigUnits())
+	denominator.Mul(denominator, big.NewInt(100))
+	return b.with(mode.divide(numerator, denominator))
+}
+
+// Div returns the amount divided by n, rounded to a minor unit with the rounding mode
+func (b BigAmount) Div(n int64, mode RoundingMode) (BigAmount, error) {
+	if n == 0 {
+		return BigAmount{}, &DivisionByZeroError{}
+	}
+	return b.with(mode.divide(b.bigUnits(), big.NewInt(n))), nil
+}
+
+// Allocate splits the amount into parts in proportion to the ratios in the same way as Amount.Allocate
+func (b BigAmount) Allocate(ratios ...int64) ([]BigAmount, error) {
+	parts, err := allocate(b.bigUnits(), ratios)
+	if err != nil {
+		return nil, err
+	}
+	amounts := make([]BigAmount, len(parts))
+	for i, units := range parts {
+		amounts[i] = b.with(units)
+	}
+	return amounts, nil
+}
+
+// Split divides the amount into n parts in the same way as Amount.Split
+func (b BigAmount) Split(n int) ([]BigAmount, error) {
+	ratios, err := even(n)
+	if err != nil {
+		return nil, err
+	}
+	return b.Allocate(ratios...)
+}
+
+// with returns an amount in the same currency that takes ownership of units
+func (b BigAmount) with(units *big.Int) BigAmount {
+	return BigAmount{units: units, currency: b.currency}
+}
+
+func (b BigAmount) bigUnits() *big.Int {
+	if b.units == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(b.units)
+}
+
+// fromBig never overflows
+func (b BigAmount) fromBig(units *big.Int, code Code) (BigAmount, error) {
+	return BigFromMinorUnits(units, code), nil
+}
+
+// maxSize is nil, as a BigAmount can hold an amount of any size
+func (b BigAmount) maxSize() *big.Int {
+	return nil
+}
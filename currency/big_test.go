@@ -0,0 +1,225 @@
+package currency
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// wei parses a number of minor units for tests
+func wei(t *testing.T, units string) *big.Int {
+	t.Helper()
+	n, ok := new(big.Int).SetString(units, 10)
+	if !ok {
+		t.Fatalf("invalid units %q", units)
+	}
+	return n
+}
+
+func TestBigAmount_String(t *testing.T) {
+	type testCase struct {
+		name     string
+		b        BigAmount
+		expected string
+		text     string
+	}
+	testCases := []testCase{
+		{"Zero", BigAmount{}, "$0.00", "USD 0.00"},
+		{"Ether", BigFromMinorUnits(wei(t, "1500000000000000000"), ETH), "Ξ1.500000000000000000", "ETH 1.500000000000000000"},
+		{"Large Ether", BigFromMinorUnits(wei(t, "123456789000000000000000001"), ETH), "Ξ123456789.000000000000000001", "ETH 123456789.000000000000000001"},
+		{"Negative Bitcoin", BigFromMinorUnits(big.NewInt(-1), BTC), "-₿0.00000001", "BTC -0.00000001"},
+		{"Yen", BigFromMinorUnits(big.NewInt(1500), JPY), "¥1500", "JPY 1500"},
+		{"Unknown", BigFromMinorUnits(big.NewInt(105), "XYZ"), "XYZ 1.05", "XYZ 1.05"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if s := test.b.String(); s != test.expected {
+				t.Errorf("Expected: %s, got: %s", test.expected, s)
+			}
+			if text := test.b.Text(); text != test.text {
+				t.Errorf("Expected text: %s, got: %s", test.text, text)
+			}
+		})
+	}
+}
+
+func TestBigAmount_Arithmetic(t *testing.T) {
+	// larger than an int64 of wei
+	a := BigFromMinorUnits(wei(t, "10000000000000000000"), ETH)
+	b := BigFromMinorUnits(wei(t, "2500000000000000000"), ETH)
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if sum.Text() != "ETH 12.500000000000000000" {
+		t.Errorf("Expected ETH 12.5, got: %s", sum.Text())
+	}
+	difference, err := b.Sub(a)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if difference.Text() != "ETH -7.500000000000000000" {
+		t.Errorf("Expected ETH -7.5, got: %s", difference.Text())
+	}
+	if !b.Less(a) || !a.Greater(b) || a.Equals(b) || !a.Equals(BigFromMinorUnits(wei(t, "10000000000000000000"), ETH)) {
+		t.Errorf("Expected %s to be greater than %s", a, b)
+	}
+	if a.Abs().Neg().Sign() != -1 || !a.Neg().Neg().Equals(a) {
+		t.Errorf("Expected negating twice to return %s", a)
+	}
+
+	_, err = a.Add(BigAmount{})
+	if _, ok := err.(*CurrencyMismatchError); !ok {
+		t.Errorf("Expected CurrencyMismatchError but got %#v", err)
+	}
+	if a.Greater(BigAmount{}) || a.Less(BigAmount{}) || a.Equals(BigAmount{}) {
+		t.Errorf("Expected amounts in different currencies not to be ordered")
+	}
+
+	premium := a.Percent(NewRate(125, 1), RoundHalfEven)
+	if premium.Text() != "ETH 1.250000000000000000" {
+		t.Errorf("Expected ETH 1.25, got: %s", premium.Text())
+	}
+	third, err := a.Div(3, RoundDown)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if third.Text() != "ETH 3.333333333333333333" {
+		t.Errorf("Expected ETH 3.333333333333333333, got: %s", third.Text())
+	}
+	_, err = a.Div(0, RoundDown)
+	if _, ok := err.(*DivisionByZeroError); !ok {
+		t.Errorf("Expected DivisionByZeroError but got %#v", err)
+	}
+	if doubled := a.MulInt(2); doubled.Text() != "ETH 20.000000000000000000" {
+		t.Errorf("Expected ETH 20, got: %s", doubled.Text())
+	}
+
+	parts, err := a.Split(3)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	total := BigFromMinorUnits(big.NewInt(0), ETH)
+	for _, part := range parts {
+		total, _ = total.Add(part)
+	}
+	if !total.Equals(a) || parts[0].Text() != "ETH 3.333333333333333334" {
+		t.Errorf("Expected parts adding up to %s with the remainder first, got: %v", a, parts)
+	}
+}
+
+func TestBigAmount_Immutable(t *testing.T) {
+	units := big.NewInt(100)
+	b := BigFromMinorUnits(units, BTC)
+	units.SetInt64(200)
+	b.MinorUnits().SetInt64(300)
+	if b.MinorUnits().Int64() != 100 {
+		t.Errorf("Expected the amount not to change, got: %s", b)
+	}
+}
+
+func TestBigAmount_Amount(t *testing.T) {
+	amount := MustNew(12, 34, EUR)
+	back, err := amount.Big().Amount()
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if back != amount {
+		t.Errorf("Expected: %v, got: %v", amount, back)
+	}
+
+	large := BigFromMinorUnits(new(big.Int).Add(big.NewInt(math.MaxInt64), big.NewInt(1)), USD)
+	_, err = large.Amount()
+	if _, ok := err.(*OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %#v", err)
+	}
+}
+
+func TestBigAmount_JSON(t *testing.T) {
+	b := BigFromMinorUnits(wei(t, "123456789000000000000000001"), ETH)
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if string(data) != `"ETH 123456789.000000000000000001"` {
+		t.Errorf("Expected ETH text form, got: %s", data)
+	}
+	var decoded BigAmount
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if !decoded.Equals(b) {
+		t.Errorf("Expected: %s, got: %s", b, decoded)
+	}
+
+	// an Amount and a BigAmount have the same text form, but only a BigAmount can read amounts beyond an int64
+	var amount Amount
+	err = json.Unmarshal(data, &amount)
+	if _, ok := err.(*InvalidCurrencyFormatError); !ok {
+		t.Errorf("Expected InvalidCurrencyFormatError but got %#v", err)
+	}
+}
+
+func TestBigAmount_SQL(t *testing.T) {
+	b := BigFromMinorUnits(big.NewInt(-150000000), BTC)
+	value, err := b.Value()
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	var scanned BigAmount
+	err = scanned.Scan([]byte(value.(string)))
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if !scanned.Equals(b) {
+		t.Errorf("Expected: %s, got: %s", b, scanned)
+	}
+	err = scanned.Scan(12)
+	if _, ok := err.(*UnsupportedScanError); !ok {
+		t.Errorf("Expected UnsupportedScanError but got %#v", err)
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	b, err := ParseMoneyIn[BigAmount](EnUS, "Ξ123,456,789.000000000000000001", ETH)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if b.Text() != "ETH 123456789.000000000000000001" {
+		t.Errorf("Expected ETH 123456789.000000000000000001, got: %s", b.Text())
+	}
+	if formatted := DeDE.FormatBig(b); formatted != "123.456.789,000000000000000001 Ξ" {
+		t.Errorf("Expected German formatting, got: %s", formatted)
+	}
+
+	_, err = ParseMoneyIn[Amount](EnUS, "Ξ123,456,789", ETH)
+	if _, ok := err.(*InvalidCurrencyFormatError); !ok {
+		t.Errorf("Expected InvalidCurrencyFormatError but got %#v", err)
+	}
+
+	a, err := ParseMoneyWithDefault[Amount](EnUS, "₿0.5", ETH)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if a != FromMinorUnits(50000000, BTC) {
+		t.Errorf("Expected ₿0.5, got: %s", a)
+	}
+
+	if unit := MinorUnitOf[BigAmount](ETH); unit.Text() != "ETH 0.000000000000000001" {
+		t.Errorf("Expected one wei, got: %s", unit.Text())
+	}
+}
+
+func TestConvertMoney(t *testing.T) {
+	rate := ExchangeRate{From: ETH, To: USD, Rate: NewRate(312055, 2)}
+	converted, err := ConvertMoney(rate, BigFromMinorUnits(wei(t, "2000000000000000001"), ETH), RoundDown)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if converted.Text() != "USD 6241.10" {
+		t.Errorf("Expected USD 6241.10, got: %s", converted.Text())
+	}
+}
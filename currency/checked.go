@@ -0,0 +1,33 @@
+package currency
+
+import "math"
+
+// add returns a + b and false if the sum overflows
+func add(a, b int64) (int64, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+	return sum, true
+}
+
+// sub returns a - b and false if the difference overflows
+func sub(a, b int64) (int64, bool) {
+	difference := a - b
+	if (b > 0 && difference > a) || (b < 0 && difference < a) {
+		return 0, false
+	}
+	return difference, true
+}
+
+// mul returns a * b and false if the product overflows
+func mul(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return product, true
+}
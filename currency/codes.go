@@ -0,0 +1,66 @@
+package currency
+
+// Code is an ISO 4217 currency code, or a code of the same form for a currency that is not in ISO 4217
+type Code string
+
+const (
+	USD Code = "USD"
+	EUR Code = "EUR"
+	GBP Code = "GBP"
+	JPY Code = "JPY"
+	KWD Code = "KWD"
+	CAD Code = "CAD"
+	AUD Code = "AUD"
+	CHF Code = "CHF"
+
+	// BTC and ETH are not ISO 4217 currencies, but are written with codes of the same form. Their minor units are the
+	// satoshi and the wei, and amounts of ETH quickly exceed an int64 of wei, so they are usually held as a BigAmount.
+	BTC Code = "BTC"
+	ETH Code = "ETH"
+)
+
+// DefaultCurrency is used for amounts that do not have a currency, so that amounts created before currencies were
+// supported keep their meaning
+const DefaultCurrency = USD
+
+// Currency describes how amounts in a currency are written. Exponent is the number of digits after the decimal point,
+// so one major unit is 10^Exponent minor units.
+type Currency struct {
+	Code     Code
+	Symbol   string
+	Exponent int
+}
+
+var currencies = map[Code]Currency{
+	USD: {Code: USD, Symbol: "$", Exponent: 2},
+	EUR: {Code: EUR, Symbol: "€", Exponent: 2},
+	GBP: {Code: GBP, Symbol: "£", Exponent: 2},
+	JPY: {Code: JPY, Symbol: "¥", Exponent: 0},
+	KWD: {Code: KWD, Symbol: "KD", Exponent: 3},
+	CAD: {Code: CAD, Symbol: "CA$", Exponent: 2},
+	AUD: {Code: AUD, Symbol: "A$", Exponent: 2},
+	CHF: {Code: CHF, Symbol: "CHF", Exponent: 2},
+	BTC: {Code: BTC, Symbol: "₿", Exponent: 8},
+	ETH: {Code: ETH, Symbol: "Ξ", Exponent: 18},
+}
+
+// Lookup returns the currency for the code. An empty code is the DefaultCurrency.
+func Lookup(code Code) (Currency, error) {
+	if code == "" {
+		code = DefaultCurrency
+	}
+	c, ok := currencies[code]
+	if !ok {
+		return Currency{}, &UnknownCurrencyError{code: code}
+	}
+	return c, nil
+}
+
+// scale returns the number of minor units in one major unit
+func (c Currency) scale() int64 {
+	scale := int64(1)
+	for i := 0; i < c.Exponent; i++ {
+		scale *= 10
+	}
+	return scale
+}
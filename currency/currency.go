@@ -2,122 +2,252 @@ package currency
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
+	"math"
 )
 
+// Amount is an amount of money in a currency, held as a whole number of the minor units of the currency, such as
+// cents or yen. There is only one way to hold each amount, so amounts can be compared with ==. The zero Amount is zero
+// in the DefaultCurrency.
 type Amount struct {
-	Dollars int64
-	Cents   int64
+	units    int64
+	currency Code
 }
 
-func (a Amount) String() string {
-	sign := ""
-	if a.Dollars < 0 || a.Cents < 0 {
-		sign = "-"
-		a = a.Abs()
+// New creates an amount from its major and minor units, such as dollars and cents. The minor units must be less than
+// one major unit and must not have the opposite sign to the major units, so -1.50 is New(-1, -50, USD).
+func New(major, minor int64, code Code) (Amount, error) {
+	c, err := Lookup(code)
+	if err != nil {
+		return Amount{}, err
+	}
+	scale := c.scale()
+	if minor <= -scale || minor >= scale {
+		return Amount{}, &InvalidAmountError{reason: fmt.Sprintf("%d minor units is more than one major unit of %s", minor, c.Code)}
+	}
+	if (major < 0 && minor > 0) || (major > 0 && minor < 0) {
+		return Amount{}, &InvalidAmountError{reason: "major and minor units must have the same sign"}
+	}
+	units, ok := mul(major, scale)
+	if !ok {
+		return Amount{}, &OverflowError{}
+	}
+	units, ok = add(units, minor)
+	if !ok {
+		return Amount{}, &OverflowError{}
 	}
-	return fmt.Sprintf("%s$%d.%02d", sign, a.Dollars, a.Cents)
+	return FromMinorUnits(units, c.Code), nil
 }
 
-func (a Amount) Abs() Amount {
-	return Amount{
-		Dollars: abs(a.Dollars),
-		Cents:   abs(a.Cents),
+// MustNew is like New but panics if the amount is not valid. It is intended for amounts that are known to be valid,
+// such as constants.
+func MustNew(major, minor int64, code Code) Amount {
+	amount, err := New(major, minor, code)
+	if err != nil {
+		panic(err)
 	}
+	return amount
 }
 
-func abs(value int64) int64 {
-	if value < 0 {
-		return -value
+// FromMinorUnits creates an amount from a number of minor units of the currency, so FromMinorUnits(150, USD) is $1.50
+func FromMinorUnits(units int64, code Code) Amount {
+	// the DefaultCurrency is held as an empty code, so that the zero Amount is the same as zero in the DefaultCurrency
+	if code == DefaultCurrency {
+		code = ""
 	}
-	return value
+	return Amount{units: units, currency: code}
 }
 
-func (a Amount) Add(amt Amount) Amount {
-	totalCents := a.Cents + amt.Cents
-	totalDollars := a.Dollars + amt.Dollars
-	if totalCents >= 100 {
-		totalDollars++
-		totalCents -= 100
-	} else if totalCents < 0 {
-		totalDollars--
-		totalCents += 100
-	}
-	return Amount{
-		Dollars: totalDollars,
-		Cents:   totalCents,
+// MinorUnit returns the smallest amount that can be represented in the currency, such as one cent or one yen
+func MinorUnit(code Code) Amount {
+	return FromMinorUnits(1, code)
+}
+
+// Code returns the currency of the amount
+func (a Amount) Code() Code {
+	if a.currency == "" {
+		return DefaultCurrency
 	}
+	return a.currency
+}
+
+// MinorUnits returns the amount as a number of minor units of its currency
+func (a Amount) MinorUnits() int64 {
+	return a.units
+}
+
+// Major returns the whole major units of the amount, such as the dollars of $1.50
+func (a Amount) Major() int64 {
+	return a.units / a.info().scale()
 }
 
-func (a Amount) Sub(amt Amount) Amount {
-	totalCentsA := a.Dollars*100 + a.Cents
-	totalCentsB := amt.Dollars*100 + amt.Cents
+// Minor returns the minor units of the amount that are less than one major unit, such as the cents of $1.50. It has
+// the same sign as the amount.
+func (a Amount) Minor() int64 {
+	return a.units % a.info().scale()
+}
 
-	diffCents := totalCentsA - totalCentsB
-	return Amount{
-		Dollars: diffCents / 100,
-		Cents:   diffCents % 100,
+// Sign returns -1, 0 or 1 for a negative, zero or positive amount
+func (a Amount) Sign() int {
+	switch {
+	case a.units < 0:
+		return -1
+	case a.units > 0:
+		return 1
 	}
+	return 0
 }
 
-func (a Amount) Equals(amt Amount) bool {
-	return a.Dollars == amt.Dollars && a.Cents == amt.Cents
+// IsZero reports whether the amount is zero
+func (a Amount) IsZero() bool {
+	return a.units == 0
 }
 
-func (a Amount) Less(amt Amount) bool {
-	if a.Dollars < amt.Dollars {
-		return true
-	} else if a.Dollars == amt.Dollars && a.Cents < amt.Cents {
-		return true
+// info returns the currency of the amount. Amounts in a currency that is not known are written with two decimal
+// places and the code as the symbol.
+func (a Amount) info() Currency {
+	return infoOf(a.Code())
+}
+
+// infoOf returns the currency for the code, or a currency with two decimal places and the code as the symbol if the
+// code is not known
+func infoOf(code Code) Currency {
+	c, err := Lookup(code)
+	if err != nil {
+		return Currency{Code: code, Symbol: string(code) + " ", Exponent: 2}
 	}
-	return false
+	return c
 }
 
-func (a Amount) Greater(amt Amount) bool {
-	if a.Dollars > amt.Dollars {
-		return true
-	} else if a.Dollars == amt.Dollars && a.Cents > amt.Cents {
-		return true
+// split returns the sign and the major and minor units of the size of the amount. The units are unsigned so that the
+// smallest int64 can be written.
+func (a Amount) split() (negative bool, major, minor uint64) {
+	size := uint64(a.units)
+	if a.units < 0 {
+		negative = true
+		size = -size
 	}
-	return false
+	scale := uint64(a.info().scale())
+	return negative, size / scale, size % scale
 }
 
-// ParseAmount takes in a string dollar value and returns an Amount that is equivalent
-func ParseAmount(s string) (Amount, error) {
-	sign := int64(1)
-	if strings.HasPrefix(s, "-") {
-		sign = -1
-		s = strings.TrimPrefix(s, "-")
+func (a Amount) String() string {
+	c := a.info()
+	negative, major, minor := a.split()
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+	if c.Exponent == 0 {
+		return fmt.Sprintf("%s%s%d", sign, c.Symbol, major)
+	}
+	return fmt.Sprintf("%s%s%d.%0*d", sign, c.Symbol, major, c.Exponent, minor)
+}
+
+// Abs returns the size of the amount. It returns an OverflowError for the one negative amount that has no positive
+// equivalent.
+func (a Amount) Abs() (Amount, error) {
+	if a.units < 0 {
+		return a.Neg()
 	}
+	return a, nil
+}
 
-	s = strings.TrimPrefix(s, "$")
+// Neg returns the amount with the opposite sign
+func (a Amount) Neg() (Amount, error) {
+	if a.units == math.MinInt64 {
+		return Amount{}, &OverflowError{}
+	}
+	return FromMinorUnits(-a.units, a.Code()), nil
+}
 
-	parts := strings.Split(s, ".")
-	if len(parts) > 2 {
-		return Amount{}, &InvalidCurrencyFormatError{amount: s}
+// checkCurrency returns a CurrencyMismatchError if the amounts are not in the same currency
+func (a Amount) checkCurrency(amt Amount) error {
+	if a.Code() != amt.Code() {
+		return &CurrencyMismatchError{expected: a.Code(), actual: amt.Code()}
 	}
+	return nil
+}
 
-	dollars, err := strconv.ParseInt(parts[0], 10, 64)
+// Add returns the sum of the amounts, or an error if they are in different currencies or the sum overflows
+func (a Amount) Add(amt Amount) (Amount, error) {
+	err := a.checkCurrency(amt)
 	if err != nil {
-		return Amount{}, &InvalidCurrencyFormatError{amount: s}
+		return Amount{}, err
+	}
+	units, ok := add(a.units, amt.units)
+	if !ok {
+		return Amount{}, &OverflowError{}
 	}
+	return FromMinorUnits(units, a.Code()), nil
+}
 
-	var cents int64
+// Sub returns the difference of the amounts, or an error if they are in different currencies or the difference
+// overflows
+func (a Amount) Sub(amt Amount) (Amount, error) {
+	err := a.checkCurrency(amt)
+	if err != nil {
+		return Amount{}, err
+	}
+	units, ok := sub(a.units, amt.units)
+	if !ok {
+		return Amount{}, &OverflowError{}
+	}
+	return FromMinorUnits(units, a.Code()), nil
+}
 
-	if len(parts) == 2 {
-		if len(parts[1]) > 2 {
-			return Amount{}, &InvalidCurrencyFormatError{amount: s}
-		}
-		centsString := parts[1] + strings.Repeat("0", 2-len(parts[1]))
-		cents, err = strconv.ParseInt(centsString, 10, 64)
-		if err != nil {
-			return Amount{}, &InvalidCurrencyFormatError{amount: s}
-		}
+// Cmp compares the amounts, returning -1 if a is less than amt, 0 if they are equal and 1 if a is greater. Amounts in
+// different currencies cannot be compared without converting them and return a CurrencyMismatchError.
+func (a Amount) Cmp(amt Amount) (int, error) {
+	err := a.checkCurrency(amt)
+	if err != nil {
+		return 0, err
 	}
+	switch {
+	case a.units < amt.units:
+		return -1, nil
+	case a.units > amt.units:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Equals is false for amounts in different currencies
+func (a Amount) Equals(amt Amount) bool {
+	cmp, err := a.Cmp(amt)
+	return err == nil && cmp == 0
+}
+
+// Less is false for amounts in different currencies, as they cannot be ordered without converting them
+func (a Amount) Less(amt Amount) bool {
+	cmp, err := a.Cmp(amt)
+	return err == nil && cmp < 0
+}
+
+// Greater is false for amounts in different currencies, as they cannot be ordered without converting them
+func (a Amount) Greater(amt Amount) bool {
+	cmp, err := a.Cmp(amt)
+	return err == nil && cmp > 0
+}
+
+// ParseAmount takes in a string value and returns an Amount that is equivalent. The currency is taken from the
+// symbol or code, and values without either are in the DefaultCurrency. The value is parsed in the DefaultLocale.
+func ParseAmount(s string) (Amount, error) {
+	return DefaultLocale.Parse(s)
+}
+
+// ParseAmountIn parses a value in the given currency. The symbol is optional, but if it is given it must be the
+// symbol of the currency.
+func ParseAmountIn(s string, code Code) (Amount, error) {
+	return DefaultLocale.ParseIn(s, code)
+}
+
+// ParseAmountWithDefault parses a value in any currency. The currency is taken from the symbol, and values without a
+// symbol are in the given currency.
+func ParseAmountWithDefault(s string, code Code) (Amount, error) {
+	return DefaultLocale.ParseWithDefault(s, code)
+}
 
-	return Amount{
-		Dollars: sign * dollars,
-		Cents:   sign * cents,
-	}, nil
+// padDigits writes value with at least width digits
+func padDigits(value uint64, width int) string {
+	return fmt.Sprintf("%0*d", width, value)
 }
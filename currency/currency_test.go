@@ -1,6 +1,7 @@
 package currency
 
 import (
+	"math"
 	"reflect"
 	"testing"
 )
@@ -13,14 +14,17 @@ func TestAmount_Add(t *testing.T) {
 		expected Amount
 	}
 	testCases := []testCase{
-		{"Zero", Amount{Dollars: 0, Cents: 0}, Amount{Dollars: 0, Cents: 0}, Amount{Dollars: 0, Cents: 0}},
-		{"OneZero", Amount{Dollars: 1, Cents: 55}, Amount{Dollars: 0, Cents: 0}, Amount{Dollars: 1, Cents: 55}},
-		{"Simple", Amount{Dollars: 1, Cents: 55}, Amount{Dollars: 5, Cents: 29}, Amount{Dollars: 6, Cents: 84}},
-		{"CentRollover", Amount{Dollars: 1, Cents: 99}, Amount{Dollars: 5, Cents: 2}, Amount{Dollars: 7, Cents: 1}},
+		{"Zero", MustNew(0, 0, USD), MustNew(0, 0, USD), MustNew(0, 0, USD)},
+		{"OneZero", MustNew(1, 55, USD), MustNew(0, 0, USD), MustNew(1, 55, USD)},
+		{"Simple", MustNew(1, 55, USD), MustNew(5, 29, USD), MustNew(6, 84, USD)},
+		{"CentRollover", MustNew(1, 99, USD), MustNew(5, 2, USD), MustNew(7, 1, USD)},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			total := test.a1.Add(test.a2)
+			total, err := test.a1.Add(test.a2)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
 			if !reflect.DeepEqual(total, test.expected) {
 				t.Errorf("Expected: %#v, got: %#v", test.expected, total)
 			}
@@ -36,14 +40,17 @@ func TestAmount_Sub(t *testing.T) {
 		expected Amount
 	}
 	testCases := []testCase{
-		{"Zero", Amount{Dollars: 0, Cents: 0}, Amount{Dollars: 0, Cents: 0}, Amount{Dollars: 0, Cents: 0}},
-		{"SubZero", Amount{Dollars: 1, Cents: 55}, Amount{Dollars: 0, Cents: 0}, Amount{Dollars: 1, Cents: 55}},
-		{"Simple", Amount{Dollars: 1, Cents: 00}, Amount{Dollars: 0, Cents: 29}, Amount{Dollars: 0, Cents: 71}},
-		{"CentRollover", Amount{Dollars: 1, Cents: 99}, Amount{Dollars: 5, Cents: 2}, Amount{Dollars: -3, Cents: -3}},
+		{"Zero", MustNew(0, 0, USD), MustNew(0, 0, USD), MustNew(0, 0, USD)},
+		{"SubZero", MustNew(1, 55, USD), MustNew(0, 0, USD), MustNew(1, 55, USD)},
+		{"Simple", MustNew(1, 0, USD), MustNew(0, 29, USD), MustNew(0, 71, USD)},
+		{"CentRollover", MustNew(1, 99, USD), MustNew(5, 2, USD), MustNew(-3, -3, USD)},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			total := test.a1.Sub(test.a2)
+			total, err := test.a1.Sub(test.a2)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
 			if !reflect.DeepEqual(total, test.expected) {
 				t.Errorf("Expected: %#v, got: %#v", test.expected, total)
 			}
@@ -59,10 +66,10 @@ func TestAmount_Equals(t *testing.T) {
 		expected bool
 	}
 	testCases := []testCase{
-		{"Zero", Amount{Dollars: 0, Cents: 0}, Amount{Dollars: 0, Cents: 0}, true},
-		{"First Larger", Amount{Dollars: 1, Cents: 55}, Amount{Dollars: 0, Cents: 0}, false},
-		{"Second Larger", Amount{Dollars: 1, Cents: 55}, Amount{Dollars: 5, Cents: 29}, false},
-		{"Simple", Amount{Dollars: 5, Cents: 99}, Amount{Dollars: 5, Cents: 99}, true},
+		{"Zero", MustNew(0, 0, USD), MustNew(0, 0, USD), true},
+		{"First Larger", MustNew(1, 55, USD), MustNew(0, 0, USD), false},
+		{"Second Larger", MustNew(1, 55, USD), MustNew(5, 29, USD), false},
+		{"Simple", MustNew(5, 99, USD), MustNew(5, 99, USD), true},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
@@ -81,12 +88,12 @@ func TestAmount_ToString(t *testing.T) {
 		expected string
 	}
 	testCases := []testCase{
-		{"Zero", Amount{Dollars: 0, Cents: 0}, "$0.00"},
-		{"One Cent", Amount{Dollars: 0, Cents: 1}, "$0.01"},
-		{"Multiple Cents", Amount{Dollars: 0, Cents: 15}, "$0.15"},
-		{"Dollar No Cents", Amount{Dollars: 1, Cents: 0}, "$1.00"},
-		{"Dollar And Cents", Amount{Dollars: 1, Cents: 33}, "$1.33"},
-		{"Negative", Amount{Dollars: -1, Cents: -33}, "-$1.33"},
+		{"Zero", MustNew(0, 0, USD), "$0.00"},
+		{"One Cent", MustNew(0, 1, USD), "$0.01"},
+		{"Multiple Cents", MustNew(0, 15, USD), "$0.15"},
+		{"Dollar No Cents", MustNew(1, 0, USD), "$1.00"},
+		{"Dollar And Cents", MustNew(1, 33, USD), "$1.33"},
+		{"Negative", MustNew(-1, -33, USD), "-$1.33"},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
@@ -106,17 +113,17 @@ func TestAmount_ParseString(t *testing.T) {
 		shouldError bool
 	}
 	testCases := []testCase{
-		{"Zero", "$0.00", Amount{Dollars: 0, Cents: 0}, false},
-		{"Zero No Dollar Sign", "0.00", Amount{Dollars: 0, Cents: 0}, false},
-		{"Only Cents", "$0.55", Amount{Dollars: 0, Cents: 55}, false},
-		{"Only Dollar", "$1.00", Amount{Dollars: 1, Cents: 0}, false},
-		{"No Decimal Cents", "$1", Amount{Dollars: 1, Cents: 0}, false},
-		{"No Dollar", "$.55", Amount{Dollars: 0, Cents: 55}, true},
-		{"Dollar and Cents", "$5.99", Amount{Dollars: 5, Cents: 99}, false},
-		{"Partial Cents", "$0.5", Amount{Dollars: 0, Cents: 50}, false},
+		{"Zero", "$0.00", MustNew(0, 0, USD), false},
+		{"Zero No Dollar Sign", "0.00", MustNew(0, 0, USD), false},
+		{"Only Cents", "$0.55", MustNew(0, 55, USD), false},
+		{"Only Dollar", "$1.00", MustNew(1, 0, USD), false},
+		{"No Decimal Cents", "$1", MustNew(1, 0, USD), false},
+		{"No Dollar", "$.55", MustNew(0, 55, USD), true},
+		{"Dollar and Cents", "$5.99", MustNew(5, 99, USD), false},
+		{"Partial Cents", "$0.5", MustNew(0, 50, USD), false},
 		{"Empty String", "", Amount{}, true},
 		{"Extra Digits", "$0.55555", Amount{}, true},
-		{"Negative", "-$1.55", Amount{Dollars: -1, Cents: -55}, false},
+		{"Negative", "-$1.55", MustNew(-1, -55, USD), false},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
@@ -144,9 +151,9 @@ func TestAmount_Less(t *testing.T) {
 		expected bool
 	}
 	testCases := []testCase{
-		{"Zero", Amount{Dollars: 0, Cents: 0}, Amount{Dollars: 0, Cents: 0}, false},
-		{"First Larger", Amount{Dollars: 1, Cents: 55}, Amount{Dollars: 0, Cents: 0}, false},
-		{"Second Larger", Amount{Dollars: 1, Cents: 55}, Amount{Dollars: 5, Cents: 29}, true},
+		{"Zero", MustNew(0, 0, USD), MustNew(0, 0, USD), false},
+		{"First Larger", MustNew(1, 55, USD), MustNew(0, 0, USD), false},
+		{"Second Larger", MustNew(1, 55, USD), MustNew(5, 29, USD), true},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
@@ -166,9 +173,9 @@ func TestAmount_Greater(t *testing.T) {
 		expected bool
 	}
 	testCases := []testCase{
-		{"Zero", Amount{Dollars: 0, Cents: 0}, Amount{Dollars: 0, Cents: 0}, false},
-		{"First Larger", Amount{Dollars: 1, Cents: 55}, Amount{Dollars: 0, Cents: 0}, true},
-		{"Second Larger", Amount{Dollars: 1, Cents: 55}, Amount{Dollars: 5, Cents: 29}, false},
+		{"Zero", MustNew(0, 0, USD), MustNew(0, 0, USD), false},
+		{"First Larger", MustNew(1, 55, USD), MustNew(0, 0, USD), true},
+		{"Second Larger", MustNew(1, 55, USD), MustNew(5, 29, USD), false},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
@@ -180,44 +187,266 @@ func TestAmount_Greater(t *testing.T) {
 	}
 }
 
-func TestAmount_abs(t *testing.T) {
+func TestAmount_CurrentAbs(t *testing.T) {
+	type testCase struct {
+		name     string
+		a        Amount
+		expected Amount
+	}
+	testCases := []testCase{
+		{"Zero", MustNew(0, 0, USD), MustNew(0, 0, USD)},
+		{"Positive", MustNew(1, 1, USD), MustNew(1, 1, USD)},
+		{"Negative", MustNew(-1, -1, USD), MustNew(1, 1, USD)},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			absVal, err := test.a.Abs()
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if !reflect.DeepEqual(absVal, test.expected) {
+				t.Fatalf("Expected value %v, got %v", test.expected, absVal)
+			}
+		})
+	}
+}
+
+func TestAmount_CurrencyString(t *testing.T) {
 	type testCase struct {
 		name     string
-		a        int64
+		a1       Amount
+		expected string
+	}
+	testCases := []testCase{
+		{"Euro", MustNew(12, 5, EUR), "€12.05"},
+		{"Pound", MustNew(3, 50, GBP), "£3.50"},
+		{"Yen", MustNew(1500, 0, JPY), "¥1500"},
+		{"Dinar", MustNew(1, 5, KWD), "KD1.005"},
+		{"Negative Dinar", MustNew(-1, -500, KWD), "-KD1.500"},
+		{"Unknown", FromMinorUnits(105, "XYZ"), "XYZ 1.05"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			stringVal := test.a1.String()
+			if test.expected != stringVal {
+				t.Errorf("Expected value to be %s, but was: %s", test.expected, stringVal)
+			}
+		})
+	}
+}
+
+func TestAmount_ParseCurrency(t *testing.T) {
+	type testCase struct {
+		name        string
+		given       string
+		code        Code
+		expected    Amount
+		shouldError bool
+	}
+	testCases := []testCase{
+		{"Euro Symbol", "€12.05", "", MustNew(12, 5, EUR), false},
+		{"Canadian Symbol", "CA$12.05", "", MustNew(12, 5, CAD), false},
+		{"Yen", "¥1500", "", MustNew(1500, 0, JPY), false},
+		{"Yen Decimals", "¥1500.5", "", Amount{}, true},
+		{"Dinar", "KD1.5", "", MustNew(1, 500, KWD), false},
+		{"Dinar Three Decimals", "-KD1.005", "", MustNew(-1, -5, KWD), false},
+		{"Dinar Four Decimals", "KD1.0005", "", Amount{}, true},
+		{"In Currency Without Symbol", "12.05", EUR, MustNew(12, 5, EUR), false},
+		{"In Currency With Symbol", "£12", GBP, MustNew(12, 0, GBP), false},
+		{"In Currency Wrong Symbol", "$12", GBP, Amount{}, true},
+		{"In Unknown Currency", "12", "XYZ", Amount{}, true},
+		{"Signed Dollars", "$-5", "", Amount{}, true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var amount Amount
+			var err error
+			if test.code == "" {
+				amount, err = ParseAmount(test.given)
+			} else {
+				amount, err = ParseAmountIn(test.given, test.code)
+			}
+			if err != nil && !test.shouldError {
+				t.Fatalf("operation failed with: %s", err.Error())
+			} else if err == nil && test.shouldError {
+				t.Fatalf("operation should have failed but didn't")
+			} else if err != nil && test.shouldError {
+				return
+			}
+
+			if test.expected != amount {
+				t.Fatalf("Expected value to be %s, but was: %s", test.expected, amount)
+			}
+		})
+	}
+}
+
+func TestAmount_CurrencyArithmetic(t *testing.T) {
+	yen := MustNew(500, 0, JPY)
+	total, err := yen.Add(MustNew(250, 0, JPY))
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if !total.Equals(MustNew(750, 0, JPY)) {
+		t.Fatalf("Expected ¥750, got %s", total)
+	}
+
+	dinar, err := MustNew(1, 999, KWD).Add(MustNew(0, 2, KWD))
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if !reflect.DeepEqual(MustNew(2, 1, KWD), dinar) {
+		t.Fatalf("Expected KD2.001, got %s", dinar)
+	}
+
+	_, err = yen.Add(MustNew(5, 0, USD))
+	if _, ok := err.(*CurrencyMismatchError); !ok {
+		t.Fatalf("Expected a currency mismatch error and received a different error instead: %v", err)
+	}
+	_, err = yen.Sub(MustNew(5, 0, EUR))
+	if _, ok := err.(*CurrencyMismatchError); !ok {
+		t.Fatalf("Expected a currency mismatch error and received a different error instead: %v", err)
+	}
+	if yen.Less(MustNew(5000, 0, EUR)) || yen.Greater(MustNew(0, 1, EUR)) || yen.Equals(MustNew(500, 0, EUR)) {
+		t.Fatalf("Expected amounts in different currencies to not be comparable")
+	}
+	if !(MustNew(1, 0, USD)).Equals(MustNew(1, 0, DefaultCurrency)) {
+		t.Fatalf("Expected an amount without a currency to be in the default currency")
+	}
+}
+
+func TestMinorUnit(t *testing.T) {
+	type testCase struct {
+		code     Code
+		expected string
+	}
+	testCases := []testCase{
+		{USD, "$0.01"},
+		{JPY, "¥1"},
+		{KWD, "KD0.001"},
+	}
+	for _, test := range testCases {
+		if unit := MinorUnit(test.code).String(); unit != test.expected {
+			t.Fatalf("Expected minor unit of %s to be %s, got %s", test.code, test.expected, unit)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	type testCase struct {
+		name     string
+		major    int64
+		minor    int64
+		code     Code
 		expected int64
 	}
 	testCases := []testCase{
-		{"Zero", 0, 0},
-		{"Positive", 1, 1},
-		{"Negative", -1, 1},
+		{"Positive", 1, 50, USD, 150},
+		{"Negative", -1, -50, USD, -150},
+		{"Only Minor", 0, -5, USD, -5},
+		{"Yen", 1500, 0, JPY, 1500},
+		{"Dinar", 2, 1, KWD, 2001},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			absVal := abs(test.a)
-			if absVal != test.expected {
-				t.Fatalf("Expected value %d, got %d", test.expected, absVal)
+			amount, err := New(test.major, test.minor, test.code)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if amount.MinorUnits() != test.expected {
+				t.Errorf("Expected %d minor units, got %d", test.expected, amount.MinorUnits())
+			}
+			if amount.Major() != test.major || amount.Minor() != test.minor {
+				t.Errorf("Expected %d and %d, got %d and %d", test.major, test.minor, amount.Major(), amount.Minor())
 			}
 		})
 	}
 }
 
-func TestAmount_CurrentAbs(t *testing.T) {
+func TestNew_Invalid(t *testing.T) {
+	_, err := New(1, -50, USD)
+	if _, ok := err.(*InvalidAmountError); !ok {
+		t.Errorf("Expected InvalidAmountError for mixed signs but got %#v", err)
+	}
+	_, err = New(1, 100, USD)
+	if _, ok := err.(*InvalidAmountError); !ok {
+		t.Errorf("Expected InvalidAmountError for too many minor units but got %#v", err)
+	}
+	_, err = New(1, 1, JPY)
+	if _, ok := err.(*InvalidAmountError); !ok {
+		t.Errorf("Expected InvalidAmountError for minor units of yen but got %#v", err)
+	}
+	_, err = New(math.MaxInt64/10, 0, USD)
+	if _, ok := err.(*OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %#v", err)
+	}
+	_, err = New(1, 0, "XYZ")
+	if _, ok := err.(*UnknownCurrencyError); !ok {
+		t.Errorf("Expected UnknownCurrencyError but got %#v", err)
+	}
+}
+
+func TestAmount_Overflow(t *testing.T) {
+	largest := FromMinorUnits(math.MaxInt64, USD)
+	smallest := FromMinorUnits(math.MinInt64, USD)
+
+	_, err := largest.Add(MinorUnit(USD))
+	if _, ok := err.(*OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %#v", err)
+	}
+	_, err = smallest.Sub(MinorUnit(USD))
+	if _, ok := err.(*OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %#v", err)
+	}
+	_, err = MinorUnit(USD).Sub(smallest)
+	if _, ok := err.(*OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %#v", err)
+	}
+	_, err = smallest.Abs()
+	if _, ok := err.(*OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %#v", err)
+	}
+
+	total, err := largest.Add(smallest)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if total.MinorUnits() != -1 {
+		t.Errorf("Expected -1 minor units, got %d", total.MinorUnits())
+	}
+	if smallest.String() != "-$92233720368547758.08" {
+		t.Errorf("Expected the smallest amount to be written in full, got %s", smallest.String())
+	}
+}
+
+func TestAmount_Cmp(t *testing.T) {
 	type testCase struct {
 		name     string
-		a        Amount
-		expected Amount
+		a1       Amount
+		a2       Amount
+		expected int
 	}
 	testCases := []testCase{
-		{"Zero", Amount{Dollars: 0, Cents: 0}, Amount{Dollars: 0, Cents: 0}},
-		{"Positive", Amount{Dollars: 1, Cents: 1}, Amount{Dollars: 1, Cents: 1}},
-		{"Negative", Amount{Dollars: -1, Cents: -1}, Amount{Dollars: 1, Cents: 1}},
+		{"Equal", MustNew(1, 50, USD), MustNew(1, 50, USD), 0},
+		{"Less", MustNew(1, 49, USD), MustNew(1, 50, USD), -1},
+		{"Greater", MustNew(1, 0, USD), MustNew(0, 99, USD), 1},
+		{"Negative", MustNew(-1, -50, USD), MustNew(-1, 0, USD), -1},
+		{"Zero Value", Amount{}, MustNew(0, 0, USD), 0},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			absVal := test.a.Abs()
-			if !reflect.DeepEqual(absVal, test.expected) {
-				t.Fatalf("Expected value %v, got %v", test.expected, absVal)
+			cmp, err := test.a1.Cmp(test.a2)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if cmp != test.expected {
+				t.Errorf("Expected %d, got %d", test.expected, cmp)
 			}
 		})
 	}
+
+	_, err := MustNew(1, 0, USD).Cmp(MustNew(1, 0, EUR))
+	if _, ok := err.(*CurrencyMismatchError); !ok {
+		t.Errorf("Expected CurrencyMismatchError but got %#v", err)
+	}
 }
@@ -2,10 +2,102 @@ package currency
 
 import "fmt"
 
+// InvalidCurrencyFormatError reports why an amount could not be parsed and the position of the character, counted
+// from zero, where parsing failed
 type InvalidCurrencyFormatError struct {
-	amount string
+	amount   string
+	position int
+	reason   string
 }
 
 func (e *InvalidCurrencyFormatError) Error() string {
-	return fmt.Sprintf("invalid format. Currency must be in the form one of ($0.00, 0.00, $0, 0) . Was given: %s", e.amount)
+	return fmt.Sprintf("invalid format at position %d of %q: %s", e.position, e.amount, e.reason)
+}
+
+// Position returns the index of the character where parsing failed
+func (e *InvalidCurrencyFormatError) Position() int {
+	return e.position
+}
+
+// Reason returns why parsing failed
+func (e *InvalidCurrencyFormatError) Reason() string {
+	return e.reason
+}
+
+type CurrencyMismatchError struct {
+	expected Code
+	actual   Code
+}
+
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("currency mismatch. Expected %s, got %s", e.expected, e.actual)
+}
+
+type UnknownCurrencyError struct {
+	code Code
+}
+
+func (e *UnknownCurrencyError) Error() string {
+	return fmt.Sprintf("unknown currency %s", e.code)
+}
+
+type InvalidRateError struct {
+	rate string
+}
+
+func (e *InvalidRateError) Error() string {
+	return fmt.Sprintf("invalid rate. Rates must be decimal numbers, such as 1.0842, and exchange rates must be positive. Was given: %s", e.rate)
+}
+
+type RateNotFoundError struct {
+	from Code
+	to   Code
+}
+
+func (e *RateNotFoundError) Error() string {
+	return fmt.Sprintf("no exchange rate from %s to %s", e.from, e.to)
+}
+
+type OverflowError struct{}
+
+func (e *OverflowError) Error() string {
+	return "amount is too large to be represented"
+}
+
+type UnknownLocaleError struct {
+	name string
+}
+
+func (e *UnknownLocaleError) Error() string {
+	return fmt.Sprintf("unknown locale %s", e.name)
+}
+
+type InvalidAmountError struct {
+	reason string
+}
+
+func (e *InvalidAmountError) Error() string {
+	return fmt.Sprintf("invalid amount: %s", e.reason)
+}
+
+type DivisionByZeroError struct{}
+
+func (e *DivisionByZeroError) Error() string {
+	return "cannot divide an amount by zero"
+}
+
+type InvalidRatiosError struct {
+	reason string
+}
+
+func (e *InvalidRatiosError) Error() string {
+	return fmt.Sprintf("invalid ratios: %s", e.reason)
+}
+
+type UnsupportedScanError struct {
+	value any
+}
+
+func (e *UnsupportedScanError) Error() string {
+	return fmt.Sprintf("cannot scan %T into an amount, it must be stored as text", e.value)
 }
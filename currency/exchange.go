@@ -0,0 +1,131 @@
+package currency
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+// ExchangeRate converts amounts in one currency to another. One major unit of From is worth Rate major units of To.
+type ExchangeRate struct {
+	From Code `json:"from"`
+	To   Code `json:"to"`
+	Rate Rate `json:"rate"`
+}
+
+// Convert converts an amount in the From currency to the To currency. The result is rounded to the minor unit of the
+// To currency using the rounding mode.
+func (r ExchangeRate) Convert(amount Amount, mode RoundingMode) (Amount, error) {
+	return convert(r, amount, mode)
+}
+
+func convert[M Money[M]](r ExchangeRate, amount M, mode RoundingMode) (M, error) {
+	var zero M
+	if amount.Code() != r.From {
+		return zero, &CurrencyMismatchError{expected: r.From, actual: amount.Code()}
+	}
+	from, err := Lookup(r.From)
+	if err != nil {
+		return zero, err
+	}
+	to, err := Lookup(r.To)
+	if err != nil {
+		return zero, err
+	}
+
+	// units * rate * 10^to.Exponent / 10^from.Exponent
+	numerator, denominator := r.Rate.fraction()
+	numerator.Mul(numerator, amount.bigUnits())
+	numerator.Mul(numerator, pow10(to.Exponent))
+	denominator.Mul(denominator, pow10(from.Exponent))
+	return zero.fromBig(mode.divide(numerator, denominator), r.To)
+}
+
+// ExchangeRateProvider provides the rates used to convert between currencies
+type ExchangeRateProvider interface {
+	// Rate returns the rate to convert from one currency to another. Converting a currency to itself always has a
+	// rate of 1.
+	Rate(from, to Code) (ExchangeRate, error)
+}
+
+// Convert converts the amount to another currency with the rate from the provider
+func Convert(amount Amount, to Code, provider ExchangeRateProvider, mode RoundingMode) (Amount, error) {
+	rate, err := provider.Rate(amount.Code(), to)
+	if err != nil {
+		return Amount{}, err
+	}
+	return rate.Convert(amount, mode)
+}
+
+type pair struct {
+	from Code
+	to   Code
+}
+
+// staticRateProvider provides rates from a fixed table
+type staticRateProvider struct {
+	rates map[pair]ExchangeRate
+}
+
+// NewStaticRateProvider creates a provider with a fixed table of rates. Only the given conversions are available, the
+// inverse of a rate is not calculated as it would need to be rounded.
+func NewStaticRateProvider(rates ...ExchangeRate) (ExchangeRateProvider, error) {
+	p := &staticRateProvider{rates: map[pair]ExchangeRate{}}
+	for _, rate := range rates {
+		if _, err := Lookup(rate.From); err != nil {
+			return nil, err
+		}
+		if _, err := Lookup(rate.To); err != nil {
+			return nil, err
+		}
+		if !rate.Rate.IsPositive() {
+			return nil, &InvalidRateError{rate: rate.Rate.String()}
+		}
+		p.rates[pair{from: rate.From, to: rate.To}] = rate
+	}
+	return p, nil
+}
+
+func (p *staticRateProvider) Rate(from, to Code) (ExchangeRate, error) {
+	if from == to {
+		return ExchangeRate{From: from, To: to, Rate: NewRate(1, 0)}, nil
+	}
+	rate, ok := p.rates[pair{from: from, to: to}]
+	if !ok {
+		return ExchangeRate{}, &RateNotFoundError{from: from, to: to}
+	}
+	return rate, nil
+}
+
+// rateEntry is a rate as it is written in a rates file
+type rateEntry struct {
+	From Code   `json:"from"`
+	To   Code   `json:"to"`
+	Rate string `json:"rate"`
+}
+
+// NewFileRateProvider loads a table of rates from a JSON file, which holds a list of objects with the "from" and "to"
+// currency codes and the "rate" as a decimal string, such as {"from": "EUR", "to": "USD", "rate": "1.0842"}. The
+// rates are read once, so a new provider must be created to pick up changes to the file.
+func NewFileRateProvider(path string) (ExchangeRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to read rates file"), err)
+	}
+
+	var entries []rateEntry
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to decode rates file"), err)
+	}
+
+	rates := make([]ExchangeRate, 0, len(entries))
+	for _, entry := range entries {
+		rate, err := ParseRate(entry.Rate)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, ExchangeRate{From: entry.From, To: entry.To, Rate: rate})
+	}
+	return NewStaticRateProvider(rates...)
+}
@@ -0,0 +1,176 @@
+package currency
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseRate(t *testing.T) {
+	type testCase struct {
+		name     string
+		rate     string
+		expected Rate
+		str      string
+	}
+	testCases := []testCase{
+		{"Whole", "2", NewRate(2, 0), "2"},
+		{"Decimal", "1.0842", NewRate(10842, 4), "1.0842"},
+		{"TrailingZeros", "1.50", NewRate(15, 1), "1.5"},
+		{"LessThanOne", "0.0067", NewRate(67, 4), "0.0067"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			rate, err := ParseRate(test.rate)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if !reflect.DeepEqual(rate, test.expected) {
+				t.Errorf("Expected: %#v, got: %#v", test.expected, rate)
+			}
+			if rate.String() != test.str {
+				t.Errorf("Expected: %s, got: %s", test.str, rate.String())
+			}
+		})
+	}
+}
+
+func TestParseRate_Invalid(t *testing.T) {
+	for _, rate := range []string{"", "1.", ".5", "1.2.3", "abc", "1.-5"} {
+		t.Run(rate, func(t *testing.T) {
+			_, err := ParseRate(rate)
+			if _, ok := err.(*InvalidRateError); !ok {
+				t.Errorf("Expected InvalidRateError but got %#v", err)
+			}
+		})
+	}
+}
+
+func TestExchangeRate_Convert(t *testing.T) {
+	type testCase struct {
+		name     string
+		rate     ExchangeRate
+		amount   Amount
+		mode     RoundingMode
+		expected Amount
+	}
+	testCases := []testCase{
+		{"Exact", ExchangeRate{From: EUR, To: USD, Rate: NewRate(15, 1)}, MustNew(10, 0, EUR), RoundHalfEven, MustNew(15, 0, USD)},
+		{"RoundUp", ExchangeRate{From: EUR, To: USD, Rate: NewRate(10842, 4)}, MustNew(10, 5, EUR), RoundHalfEven, MustNew(10, 90, USD)},
+		{"HalfToEven", ExchangeRate{From: USD, To: EUR, Rate: NewRate(5, 1)}, MustNew(0, 5, USD), RoundHalfEven, MustNew(0, 2, EUR)},
+		{"HalfToEvenUp", ExchangeRate{From: USD, To: EUR, Rate: NewRate(5, 1)}, MustNew(0, 7, USD), RoundHalfEven, MustNew(0, 4, EUR)},
+		{"Down", ExchangeRate{From: EUR, To: USD, Rate: NewRate(10842, 4)}, MustNew(10, 5, EUR), RoundDown, MustNew(10, 89, USD)},
+		{"ToZeroDecimals", ExchangeRate{From: USD, To: JPY, Rate: NewRate(14955, 2)}, MustNew(12, 34, USD), RoundHalfEven, MustNew(1845, 0, JPY)},
+		{"FromZeroDecimals", ExchangeRate{From: JPY, To: USD, Rate: NewRate(67, 4)}, MustNew(1845, 0, JPY), RoundHalfEven, MustNew(12, 36, USD)},
+		{"ToThreeDecimals", ExchangeRate{From: USD, To: KWD, Rate: NewRate(3075, 4)}, MustNew(100, 0, USD), RoundHalfEven, MustNew(30, 750, KWD)},
+		{"Negative", ExchangeRate{From: EUR, To: USD, Rate: NewRate(10842, 4)}, MustNew(-10, -5, EUR), RoundDown, MustNew(-10, -89, USD)},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			converted, err := test.rate.Convert(test.amount, test.mode)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if !reflect.DeepEqual(converted, test.expected) {
+				t.Errorf("Expected: %#v, got: %#v", test.expected, converted)
+			}
+		})
+	}
+}
+
+func TestExchangeRate_ConvertErrors(t *testing.T) {
+	rate := ExchangeRate{From: EUR, To: USD, Rate: NewRate(10842, 4)}
+	_, err := rate.Convert(MustNew(10, 0, GBP), RoundHalfEven)
+	if _, ok := err.(*CurrencyMismatchError); !ok {
+		t.Errorf("Expected CurrencyMismatchError but got %#v", err)
+	}
+
+	rate = ExchangeRate{From: EUR, To: JPY, Rate: NewRate(1000000, 0)}
+	_, err = rate.Convert(MustNew(1<<50, 0, EUR), RoundHalfEven)
+	if _, ok := err.(*OverflowError); !ok {
+		t.Errorf("Expected OverflowError but got %#v", err)
+	}
+}
+
+func TestStaticRateProvider(t *testing.T) {
+	eurUSD := ExchangeRate{From: EUR, To: USD, Rate: NewRate(10842, 4)}
+	provider, err := NewStaticRateProvider(eurUSD)
+	if err != nil {
+		t.Fatalf("could not initialize provider: %s", err.Error())
+	}
+
+	rate, err := provider.Rate(EUR, USD)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if !reflect.DeepEqual(rate, eurUSD) {
+		t.Errorf("Expected: %#v, got: %#v", eurUSD, rate)
+	}
+
+	rate, err = provider.Rate(GBP, GBP)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if rate.Rate != NewRate(1, 0) {
+		t.Errorf("Expected a rate of 1 to the same currency, got: %s", rate.Rate)
+	}
+
+	_, err = provider.Rate(USD, EUR)
+	if _, ok := err.(*RateNotFoundError); !ok {
+		t.Errorf("Expected RateNotFoundError but got %#v", err)
+	}
+
+	converted, err := Convert(MustNew(100, 0, EUR), USD, provider, RoundHalfEven)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	expected := MustNew(108, 42, USD)
+	if !reflect.DeepEqual(converted, expected) {
+		t.Errorf("Expected: %#v, got: %#v", expected, converted)
+	}
+}
+
+func TestStaticRateProvider_Invalid(t *testing.T) {
+	_, err := NewStaticRateProvider(ExchangeRate{From: EUR, To: USD, Rate: NewRate(0, 0)})
+	if _, ok := err.(*InvalidRateError); !ok {
+		t.Errorf("Expected InvalidRateError but got %#v", err)
+	}
+	_, err = NewStaticRateProvider(ExchangeRate{From: "XXX", To: USD, Rate: NewRate(1, 0)})
+	if _, ok := err.(*UnknownCurrencyError); !ok {
+		t.Errorf("Expected UnknownCurrencyError but got %#v", err)
+	}
+}
+
+func TestFileRateProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(path, []byte(`[
+		{"from": "EUR", "to": "USD", "rate": "1.0842"},
+		{"from": "JPY", "to": "USD", "rate": "0.0067"}
+	]`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write rates file: %s", err.Error())
+	}
+
+	provider, err := NewFileRateProvider(path)
+	if err != nil {
+		t.Fatalf("could not initialize provider: %s", err.Error())
+	}
+	rate, err := provider.Rate(JPY, USD)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	expected := ExchangeRate{From: JPY, To: USD, Rate: NewRate(67, 4)}
+	if !reflect.DeepEqual(rate, expected) {
+		t.Errorf("Expected: %#v, got: %#v", expected, rate)
+	}
+
+	err = os.WriteFile(path, []byte(`[{"from": "EUR", "to": "USD", "rate": "1,08"}]`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write rates file: %s", err.Error())
+	}
+	_, err = NewFileRateProvider(path)
+	if _, ok := err.(*InvalidRateError); !ok {
+		t.Errorf("Expected InvalidRateError but got %#v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package currency
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// addSeeds adds pairs of minor units that sit on the edges of the int64 range
+func addSeeds(f *testing.F) {
+	f.Add(int64(0), int64(0))
+	f.Add(int64(150), int64(-99))
+	f.Add(int64(math.MaxInt64), int64(1))
+	f.Add(int64(math.MinInt64), int64(-1))
+	f.Add(int64(math.MinInt64), int64(math.MaxInt64))
+}
+
+// fits reports whether the exact result of the operation can be held in an int64
+func fits(x, y int64, op func(z, x, y *big.Int) *big.Int) bool {
+	return op(new(big.Int), big.NewInt(x), big.NewInt(y)).IsInt64()
+}
+
+func FuzzAmount_AddCommutative(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, x, y int64) {
+		a, b := FromMinorUnits(x, USD), FromMinorUnits(y, USD)
+		ab, errAB := a.Add(b)
+		ba, errBA := b.Add(a)
+		if (errAB == nil) != (errBA == nil) {
+			t.Fatalf("a + b and b + a disagree on overflow: %v, %v", errAB, errBA)
+		}
+		if (errAB == nil) != fits(x, y, (*big.Int).Add) {
+			t.Fatalf("overflow of %d + %d was not reported correctly: %v", x, y, errAB)
+		}
+		if errAB == nil && ab != ba {
+			t.Fatalf("a + b = %s, b + a = %s", ab, ba)
+		}
+	})
+}
+
+func FuzzAmount_AddSubInverse(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, x, y int64) {
+		a, b := FromMinorUnits(x, USD), FromMinorUnits(y, USD)
+		sum, err := a.Add(b)
+		if (err == nil) != fits(x, y, (*big.Int).Add) {
+			t.Fatalf("overflow of %d + %d was not reported correctly: %v", x, y, err)
+		}
+		if err == nil {
+			difference, err := sum.Sub(b)
+			if err != nil {
+				t.Fatalf("(a + b) - b failed with: %s", err.Error())
+			}
+			if difference != a {
+				t.Fatalf("(a + b) - b = %s, expected %s", difference, a)
+			}
+		}
+
+		difference, err := a.Sub(b)
+		if (err == nil) != fits(x, y, (*big.Int).Sub) {
+			t.Fatalf("overflow of %d - %d was not reported correctly: %v", x, y, err)
+		}
+		if err == nil {
+			sum, err := difference.Add(b)
+			if err != nil {
+				t.Fatalf("(a - b) + b failed with: %s", err.Error())
+			}
+			if sum != a {
+				t.Fatalf("(a - b) + b = %s, expected %s", sum, a)
+			}
+		}
+	})
+}
+
+func FuzzAmount_Ordering(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, x, y int64) {
+		a, b := FromMinorUnits(x, USD), FromMinorUnits(y, USD)
+		cmp, err := a.Cmp(b)
+		if err != nil {
+			t.Fatalf("operation failed with: %s", err.Error())
+		}
+		reverse, err := b.Cmp(a)
+		if err != nil {
+			t.Fatalf("operation failed with: %s", err.Error())
+		}
+		if cmp != -reverse {
+			t.Fatalf("a.Cmp(b) = %d but b.Cmp(a) = %d", cmp, reverse)
+		}
+		if a.Less(b) != (cmp < 0) || a.Greater(b) != (cmp > 0) || a.Equals(b) != (cmp == 0) {
+			t.Fatalf("Less, Greater and Equals of %s and %s disagree with Cmp %d", a, b, cmp)
+		}
+		if (cmp < 0) != (x < y) {
+			t.Fatalf("%s.Cmp(%s) = %d does not match the order of the minor units", a, b, cmp)
+		}
+
+		// adding the same amount to both sides keeps their order
+		one := MinorUnit(USD)
+		a1, errA := a.Add(one)
+		b1, errB := b.Add(one)
+		if errA == nil && errB == nil {
+			shifted, _ := a1.Cmp(b1)
+			if shifted != cmp {
+				t.Fatalf("adding one minor unit changed the order of %s and %s", a, b)
+			}
+		}
+	})
+}
+
+func FuzzAmount_Allocate(f *testing.F) {
+	f.Add(int64(100), int64(1), int64(1), int64(1))
+	f.Add(int64(-7), int64(3), int64(0), int64(5))
+	f.Add(int64(math.MinInt64), int64(math.MaxInt64), int64(1), int64(2))
+	f.Fuzz(func(t *testing.T, units, r1, r2, r3 int64) {
+		if r1 < 0 || r2 < 0 || r3 < 0 || (r1 == 0 && r2 == 0 && r3 == 0) {
+			t.Skip()
+		}
+		a := FromMinorUnits(units, USD)
+		parts, err := a.Allocate(r1, r2, r3)
+		if err != nil {
+			t.Fatalf("operation failed with: %s", err.Error())
+		}
+
+		// the parts add up to the amount, and each is within one minor unit of its exact share
+		sum := new(big.Int)
+		total := new(big.Rat).SetInt(new(big.Int).Add(new(big.Int).Add(big.NewInt(r1), big.NewInt(r2)), big.NewInt(r3)))
+		for i, ratio := range []int64{r1, r2, r3} {
+			sum.Add(sum, big.NewInt(parts[i].MinorUnits()))
+			exact := new(big.Rat).Mul(new(big.Rat).SetInt64(units), new(big.Rat).SetInt64(ratio))
+			exact.Quo(exact, total)
+			distance := new(big.Rat).Sub(new(big.Rat).SetInt64(parts[i].MinorUnits()), exact)
+			if distance.Abs(distance).Cmp(big.NewRat(1, 1)) >= 0 {
+				t.Fatalf("part %d of %s is %s, more than one minor unit from its share", i, a, parts[i])
+			}
+		}
+		if sum.Cmp(big.NewInt(units)) != 0 {
+			t.Fatalf("parts of %s add up to %s minor units", a, sum)
+		}
+	})
+}
+
+func FuzzAmount_TextRoundTrip(f *testing.F) {
+	f.Add(int64(0), "USD")
+	f.Add(int64(-5), "KWD")
+	f.Add(int64(math.MinInt64), "JPY")
+	f.Fuzz(func(t *testing.T, units int64, code string) {
+		if !isCode(code) {
+			t.Skip()
+		}
+		a := FromMinorUnits(units, Code(code))
+		parsed, err := ParseText(a.Text())
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", a.Text(), err.Error())
+		}
+		if parsed != a {
+			t.Fatalf("Expected %#v, got %#v", a, parsed)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package currency
+
+import (
+	"strings"
+)
+
+// SymbolPosition is where the currency symbol is written relative to the number
+type SymbolPosition int
+
+const (
+	SymbolBefore SymbolPosition = iota
+	SymbolAfter
+)
+
+// NegativeStyle is how a negative amount is written
+type NegativeStyle int
+
+const (
+	// NegativeMinus writes a minus sign before the amount, such as -$12.00
+	NegativeMinus NegativeStyle = iota
+	// NegativeParentheses writes the amount in parentheses, as accounts do, such as ($12.00)
+	NegativeParentheses
+)
+
+// Locale describes how amounts are written in a region. Parsing is more lenient than formatting: an amount may use
+// either negative style, the symbol or ISO code of its currency on either side of the number, and grouping separators
+// are optional.
+type Locale struct {
+	Name             string
+	GroupSeparator   rune
+	DecimalSeparator rune
+	SymbolPosition   SymbolPosition
+	// SymbolSeparator is written between the symbol and the number, such as a space in "12,00 €"
+	SymbolSeparator string
+	NegativeStyle   NegativeStyle
+}
+
+var (
+	EnUS = Locale{Name: "en-US", GroupSeparator: ',', DecimalSeparator: '.', SymbolPosition: SymbolBefore}
+	EnGB = Locale{Name: "en-GB", GroupSeparator: ',', DecimalSeparator: '.', SymbolPosition: SymbolBefore}
+	DeDE = Locale{Name: "de-DE", GroupSeparator: '.', DecimalSeparator: ',', SymbolPosition: SymbolAfter, SymbolSeparator: " "}
+	DeCH = Locale{Name: "de-CH", GroupSeparator: '\'', DecimalSeparator: '.', SymbolPosition: SymbolBefore, SymbolSeparator: " "}
+	FrFR = Locale{Name: "fr-FR", GroupSeparator: '\u202f', DecimalSeparator: ',', SymbolPosition: SymbolAfter, SymbolSeparator: "\u00a0"}
+	JaJP = Locale{Name: "ja-JP", GroupSeparator: ',', DecimalSeparator: '.', SymbolPosition: SymbolBefore}
+)
+
+// DefaultLocale is used by ParseAmount and its variants
+var DefaultLocale = EnUS
+
+var locales = map[string]Locale{
+	EnUS.Name: EnUS,
+	EnGB.Name: EnGB,
+	DeDE.Name: DeDE,
+	DeCH.Name: DeCH,
+	FrFR.Name: FrFR,
+	JaJP.Name: JaJP,
+}
+
+// LookupLocale returns the locale with the given name, such as "de-DE"
+func LookupLocale(name string) (Locale, error) {
+	l, ok := locales[name]
+	if !ok {
+		return Locale{}, &UnknownLocaleError{name: name}
+	}
+	return l, nil
+}
+
+// Format writes the amount with the symbol, separators and negative style of the locale
+func (l Locale) Format(a Amount) string {
+	negative, major, minor := a.split()
+	return l.format(a.info(), negative, padDigits(major, 1), padDigits(minor, a.info().Exponent))
+}
+
+// FormatBig writes the amount with the symbol, separators and negative style of the locale
+func (l Locale) FormatBig(b BigAmount) string {
+	negative, major, minor := b.split()
+	return l.format(b.info(), negative, major, minor)
+}
+
+// format writes an amount from the digits of its major and minor units
+func (l Locale) format(c Currency, negative bool, major, minor string) string {
+	var number strings.Builder
+	number.WriteString(l.group(major))
+	if c.Exponent > 0 {
+		number.WriteRune(l.DecimalSeparator)
+		number.WriteString(minor)
+	}
+
+	symbol := strings.TrimSpace(c.Symbol)
+	var formatted string
+	if l.SymbolPosition == SymbolAfter {
+		formatted = number.String() + l.SymbolSeparator + symbol
+	} else {
+		formatted = symbol + l.SymbolSeparator + number.String()
+	}
+
+	if !negative {
+		return formatted
+	}
+	if l.NegativeStyle == NegativeParentheses {
+		return "(" + formatted + ")"
+	}
+	return "-" + formatted
+}
+
+// group writes the digits in groups of three
+func (l Locale) group(digits string) string {
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteRune(l.GroupSeparator)
+		}
+		grouped.WriteRune(digit)
+	}
+	return grouped.String()
+}
+
+// Parse parses an amount written in the locale. The currency is taken from the symbol or code, and amounts without
+// either are in the DefaultCurrency.
+func (l Locale) Parse(s string) (Amount, error) {
+	return parse[Amount](l, s, DefaultCurrency, false)
+}
+
+// ParseIn parses an amount in the given currency written in the locale. The symbol or code is optional, but if it is
+// given it must be for the currency.
+func (l Locale) ParseIn(s string, code Code) (Amount, error) {
+	return parse[Amount](l, s, code, true)
+}
+
+// ParseWithDefault parses an amount in any currency written in the locale. Amounts without a symbol or code are in
+// the given currency.
+func (l Locale) ParseWithDefault(s string, code Code) (Amount, error) {
+	return parse[Amount](l, s, code, false)
+}
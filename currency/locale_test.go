@@ -0,0 +1,152 @@
+package currency
+
+import (
+	"testing"
+)
+
+func TestLocale_Parse(t *testing.T) {
+	type testCase struct {
+		name     string
+		locale   Locale
+		given    string
+		expected Amount
+	}
+	testCases := []testCase{
+		{"Grouped", EnUS, "1,234.56", MustNew(1234, 56, USD)},
+		{"Grouped Symbol", EnUS, "$1,234", MustNew(1234, 0, USD)},
+		{"Many Groups", EnUS, "$1,234,567.89", MustNew(1234567, 89, USD)},
+		{"Code Before", EnUS, "USD 12", MustNew(12, 0, USD)},
+		{"Code After", EnUS, "12.50 EUR", MustNew(12, 50, EUR)},
+		{"Code No Space", EnUS, "GBP12", MustNew(12, 0, GBP)},
+		{"Parentheses", EnUS, "(12.00)", MustNew(-12, 0, USD)},
+		{"Parentheses Symbol", EnUS, "($1,234.50)", MustNew(-1234, -50, USD)},
+		{"Surrounding Spaces", EnUS, "  $5 ", MustNew(5, 0, USD)},
+		{"Decimal Comma", DeDE, "1.234,56 €", MustNew(1234, 56, EUR)},
+		{"Decimal Comma Negative", DeDE, "-1.234,56 €", MustNew(-1234, -56, EUR)},
+		{"Decimal Comma Symbol Before", DeDE, "€12,5", MustNew(12, 50, EUR)},
+		{"Space Groups", FrFR, "1 234 567,89 €", MustNew(1234567, 89, EUR)},
+		{"Narrow Space Groups", FrFR, "1\u202f234,56\u00a0€", MustNew(1234, 56, EUR)},
+		{"Apostrophe Groups", DeCH, "CHF 1'234.50", MustNew(1234, 50, CHF)},
+		{"Yen", JaJP, "¥1,500", MustNew(1500, 0, JPY)},
+		{"Dinar", EnUS, "KWD 1,000.125", MustNew(1000, 125, KWD)},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			amount, err := test.locale.Parse(test.given)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if test.expected != amount {
+				t.Fatalf("Expected value to be %#v, but was: %#v", test.expected, amount)
+			}
+		})
+	}
+}
+
+func TestLocale_ParseErrors(t *testing.T) {
+	type testCase struct {
+		name     string
+		locale   Locale
+		given    string
+		position int
+	}
+	testCases := []testCase{
+		{"Empty", EnUS, "", 0},
+		{"Only Symbol", EnUS, "$", 1},
+		{"Short Group", EnUS, "1,23", 4},
+		{"Long First Group", EnUS, "1234,567", 4},
+		{"Short Middle Group", EnUS, "1,23,456", 4},
+		{"Group After Decimal", DeDE, "1,23.4", 4},
+		{"Decimal Comma In Wrong Locale", EnUS, "1.234,56", 4},
+		{"Two Decimal Separators", EnUS, "1.2.3", 3},
+		{"Letter", EnUS, "$12a", 3},
+		{"Unclosed Parenthesis", EnUS, "(12.00", 0},
+		{"Yen Decimals", JaJP, "¥1,500.5", 6},
+		{"No Fraction", EnUS, "$1.", 3},
+		{"Sign After Symbol", EnUS, "$-5", 1},
+		{"Too Large", EnUS, "99999999999999999999", 19},
+		{"Too Large For Int64", EnUS, "$92233720368547758.08", 1},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.locale.Parse(test.given)
+			formatErr, ok := err.(*InvalidCurrencyFormatError)
+			if !ok {
+				t.Fatalf("Expected InvalidCurrencyFormatError but got %#v", err)
+			}
+			if formatErr.Position() != test.position {
+				t.Errorf("Expected error at position %d, got %d (%s)", test.position, formatErr.Position(), formatErr.Reason())
+			}
+			if formatErr.Reason() == "" {
+				t.Errorf("Expected a reason for the error")
+			}
+		})
+	}
+}
+
+func TestLocale_ParseIn(t *testing.T) {
+	_, err := DeDE.ParseIn("12,00 $", EUR)
+	if _, ok := err.(*CurrencyMismatchError); !ok {
+		t.Errorf("Expected CurrencyMismatchError but got %#v", err)
+	}
+	amount, err := DeDE.ParseIn("12,00", EUR)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if expected := (MustNew(12, 0, EUR)); amount != expected {
+		t.Errorf("Expected value to be %#v, but was: %#v", expected, amount)
+	}
+}
+
+func TestLocale_Format(t *testing.T) {
+	accounting := EnUS
+	accounting.NegativeStyle = NegativeParentheses
+
+	type testCase struct {
+		name     string
+		locale   Locale
+		amount   Amount
+		expected string
+	}
+	testCases := []testCase{
+		{"Small", EnUS, MustNew(0, 5, USD), "$0.05"},
+		{"Grouped", EnUS, MustNew(1234567, 89, USD), "$1,234,567.89"},
+		{"Negative", EnUS, MustNew(-1234, -50, USD), "-$1,234.50"},
+		{"Parentheses", accounting, MustNew(-12, 0, USD), "($12.00)"},
+		{"Decimal Comma", DeDE, MustNew(1234, 56, EUR), "1.234,56 €"},
+		{"Space Groups", FrFR, MustNew(1234, 56, EUR), "1\u202f234,56\u00a0€"},
+		{"Apostrophe Groups", DeCH, MustNew(1234, 50, CHF), "CHF 1'234.50"},
+		{"Yen", JaJP, MustNew(1500, 0, JPY), "¥1,500"},
+		{"Dinar", EnUS, MustNew(1000, 5, KWD), "KD1,000.005"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			formatted := test.locale.Format(test.amount)
+			if formatted != test.expected {
+				t.Errorf("Expected value to be %s, but was: %s", test.expected, formatted)
+			}
+
+			parsed, err := test.locale.ParseWithDefault(formatted, test.amount.Code())
+			if err != nil {
+				t.Fatalf("failed to parse formatted amount: %s", err.Error())
+			}
+			if !parsed.Equals(test.amount) {
+				t.Errorf("Expected formatted amount to parse to %#v, got %#v", test.amount, parsed)
+			}
+		})
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	locale, err := LookupLocale("de-DE")
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if locale != DeDE {
+		t.Errorf("Expected %#v, got %#v", DeDE, locale)
+	}
+	_, err = LookupLocale("xx-XX")
+	if _, ok := err.(*UnknownLocaleError); !ok {
+		t.Errorf("Expected UnknownLocaleError but got %#v", err)
+	}
+}
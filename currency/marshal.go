@@ -0,0 +1,186 @@
+package currency
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+)
+
+// canonical is the locale of the text form of an amount. It has no grouping separator so that each amount is written
+// in exactly one way.
+var canonical = Locale{Name: "canonical", DecimalSeparator: '.'}
+
+// Text returns the lossless text form of the amount, which is its ISO 4217 code and its value with every decimal place
+// of the currency, such as "USD 1234.50", "JPY 1500" or "KWD -0.005". It is the form used for JSON and SQL.
+func (a Amount) Text() string {
+	negative, major, minor := a.split()
+	return text(a.info(), negative, padDigits(major, 1), padDigits(minor, a.info().Exponent))
+}
+
+// text writes the text form of an amount from the digits of its major and minor units
+func text(c Currency, negative bool, major, minor string) string {
+	var text strings.Builder
+	text.WriteString(string(c.Code))
+	text.WriteByte(' ')
+	if negative {
+		text.WriteByte('-')
+	}
+	text.WriteString(major)
+	if c.Exponent > 0 {
+		text.WriteByte('.')
+		text.WriteString(minor)
+	}
+	return text.String()
+}
+
+// ParseText parses the text form of an amount written by Text
+func ParseText(s string) (Amount, error) {
+	return parseText[Amount](s)
+}
+
+// parseText parses the text form of an amount. Amounts in currencies that are not known are written with two decimal
+// places, so they can be read back.
+func parseText[M Money[M]](s string) (M, error) {
+	var zero M
+	code, number, found := strings.Cut(s, " ")
+	p := &parser{locale: canonical, input: s, runes: []rune(s)}
+	p.end = len(p.runes)
+	if !found {
+		return zero, p.fail(p.end, "expected a currency code followed by a space")
+	}
+	if !isCode(code) {
+		return zero, p.fail(0, "expected a three letter currency code")
+	}
+
+	// the code is ASCII, so the number starts at the same index in runes as in bytes
+	p.pos = len(code) + 1
+	negative := strings.HasPrefix(number, "-")
+	if negative {
+		p.pos++
+	}
+	return amount[M](p, infoOf(Code(code)), negative)
+}
+
+// isCode reports whether s has the form of an ISO 4217 code
+func isCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.Text()), nil
+}
+
+func (a *Amount) UnmarshalText(text []byte) error {
+	amount, err := ParseText(string(text))
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Text())
+}
+
+// UnmarshalJSON reads an amount from a JSON string in the text form. A JSON null leaves the amount unchanged.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err
+	}
+	return a.UnmarshalText([]byte(text))
+}
+
+// Value stores the amount in a database as its text form
+func (a Amount) Value() (driver.Value, error) {
+	return a.Text(), nil
+}
+
+// Scan reads an amount stored in its text form
+func (a *Amount) Scan(src any) error {
+	switch value := src.(type) {
+	case string:
+		return a.UnmarshalText([]byte(value))
+	case []byte:
+		return a.UnmarshalText(value)
+	}
+	return &UnsupportedScanError{value: src}
+}
+
+// ParseBigText parses the text form of an amount into a BigAmount. It reads the text form of an Amount, and amounts
+// that are too large for one.
+func ParseBigText(s string) (BigAmount, error) {
+	return parseText[BigAmount](s)
+}
+
+func (b BigAmount) MarshalText() ([]byte, error) {
+	return []byte(b.Text()), nil
+}
+
+func (b *BigAmount) UnmarshalText(text []byte) error {
+	amount, err := ParseBigText(string(text))
+	if err != nil {
+		return err
+	}
+	*b = amount
+	return nil
+}
+
+func (b BigAmount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Text())
+}
+
+// UnmarshalJSON reads an amount from a JSON string in the text form. A JSON null leaves the amount unchanged.
+func (b *BigAmount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err
+	}
+	return b.UnmarshalText([]byte(text))
+}
+
+// Value stores the amount in a database as its text form
+func (b BigAmount) Value() (driver.Value, error) {
+	return b.Text(), nil
+}
+
+// Scan reads an amount stored in its text form
+func (b *BigAmount) Scan(src any) error {
+	switch value := src.(type) {
+	case string:
+		return b.UnmarshalText([]byte(value))
+	case []byte:
+		return b.UnmarshalText(value)
+	}
+	return &UnsupportedScanError{value: src}
+}
+
+func (r Rate) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *Rate) UnmarshalText(text []byte) error {
+	rate, err := ParseRate(string(text))
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
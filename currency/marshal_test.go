@@ -0,0 +1,166 @@
+package currency
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestAmount_Text(t *testing.T) {
+	type testCase struct {
+		name     string
+		a        Amount
+		expected string
+	}
+	testCases := []testCase{
+		{"Zero Value", Amount{}, "USD 0.00"},
+		{"Dollars", MustNew(1234, 50, USD), "USD 1234.50"},
+		{"Negative", MustNew(0, -5, USD), "USD -0.05"},
+		{"Yen", MustNew(1500, 0, JPY), "JPY 1500"},
+		{"Dinar", MustNew(-1, -5, KWD), "KWD -1.005"},
+		{"Unknown", FromMinorUnits(105, "XYZ"), "XYZ 1.05"},
+		{"Largest", FromMinorUnits(math.MaxInt64, USD), "USD 92233720368547758.07"},
+		{"Smallest", FromMinorUnits(math.MinInt64, EUR), "EUR -92233720368547758.08"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if text := test.a.Text(); text != test.expected {
+				t.Fatalf("Expected %s, got %s", test.expected, text)
+			}
+
+			parsed, err := ParseText(test.expected)
+			if err != nil {
+				t.Fatalf("operation failed with: %s", err.Error())
+			}
+			if parsed != test.a {
+				t.Fatalf("Expected %#v, got %#v", test.a, parsed)
+			}
+		})
+	}
+}
+
+func TestParseText_Invalid(t *testing.T) {
+	type testCase struct {
+		name     string
+		given    string
+		position int
+	}
+	testCases := []testCase{
+		{"No Code", "12.00", 5},
+		{"Lower Case Code", "usd 12.00", 0},
+		{"Symbol", "$ 12.00", 0},
+		{"Grouped", "USD 1,234.00", 5},
+		{"Too Many Decimals", "USD 1.005", 8},
+		{"Yen Decimals", "JPY 1.5", 5},
+		{"Empty Number", "USD ", 4},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseText(test.given)
+			formatErr, ok := err.(*InvalidCurrencyFormatError)
+			if !ok {
+				t.Fatalf("Expected InvalidCurrencyFormatError but got %#v", err)
+			}
+			if formatErr.Position() != test.position {
+				t.Errorf("Expected error at position %d, got %d (%s)", test.position, formatErr.Position(), formatErr.Reason())
+			}
+		})
+	}
+}
+
+func TestAmount_JSON(t *testing.T) {
+	type document struct {
+		Price   Amount  `json:"price"`
+		Reserve *Amount `json:"reserve"`
+	}
+	reserve := MustNew(10, 0, EUR)
+	original := document{Price: MustNew(1234, 56, EUR), Reserve: &reserve}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	expected := `{"price":"EUR 1234.56","reserve":"EUR 10.00"}`
+	if string(data) != expected {
+		t.Fatalf("Expected %s, got %s", expected, data)
+	}
+
+	var decoded document
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if decoded.Price != original.Price || *decoded.Reserve != *original.Reserve {
+		t.Fatalf("Expected %#v, got %#v", original, decoded)
+	}
+
+	decoded = document{}
+	err = json.Unmarshal([]byte(`{"price":null,"reserve":null}`), &decoded)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if decoded.Price != (Amount{}) || decoded.Reserve != nil {
+		t.Fatalf("Expected null to leave the amounts unset, got %#v", decoded)
+	}
+
+	err = json.Unmarshal([]byte(`{"price":1234.56}`), &decoded)
+	if err == nil {
+		t.Fatalf("Expected an error for an amount that is not a string")
+	}
+	err = json.Unmarshal([]byte(`{"price":"$1234.56"}`), &decoded)
+	if _, ok := err.(*InvalidCurrencyFormatError); !ok {
+		t.Fatalf("Expected InvalidCurrencyFormatError but got %#v", err)
+	}
+}
+
+func TestAmount_SQL(t *testing.T) {
+	original := MustNew(-12, -34, GBP)
+	value, err := original.Value()
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if value != driver.Value("GBP -12.34") {
+		t.Fatalf("Expected GBP -12.34, got %v", value)
+	}
+
+	for _, src := range []any{value, []byte("GBP -12.34")} {
+		var scanned Amount
+		err = scanned.Scan(src)
+		if err != nil {
+			t.Fatalf("operation failed with: %s", err.Error())
+		}
+		if scanned != original {
+			t.Fatalf("Expected %#v, got %#v", original, scanned)
+		}
+	}
+
+	var scanned Amount
+	for _, src := range []any{nil, int64(1234)} {
+		err = scanned.Scan(src)
+		if _, ok := err.(*UnsupportedScanError); !ok {
+			t.Fatalf("Expected UnsupportedScanError but got %#v", err)
+		}
+	}
+}
+
+func TestRate_JSON(t *testing.T) {
+	original := ExchangeRate{From: EUR, To: USD, Rate: NewRate(10842, 4)}
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	expected := `{"from":"EUR","to":"USD","rate":"1.0842"}`
+	if string(data) != expected {
+		t.Fatalf("Expected %s, got %s", expected, data)
+	}
+
+	var decoded ExchangeRate
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatalf("operation failed with: %s", err.Error())
+	}
+	if decoded != original {
+		t.Fatalf("Expected %#v, got %#v", original, decoded)
+	}
+}
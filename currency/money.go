@@ -0,0 +1,68 @@
+package currency
+
+import "math/big"
+
+// Money is implemented by the representations of an amount of money: Amount, which holds its minor units in an int64,
+// and BigAmount, which holds them in a big.Int for currencies with many decimal places or amounts that do not fit in
+// an int64. Code that is generic over Money, such as the bid manager, runs on either representation.
+//
+// M is the type implementing the interface, so that arithmetic stays within a single representation. The interface
+// has unexported methods, so it can only be implemented by the types in this package.
+type Money[M any] interface {
+	Code() Code
+	Sign() int
+	IsZero() bool
+	String() string
+	Text() string
+	Add(amt M) (M, error)
+	Sub(amt M) (M, error)
+	Cmp(amt M) (int, error)
+	Equals(amt M) bool
+	Less(amt M) bool
+	Greater(amt M) bool
+
+	// bigUnits returns a copy of the minor units of the amount
+	bigUnits() *big.Int
+	// fromBig creates an amount of the same representation, returning an OverflowError if the representation cannot
+	// hold the units
+	fromBig(units *big.Int, code Code) (M, error)
+	// maxSize is the largest number of minor units that is read when parsing, or nil if there is no limit
+	maxSize() *big.Int
+}
+
+// MinorUnitOf returns the smallest amount that can be represented in the currency, as MinorUnit does for an Amount
+func MinorUnitOf[M Money[M]](code Code) M {
+	var zero M
+	// one minor unit fits in every representation
+	unit, _ := zero.fromBig(big.NewInt(1), code)
+	return unit
+}
+
+// ParseMoneyIn parses an amount in the given currency written in the locale, as Locale.ParseIn does for an Amount
+func ParseMoneyIn[M Money[M]](l Locale, s string, code Code) (M, error) {
+	return parse[M](l, s, code, true)
+}
+
+// ParseMoneyWithDefault parses an amount in any currency written in the locale, as Locale.ParseWithDefault does for an
+// Amount
+func ParseMoneyWithDefault[M Money[M]](l Locale, s string, code Code) (M, error) {
+	return parse[M](l, s, code, false)
+}
+
+// ConvertMoney converts an amount with the exchange rate, as ExchangeRate.Convert does for an Amount
+func ConvertMoney[M Money[M]](rate ExchangeRate, amount M, mode RoundingMode) (M, error) {
+	return convert(rate, amount, mode)
+}
+
+// AmountOf returns the amount in the int64 representation, returning an OverflowError if it does not fit
+func AmountOf[M Money[M]](amount M) (Amount, error) {
+	return Amount{}.fromBig(amount.bigUnits(), amount.Code())
+}
+
+// PercentOf returns the given percentage of an amount, as Amount.Percent does for an Amount
+func PercentOf[M Money[M]](amount M, percent Rate, mode RoundingMode) (M, error) {
+	numerator, denominator := percent.fraction()
+	numerator.Mul(numerator, amount.bigUnits())
+	denominator.Mul(denominator, big.NewInt(100))
+	return amount.fromBig(mode.divide(numerator, denominator), amount.Code())
+}
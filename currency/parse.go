@@ -0,0 +1,246 @@
+package currency
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"unicode"
+)
+
+// marker is a symbol or ISO code that identifies the currency of a written amount
+type marker struct {
+	text     []rune
+	currency Currency
+}
+
+// markers is ordered from the longest marker to the shortest, so that "CA$" is matched before "$"
+var markers = sortedMarkers()
+
+func sortedMarkers() []marker {
+	sorted := []marker{}
+	for _, c := range currencies {
+		sorted = append(sorted, marker{text: []rune(c.Symbol), currency: c})
+		if c.Symbol != string(c.Code) {
+			sorted = append(sorted, marker{text: []rune(string(c.Code)), currency: c})
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i].text) != len(sorted[j].text) {
+			return len(sorted[i].text) > len(sorted[j].text)
+		}
+		return string(sorted[i].text) < string(sorted[j].text)
+	})
+	return sorted
+}
+
+// parser reads an amount from the runes between pos and end. Positions in errors are the index of the character in
+// the input.
+type parser struct {
+	locale Locale
+	input  string
+	runes  []rune
+	pos    int
+	end    int
+}
+
+// parse reads an amount written in the locale. When strict is true, a symbol or code must be for the given currency,
+// otherwise the given currency is only used for amounts without one.
+func parse[M Money[M]](l Locale, s string, code Code, strict bool) (M, error) {
+	var zero M
+	c, err := Lookup(code)
+	if err != nil {
+		return zero, err
+	}
+
+	p := &parser{locale: l, input: s, runes: []rune(s)}
+	p.end = len(p.runes)
+	p.trimSpace()
+	if p.pos == p.end {
+		return zero, p.fail(p.pos, "no amount was given")
+	}
+
+	negative := false
+	if p.runes[p.pos] == '(' {
+		if p.runes[p.end-1] != ')' {
+			return zero, p.fail(p.pos, "the opening parenthesis is not closed")
+		}
+		negative = true
+		p.pos++
+		p.end--
+		p.trimSpace()
+	} else if p.runes[p.pos] == '-' {
+		negative = true
+		p.pos++
+	}
+
+	m, found := p.prefix()
+	if !found {
+		m, found = p.suffix()
+	}
+	if found {
+		if strict && m.currency.Code != c.Code {
+			return zero, &CurrencyMismatchError{expected: c.Code, actual: m.currency.Code}
+		}
+		c = m.currency
+	}
+	return amount[M](p, c, negative)
+}
+
+// amount reads the number of an amount in the currency and applies the sign to it. An amount that does not fit in
+// the representation is reported at the start of the number.
+func amount[M Money[M]](p *parser, c Currency, negative bool) (M, error) {
+	var zero M
+	start := p.pos
+	units, err := p.number(c, zero.maxSize())
+	if err != nil {
+		return zero, err
+	}
+	if negative {
+		units.Neg(units)
+	}
+	result, err := zero.fromBig(units, c.Code)
+	if err != nil {
+		return zero, p.fail(start, "the amount is too large")
+	}
+	return result, nil
+}
+
+// trimSpace moves pos and end past any spaces at the start and end of the remaining input
+func (p *parser) trimSpace() {
+	for p.pos < p.end && unicode.IsSpace(p.runes[p.pos]) {
+		p.pos++
+	}
+	for p.end > p.pos && unicode.IsSpace(p.runes[p.end-1]) {
+		p.end--
+	}
+}
+
+// prefix reads a currency marker before the number
+func (p *parser) prefix() (marker, bool) {
+	for _, m := range markers {
+		next := p.pos + len(m.text)
+		if next > p.end || string(p.runes[p.pos:next]) != string(m.text) {
+			continue
+		}
+		// a code must not be the start of a longer word
+		if next < p.end && unicode.IsLetter(p.runes[next]) {
+			continue
+		}
+		p.pos = next
+		p.trimSpace()
+		return m, true
+	}
+	return marker{}, false
+}
+
+// suffix reads a currency marker after the number
+func (p *parser) suffix() (marker, bool) {
+	for _, m := range markers {
+		start := p.end - len(m.text)
+		if start < p.pos || string(p.runes[start:p.end]) != string(m.text) {
+			continue
+		}
+		if start > p.pos && unicode.IsLetter(p.runes[start-1]) {
+			continue
+		}
+		p.end = start
+		p.trimSpace()
+		return m, true
+	}
+	return marker{}, false
+}
+
+// isGroupSeparator accepts any space as a grouping separator in locales that group with a space, as the kind of space
+// is rarely visible to the person typing
+func (p *parser) isGroupSeparator(r rune) bool {
+	if unicode.IsSpace(p.locale.GroupSeparator) {
+		return unicode.IsSpace(r)
+	}
+	return r == p.locale.GroupSeparator
+}
+
+// number reads the digits of the amount, returning its size in minor units of the currency. A size larger than max is
+// reported at the digit that exceeds it, unless max is nil.
+func (p *parser) number(c Currency, max *big.Int) (*big.Int, error) {
+	if p.pos == p.end {
+		return nil, p.fail(p.pos, "expected a number")
+	}
+
+	units := new(big.Int)
+	ten := big.NewInt(10)
+	digits, groupDigits, fractionDigits := 0, 0, 0
+	grouped, fraction := false, false
+	for i := p.pos; i < p.end; i++ {
+		r := p.runes[i]
+		switch {
+		case r >= '0' && r <= '9':
+			if fraction {
+				if fractionDigits == c.Exponent {
+					return nil, p.fail(i, fmt.Sprintf("%s has only %d decimal places", c.Code, c.Exponent))
+				}
+				fractionDigits++
+			} else {
+				groupDigits++
+			}
+			digits++
+			units.Mul(units, ten).Add(units, big.NewInt(int64(r-'0')))
+			if max != nil && units.Cmp(max) > 0 {
+				return nil, p.fail(i, "the amount is too large")
+			}
+		case p.isGroupSeparator(r):
+			if fraction {
+				return nil, p.fail(i, "grouping separator after the decimal separator")
+			}
+			if groupDigits == 0 {
+				return nil, p.fail(i, "expected a digit before the grouping separator")
+			}
+			if grouped && groupDigits != 3 {
+				return nil, p.fail(i, "groups of digits must have three digits")
+			}
+			if !grouped && groupDigits > 3 {
+				return nil, p.fail(i, "the first group of digits must have at most three digits")
+			}
+			grouped = true
+			groupDigits = 0
+		case r == p.locale.DecimalSeparator:
+			if fraction {
+				return nil, p.fail(i, "more than one decimal separator")
+			}
+			if digits == 0 {
+				return nil, p.fail(i, "expected a digit before the decimal separator")
+			}
+			if grouped && groupDigits != 3 {
+				return nil, p.fail(i, "groups of digits must have three digits")
+			}
+			if c.Exponent == 0 {
+				return nil, p.fail(i, fmt.Sprintf("%s does not have decimal places", c.Code))
+			}
+			fraction = true
+		default:
+			if digits == 0 {
+				return nil, p.fail(i, fmt.Sprintf("expected a digit, found %q", r))
+			}
+			return nil, p.fail(i, fmt.Sprintf("unexpected character %q", r))
+		}
+	}
+
+	if fraction && fractionDigits == 0 {
+		return nil, p.fail(p.end, "expected a digit after the decimal separator")
+	}
+	if !fraction && grouped && groupDigits != 3 {
+		return nil, p.fail(p.end, "groups of digits must have three digits")
+	}
+
+	// pad the fraction to the number of minor units of the currency
+	for ; fractionDigits < c.Exponent; fractionDigits++ {
+		units.Mul(units, ten)
+		if max != nil && units.Cmp(max) > 0 {
+			return nil, p.fail(p.pos, "the amount is too large")
+		}
+	}
+	return units, nil
+}
+
+func (p *parser) fail(position int, reason string) error {
+	return &InvalidCurrencyFormatError{amount: p.input, position: position, reason: reason}
+}
@@ -0,0 +1,95 @@
+package currency
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Rate is an exact decimal number, such as an exchange rate, so that amounts are never multiplied by a float. The
+// value of the rate is value * 10^-exponent.
+type Rate struct {
+	value    int64
+	exponent int
+}
+
+// NewRate creates the rate value * 10^-exponent, so NewRate(10842, 4) is 1.0842
+func NewRate(value int64, exponent int) Rate {
+	return Rate{value: value, exponent: exponent}.normalize()
+}
+
+// ParseRate parses a decimal number such as "1.0842"
+func ParseRate(s string) (Rate, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) > 2 || parts[0] == "" {
+		return Rate{}, &InvalidRateError{rate: s}
+	}
+	digits := parts[0]
+	exponent := 0
+	if len(parts) == 2 {
+		if parts[1] == "" || strings.ContainsAny(parts[1], "+-") {
+			return Rate{}, &InvalidRateError{rate: s}
+		}
+		digits += parts[1]
+		exponent = len(parts[1])
+	}
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Rate{}, &InvalidRateError{rate: s}
+	}
+	return NewRate(value, exponent), nil
+}
+
+// normalize removes trailing zeros, so that equal rates have the same representation
+func (r Rate) normalize() Rate {
+	for r.exponent > 0 && r.value%10 == 0 {
+		r.value /= 10
+		r.exponent--
+	}
+	return r
+}
+
+// IsPositive reports whether the rate is greater than zero
+func (r Rate) IsPositive() bool {
+	return r.value > 0
+}
+
+// Sign returns -1, 0 or 1 for a negative, zero or positive rate
+func (r Rate) Sign() int {
+	switch {
+	case r.value < 0:
+		return -1
+	case r.value > 0:
+		return 1
+	}
+	return 0
+}
+
+func (r Rate) String() string {
+	if r.exponent <= 0 {
+		return new(big.Int).Mul(big.NewInt(r.value), pow10(-r.exponent)).String()
+	}
+	sign := ""
+	value := r.value
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	digits := fmt.Sprintf("%0*d", r.exponent+1, value)
+	split := len(digits) - r.exponent
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+// fraction returns the rate as a numerator and denominator
+func (r Rate) fraction() (*big.Int, *big.Int) {
+	if r.exponent < 0 {
+		return new(big.Int).Mul(big.NewInt(r.value), pow10(-r.exponent)), big.NewInt(1)
+	}
+	return big.NewInt(r.value), pow10(r.exponent)
+}
+
+// pow10 returns 10^n
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
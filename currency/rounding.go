@@ -0,0 +1,55 @@
+package currency
+
+import "math/big"
+
+// RoundingMode decides how a result that falls between two minor units is rounded
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest minor unit, and halves to the nearest even minor unit
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to the nearest minor unit, and halves away from zero
+	RoundHalfUp
+	// RoundDown rounds towards zero
+	RoundDown
+	// RoundUp rounds away from zero
+	RoundUp
+)
+
+// divide returns numerator / denominator rounded to a whole number using the rounding mode
+func (m RoundingMode) divide(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	// the quotient is truncated, so moving away from zero is in the direction of the sign of the exact result
+	away := int64(numerator.Sign() * denominator.Sign())
+	switch m {
+	case RoundHalfEven, RoundHalfUp:
+		// compare the remainder with half of the denominator
+		twice := new(big.Int).Abs(remainder)
+		twice.Mul(twice, big.NewInt(2))
+		cmp := twice.Cmp(new(big.Int).Abs(denominator))
+		if cmp > 0 || (cmp == 0 && (m == RoundHalfUp || quotient.Bit(0) == 1)) {
+			quotient.Add(quotient, big.NewInt(away))
+		}
+	case RoundUp:
+		quotient.Add(quotient, big.NewInt(away))
+	}
+	return quotient
+}
+
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfEven:
+		return "half-even"
+	case RoundHalfUp:
+		return "half-up"
+	case RoundDown:
+		return "down"
+	case RoundUp:
+		return "up"
+	}
+	return "unknown"
+}
@@ -0,0 +1,94 @@
+package currency
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestRoundingMode_Divide(t *testing.T) {
+	// the values from the table of rounding modes in the java.math.RoundingMode documentation, in tenths
+	type testCase struct {
+		tenths   int64
+		halfEven int64
+		halfUp   int64
+		down     int64
+		up       int64
+	}
+	testCases := []testCase{
+		{55, 6, 6, 5, 6},
+		{25, 2, 3, 2, 3},
+		{16, 2, 2, 1, 2},
+		{11, 1, 1, 1, 2},
+		{10, 1, 1, 1, 1},
+		{5, 0, 1, 0, 1},
+		{0, 0, 0, 0, 0},
+		{-5, 0, -1, 0, -1},
+		{-10, -1, -1, -1, -1},
+		{-11, -1, -1, -1, -2},
+		{-16, -2, -2, -1, -2},
+		{-25, -2, -3, -2, -3},
+		{-55, -6, -6, -5, -6},
+	}
+	for _, test := range testCases {
+		expected := map[RoundingMode]int64{
+			RoundHalfEven: test.halfEven,
+			RoundHalfUp:   test.halfUp,
+			RoundDown:     test.down,
+			RoundUp:       test.up,
+		}
+		for mode, want := range expected {
+			// a negative denominator must round the same way as a negative numerator
+			for _, sign := range []int64{1, -1} {
+				got := mode.divide(big.NewInt(sign*test.tenths), big.NewInt(sign*10))
+				if got.Int64() != want {
+					t.Errorf("Expected %d/%d rounded %s to be %d, got %d", sign*test.tenths, sign*10, mode, want, got.Int64())
+				}
+			}
+		}
+	}
+}
+
+func TestRoundingMode_DivideProperties(t *testing.T) {
+	modes := []RoundingMode{RoundHalfEven, RoundHalfUp, RoundDown, RoundUp}
+	for numerator := int64(-60); numerator <= 60; numerator++ {
+		for denominator := int64(-12); denominator <= 12; denominator++ {
+			if denominator == 0 {
+				continue
+			}
+			exact := big.NewRat(numerator, denominator)
+			for _, mode := range modes {
+				rounded := mode.divide(big.NewInt(numerator), big.NewInt(denominator))
+				// the distance from the exact result, which must be less than one
+				distance := new(big.Rat).Sub(new(big.Rat).SetInt(rounded), exact)
+				size := new(big.Rat).Abs(distance)
+				if size.Cmp(big.NewRat(1, 1)) >= 0 {
+					t.Fatalf("%d/%d rounded %s to %s, which is not within one", numerator, denominator, mode, rounded)
+				}
+
+				towardsZero := distance.Sign() == 0 || distance.Sign() != exact.Sign()
+				switch mode {
+				case RoundDown:
+					if !towardsZero {
+						t.Fatalf("%d/%d rounded down to %s, away from zero", numerator, denominator, rounded)
+					}
+				case RoundUp:
+					if distance.Sign() != 0 && towardsZero {
+						t.Fatalf("%d/%d rounded up to %s, towards zero", numerator, denominator, rounded)
+					}
+				default:
+					if size.Cmp(big.NewRat(1, 2)) > 0 {
+						t.Fatalf("%d/%d rounded %s to %s, which is not the nearest", numerator, denominator, mode, rounded)
+					}
+					if size.Cmp(big.NewRat(1, 2)) == 0 {
+						if mode == RoundHalfEven && rounded.Bit(0) != 0 {
+							t.Fatalf("%d/%d rounded half even to %s, which is odd", numerator, denominator, rounded)
+						}
+						if mode == RoundHalfUp && towardsZero {
+							t.Fatalf("%d/%d rounded half up to %s, towards zero", numerator, denominator, rounded)
+						}
+					}
+				}
+			}
+		}
+	}
+}
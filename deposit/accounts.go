@@ -0,0 +1,41 @@
+package deposit
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/ledger"
+)
+
+// HeldAccount is the account of the house that holds the money bidders have posted as deposits
+const HeldAccount ledger.Account = "house:deposits"
+
+// CapturedAccount collects the deposits of winners that were captured as payment, until they are credited against the
+// winner's invoice
+const CapturedAccount ledger.Account = "house:captured_deposits"
+
+// ForfeitedAccount collects the captured deposits of winners who defaulted, which the house keeps
+const ForfeitedAccount ledger.Account = "house:forfeited_deposits"
+
+// DepositorAccount is what the house owes a bidder for the deposits it holds for them
+func DepositorAccount(bidder auction.Bidder) ledger.Account {
+	return ledger.Account("depositor:" + string(bidder))
+}
+
+// placeEntries records that the bidder posted an amount, which the house holds and owes back to them
+func placeEntries(hold Hold, amount currency.Amount) []ledger.Entry {
+	return []ledger.Entry{ledger.Debit(HeldAccount, amount), ledger.Credit(DepositorAccount(hold.Bidder), amount)}
+}
+
+// settleEntries records that a hold was refunded to its bidder or captured as payment, after which the house no longer
+// owes it to the bidder
+func settleEntries(hold Hold) []ledger.Entry {
+	if hold.Status == StatusCaptured {
+		return []ledger.Entry{ledger.Debit(DepositorAccount(hold.Bidder), hold.Amount), ledger.Credit(CapturedAccount, hold.Amount)}
+	}
+	return []ledger.Entry{ledger.Debit(DepositorAccount(hold.Bidder), hold.Amount), ledger.Credit(HeldAccount, hold.Amount)}
+}
+
+// forfeitEntries records that a captured hold is kept by the house rather than credited against its winner's invoice
+func forfeitEntries(hold Hold) []ledger.Entry {
+	return []ledger.Entry{ledger.Debit(CapturedAccount, hold.Amount), ledger.Credit(ForfeitedAccount, hold.Amount)}
+}
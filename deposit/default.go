@@ -0,0 +1,195 @@
+package deposit
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/ledger"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultHolds keeps its holds in a Storer and posts the money they move to a ledger. The mutex serializes changes so
+// that a hold is never added to while it is being settled.
+type defaultHolds struct {
+	config Config
+	ledger ledger.Ledger
+	store  Storer
+	mtx    *sync.Mutex
+}
+
+func NewHolds(config Config, ledger ledger.Ledger, store Storer) Holds {
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &defaultHolds{
+		config: config,
+		ledger: ledger,
+		store:  store,
+		mtx:    &sync.Mutex{},
+	}
+}
+
+func (h *defaultHolds) Place(id auction.ID, bidder auction.Bidder, amount currency.Amount) (Hold, error) {
+	if bidder == "" {
+		return Hold{}, &InvalidHoldError{message: "a hold must have a bidder"}
+	}
+	if amount.Sign() <= 0 {
+		return Hold{}, &InvalidHoldError{message: "a hold must be for a positive amount"}
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	hold, found, err := h.hold(id, bidder)
+	if err != nil {
+		return Hold{}, err
+	}
+	if found && (hold.Status == StatusCaptured || hold.Status == StatusForfeited) {
+		return Hold{}, &HoldSettledError{bidder: bidder, auction: id, status: hold.Status}
+	}
+	if found && hold.Status == StatusHeld {
+		hold.Amount, err = hold.Amount.Add(amount)
+		if err != nil {
+			return Hold{}, errors.Join(&InvalidHoldError{message: "a hold must be in a single currency"}, err)
+		}
+	} else {
+		hold = Hold{Bidder: bidder, Auction: id, Amount: amount, Status: StatusHeld}
+	}
+	hold.PlacedAt = h.config.Now()
+	err = h.save(hold, fmt.Sprintf("deposit of %s in auction %s", bidder, id), placeEntries(hold, amount))
+	if err != nil {
+		return Hold{}, err
+	}
+	return hold, nil
+}
+
+func (h *defaultHolds) Release(id auction.ID, bidder auction.Bidder) (Hold, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return h.settle(id, bidder, StatusReleased)
+}
+
+func (h *defaultHolds) Capture(id auction.ID, bidder auction.Bidder) (Hold, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return h.settle(id, bidder, StatusCaptured)
+}
+
+func (h *defaultHolds) Forfeit(id auction.ID, bidder auction.Bidder) (Hold, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	hold, err := h.store.GetHold(id, bidder)
+	if err != nil {
+		return Hold{}, err
+	}
+	if hold.Status != StatusCaptured {
+		return Hold{}, &HoldNotCapturedError{bidder: bidder, auction: id, status: hold.Status}
+	}
+	hold.Status = StatusForfeited
+	hold.SettledAt = h.config.Now()
+	err = h.save(hold, fmt.Sprintf("forfeited deposit of %s in auction %s", bidder, id), forfeitEntries(hold))
+	if err != nil {
+		return Hold{}, err
+	}
+	return hold, nil
+}
+
+func (h *defaultHolds) Settle(id auction.ID, winner auction.Bidder) ([]Hold, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	holds, err := h.store.GetAuctionHolds(id)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to fetch holds"), err)
+	}
+	settled := []Hold{}
+	for _, hold := range holds {
+		if hold.Status != StatusHeld {
+			continue
+		}
+		status := StatusReleased
+		if hold.Bidder == winner {
+			status = StatusCaptured
+		}
+		hold, err = h.settle(id, hold.Bidder, status)
+		if err != nil {
+			return settled, err
+		}
+		settled = append(settled, hold)
+	}
+	return settled, nil
+}
+
+func (h *defaultHolds) Check(id auction.ID, bidder auction.Bidder, required currency.Amount) error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	hold, found, err := h.hold(id, bidder)
+	if err != nil {
+		return err
+	}
+	held := currency.FromMinorUnits(0, required.Code())
+	if found && hold.Status == StatusHeld {
+		held = hold.Amount
+	}
+	// a hold in another currency never covers the deposit
+	if held.Code() != required.Code() || held.Less(required) {
+		return &InsufficientHoldError{bidder: bidder, auction: id, required: required, held: held}
+	}
+	return nil
+}
+
+func (h *defaultHolds) GetHold(id auction.ID, bidder auction.Bidder) (Hold, error) {
+	return h.store.GetHold(id, bidder)
+}
+
+func (h *defaultHolds) GetHolds(bidder auction.Bidder) ([]Hold, error) {
+	return h.store.GetHolds(bidder)
+}
+
+// settle moves a hold that is still held to the released or captured status. It must be called with the lock held.
+func (h *defaultHolds) settle(id auction.ID, bidder auction.Bidder, status Status) (Hold, error) {
+	hold, err := h.store.GetHold(id, bidder)
+	if err != nil {
+		return Hold{}, err
+	}
+	if hold.Status != StatusHeld {
+		return Hold{}, &HoldSettledError{bidder: bidder, auction: id, status: hold.Status}
+	}
+	hold.Status = status
+	hold.SettledAt = h.config.Now()
+	err = h.save(hold, fmt.Sprintf("%s deposit of %s in auction %s", status, bidder, id), settleEntries(hold))
+	if err != nil {
+		return Hold{}, err
+	}
+	return hold, nil
+}
+
+// hold returns the hold of the bidder in the auction and whether they have one
+func (h *defaultHolds) hold(id auction.ID, bidder auction.Bidder) (Hold, bool, error) {
+	hold, err := h.store.GetHold(id, bidder)
+	var notFound *HoldNotFoundError
+	if errors.As(err, &notFound) {
+		return Hold{}, false, nil
+	} else if err != nil {
+		return Hold{}, false, errors.Join(errors.New("failed to fetch hold"), err)
+	}
+	return hold, true, nil
+}
+
+// save posts the entries of a change to the hold and then saves it. If the hold cannot be saved, the transaction is
+// reversed so that the ledger does not record a change that never happened.
+func (h *defaultHolds) save(hold Hold, description string, entries []ledger.Entry) error {
+	transaction, err := h.ledger.Post(description, entries...)
+	if err != nil {
+		return errors.Join(errors.New("failed to record hold"), err)
+	}
+	err = h.store.SaveHold(hold)
+	if err != nil {
+		_, reverseErr := h.ledger.Reverse(transaction.ID, description+" could not be saved")
+		return errors.Join(errors.New("failed to save hold"), err, reverseErr)
+	}
+	return nil
+}
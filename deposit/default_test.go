@@ -0,0 +1,274 @@
+package deposit
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/id_generator"
+	"auction/ledger"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func usd(major int64) currency.Amount {
+	return currency.MustNew(major, 0, currency.USD)
+}
+
+func newHolds() Holds {
+	holds, _ := newHoldsWithLedger()
+	return holds
+}
+
+func newHoldsWithLedger() (Holds, ledger.Ledger) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	book := ledger.NewLedger(ledger.Config{Now: func() time.Time { return now }}, id_generator.NewMemoryIDGenerator(), ledger.NewMemoryStorage())
+	return NewHolds(Config{Now: func() time.Time { return now }}, book, NewMemoryStorage()), book
+}
+
+func TestPlace(t *testing.T) {
+	holds := newHolds()
+	_, err := holds.Place("a-1", "Sasha", usd(100))
+	if err != nil {
+		t.Fatalf("Failed to place hold: %s", err.Error())
+	}
+	hold, err := holds.Place("a-1", "Sasha", usd(50))
+	if err != nil {
+		t.Fatalf("Failed to place hold: %s", err.Error())
+	}
+	if hold.Amount != usd(150) || hold.Status != StatusHeld {
+		t.Fatalf("Expected a hold of $150.00, got %#v", hold)
+	}
+
+	type testCase struct {
+		name   string
+		amount currency.Amount
+	}
+	testCases := []testCase{
+		{name: "Zero", amount: usd(0)},
+		{name: "Negative", amount: usd(-10)},
+		{name: "Other Currency", amount: currency.MustNew(10, 0, currency.EUR)},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := holds.Place("a-1", "Sasha", test.amount)
+			var invalid *InvalidHoldError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("Expected InvalidHoldError but got %#v", err)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	holds := newHolds()
+	for _, bidder := range []string{"Sasha", "John"} {
+		_, err := holds.Place("a-1", auction.Bidder(bidder), usd(100))
+		if err != nil {
+			t.Fatalf("Failed to place hold: %s", err.Error())
+		}
+	}
+	_, err := holds.Release("a-1", "John")
+	if err != nil {
+		t.Fatalf("Failed to release hold: %s", err.Error())
+	}
+
+	type testCase struct {
+		name     string
+		bidder   string
+		required currency.Amount
+		err      error
+	}
+	testCases := []testCase{
+		{name: "Sufficient", bidder: "Sasha", required: usd(100)},
+		{name: "Insufficient", bidder: "Sasha", required: usd(101), err: &InsufficientHoldError{}},
+		{name: "Other Currency", bidder: "Sasha", required: currency.MustNew(10, 0, currency.EUR), err: &InsufficientHoldError{}},
+		{name: "Released", bidder: "John", required: usd(100), err: &InsufficientHoldError{}},
+		{name: "No Hold", bidder: "Pat", required: usd(1), err: &InsufficientHoldError{}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := holds.Check("a-1", auction.Bidder(test.bidder), test.required)
+			if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+				t.Fatalf("Expected %T but got %#v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestSettle(t *testing.T) {
+	holds := newHolds()
+	for _, bidder := range []string{"Sasha", "John", "Pat"} {
+		_, err := holds.Place("a-1", auction.Bidder(bidder), usd(100))
+		if err != nil {
+			t.Fatalf("Failed to place hold: %s", err.Error())
+		}
+	}
+	_, err := holds.Place("a-2", "Sasha", usd(20))
+	if err != nil {
+		t.Fatalf("Failed to place hold: %s", err.Error())
+	}
+	_, err = holds.Release("a-1", "Pat")
+	if err != nil {
+		t.Fatalf("Failed to release hold: %s", err.Error())
+	}
+
+	settled, err := holds.Settle("a-1", "Sasha")
+	if err != nil {
+		t.Fatalf("Failed to settle holds: %s", err.Error())
+	}
+	statuses := map[auction.Bidder]Status{}
+	for _, hold := range settled {
+		statuses[hold.Bidder] = hold.Status
+	}
+	if expected := map[auction.Bidder]Status{"John": StatusReleased, "Sasha": StatusCaptured}; !reflect.DeepEqual(expected, statuses) {
+		t.Fatalf("Expected %#v, got %#v", expected, statuses)
+	}
+
+	// settling again changes nothing, and the holds in other auctions are untouched
+	settled, err = holds.Settle("a-1", "John")
+	if err != nil || len(settled) != 0 {
+		t.Fatalf("Expected no holds to be settled, got %#v and %#v", settled, err)
+	}
+	sasha, err := holds.GetHolds("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to get holds: %s", err.Error())
+	}
+	if len(sasha) != 2 || sasha[0].Status != StatusCaptured || sasha[1].Status != StatusHeld {
+		t.Fatalf("Expected a captured hold in a-1 and a held one in a-2, got %#v", sasha)
+	}
+
+	_, err = holds.Place("a-1", "Sasha", usd(10))
+	if _, ok := err.(*HoldSettledError); !ok {
+		t.Fatalf("Expected HoldSettledError but got %#v", err)
+	}
+	_, err = holds.Release("a-1", "John")
+	if _, ok := err.(*HoldSettledError); !ok {
+		t.Fatalf("Expected HoldSettledError but got %#v", err)
+	}
+	_, err = holds.Capture("a-1", "Riley")
+	if _, ok := err.(*HoldNotFoundError); !ok {
+		t.Fatalf("Expected HoldNotFoundError but got %#v", err)
+	}
+
+	// a bidder whose hold was released can post a new one
+	hold, err := holds.Place("a-1", "Pat", usd(30))
+	if err != nil {
+		t.Fatalf("Failed to place hold: %s", err.Error())
+	}
+	if hold.Amount != usd(30) || hold.Status != StatusHeld || !hold.SettledAt.IsZero() {
+		t.Fatalf("Expected a new hold of $30.00, got %#v", hold)
+	}
+}
+
+func TestLedger(t *testing.T) {
+	holds, book := newHoldsWithLedger()
+	for _, bidder := range []auction.Bidder{"Sasha", "John"} {
+		_, err := holds.Place("a-1", bidder, usd(100))
+		if err != nil {
+			t.Fatalf("Failed to place hold: %s", err.Error())
+		}
+	}
+	_, err := holds.Place("a-1", "Sasha", usd(50))
+	if err != nil {
+		t.Fatalf("Failed to place hold: %s", err.Error())
+	}
+	balances := map[ledger.Account]currency.Amount{
+		HeldAccount:               usd(250),
+		DepositorAccount("Sasha"): usd(-150),
+		DepositorAccount("John"):  usd(-100),
+		CapturedAccount:           usd(0),
+	}
+	checkBalances(t, book, balances)
+
+	// the house no longer owes either deposit once John's is refunded and Sasha's is kept as payment
+	_, err = holds.Settle("a-1", "Sasha")
+	if err != nil {
+		t.Fatalf("Failed to settle holds: %s", err.Error())
+	}
+	balances = map[ledger.Account]currency.Amount{
+		HeldAccount:               usd(150),
+		DepositorAccount("Sasha"): usd(0),
+		DepositorAccount("John"):  usd(0),
+		CapturedAccount:           usd(-150),
+	}
+	checkBalances(t, book, balances)
+}
+
+func TestForfeit(t *testing.T) {
+	holds, book := newHoldsWithLedger()
+	for _, bidder := range []auction.Bidder{"Sasha", "John"} {
+		_, err := holds.Place("a-1", bidder, usd(100))
+		if err != nil {
+			t.Fatalf("Failed to place hold: %s", err.Error())
+		}
+	}
+	_, err := holds.Forfeit("a-1", "Sasha")
+	if _, ok := err.(*HoldNotCapturedError); !ok {
+		t.Fatalf("Expected HoldNotCapturedError but got %#v", err)
+	}
+	_, err = holds.Settle("a-1", "Sasha")
+	if err != nil {
+		t.Fatalf("Failed to settle holds: %s", err.Error())
+	}
+
+	// Sasha defaulted, so the house keeps the deposit that was captured for the lot
+	hold, err := holds.Forfeit("a-1", "Sasha")
+	if err != nil {
+		t.Fatalf("Failed to forfeit hold: %s", err.Error())
+	}
+	if hold.Status != StatusForfeited {
+		t.Fatalf("Expected a forfeited hold, got %#v", hold)
+	}
+	checkBalances(t, book, map[ledger.Account]currency.Amount{
+		HeldAccount:               usd(100),
+		DepositorAccount("Sasha"): usd(0),
+		CapturedAccount:           usd(0),
+		ForfeitedAccount:          usd(-100),
+	})
+
+	for _, bidder := range []auction.Bidder{"Sasha", "John"} {
+		_, err = holds.Forfeit("a-1", bidder)
+		if _, ok := err.(*HoldNotCapturedError); !ok {
+			t.Fatalf("Expected HoldNotCapturedError but got %#v", err)
+		}
+	}
+	_, err = holds.Place("a-1", "Sasha", usd(10))
+	if _, ok := err.(*HoldSettledError); !ok {
+		t.Fatalf("Expected HoldSettledError but got %#v", err)
+	}
+}
+
+// checkBalances checks the USD balance of each account in the ledger
+func checkBalances(t *testing.T, book ledger.Ledger, expected map[ledger.Account]currency.Amount) {
+	t.Helper()
+	for account, amount := range expected {
+		balance, err := book.Balance(account, currency.USD)
+		if err != nil {
+			t.Fatalf("Failed to get balance: %s", err.Error())
+		}
+		if balance != amount {
+			t.Fatalf("Expected %s to have a balance of %s, got %s", account, amount, balance)
+		}
+	}
+}
+
+// failingStorage cannot save holds
+type failingStorage struct {
+	Storer
+}
+
+func (f failingStorage) SaveHold(hold Hold) error {
+	return errors.New("storage is unavailable")
+}
+
+func TestLedger_StorageFailure(t *testing.T) {
+	book := ledger.NewLedger(ledger.Config{}, id_generator.NewMemoryIDGenerator(), ledger.NewMemoryStorage())
+	holds := NewHolds(Config{}, book, failingStorage{Storer: NewMemoryStorage()})
+	_, err := holds.Place("a-1", "Sasha", usd(100))
+	if err == nil {
+		t.Fatalf("Expected an error when the hold cannot be saved")
+	}
+	// the deposit is reversed, so the ledger does not hold money for a hold that does not exist
+	checkBalances(t, book, map[ledger.Account]currency.Amount{HeldAccount: usd(0), DepositorAccount("Sasha"): usd(0)})
+}
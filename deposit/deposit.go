@@ -0,0 +1,58 @@
+package deposit
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"time"
+)
+
+type Status string
+
+const (
+	// StatusHeld is a deposit that is still held while the bidder takes part in the auction
+	StatusHeld Status = "held"
+	// StatusReleased is a deposit that was refunded to a bidder who did not win
+	StatusReleased Status = "released"
+	// StatusCaptured is a deposit that was converted into payment for the lot by its winner
+	StatusCaptured Status = "captured"
+	// StatusForfeited is a captured deposit that the house keeps because its winner defaulted
+	StatusForfeited Status = "forfeited"
+)
+
+// Hold is the refundable deposit a bidder has posted for an auction. A bidder has a single hold in each auction, which
+// grows when they post more.
+type Hold struct {
+	Bidder    auction.Bidder  `json:"bidder"`
+	Auction   auction.ID      `json:"auction"`
+	Amount    currency.Amount `json:"amount"`
+	Status    Status          `json:"status"`
+	PlacedAt  time.Time       `json:"placed_at"`
+	SettledAt time.Time       `json:"settled_at,omitempty"`
+}
+
+// Holds keeps the deposits that bidders have posted for auctions which require one. Every deposit that is placed,
+// released or captured is also posted to a ledger.Ledger.
+type Holds interface {
+	// Place holds an amount for the bidder in the auction, adding it to the hold they already have. A hold that was
+	// released is replaced by a new one, but a hold that was captured or forfeited cannot be added to.
+	Place(id auction.ID, bidder auction.Bidder, amount currency.Amount) (Hold, error)
+	// Release refunds the hold of the bidder in the auction
+	Release(id auction.ID, bidder auction.Bidder) (Hold, error)
+	// Capture converts the hold of the bidder in the auction into payment
+	Capture(id auction.ID, bidder auction.Bidder) (Hold, error)
+	// Forfeit keeps the captured hold of a winner who defaulted instead of crediting it against their invoice
+	Forfeit(id auction.ID, bidder auction.Bidder) (Hold, error)
+	// Settle captures the hold of the winner of the auction and releases every other hold of the auction. Holds that
+	// have already been settled are left as they are, and the holds that were changed are returned ordered by bidder.
+	Settle(id auction.ID, winner auction.Bidder) ([]Hold, error)
+	// Check returns an InsufficientHoldError unless the bidder holds at least the required amount in the auction
+	Check(id auction.ID, bidder auction.Bidder, required currency.Amount) error
+	GetHold(id auction.ID, bidder auction.Bidder) (Hold, error)
+	// GetHolds returns every hold of the bidder, ordered by auction
+	GetHolds(bidder auction.Bidder) ([]Hold, error)
+}
+
+// Config configures the holds. Now is the clock used to timestamp holds, and is time.Now if it is not set.
+type Config struct {
+	Now func() time.Time
+}
@@ -0,0 +1,55 @@
+package deposit
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"fmt"
+)
+
+type InvalidHoldError struct {
+	message string
+}
+
+func (e *InvalidHoldError) Error() string {
+	return e.message
+}
+
+type HoldNotFoundError struct {
+	bidder  auction.Bidder
+	auction auction.ID
+}
+
+func (e *HoldNotFoundError) Error() string {
+	return fmt.Sprintf("bidder %s has no deposit held in auction %s", e.bidder, e.auction)
+}
+
+type HoldSettledError struct {
+	bidder  auction.Bidder
+	auction auction.ID
+	status  Status
+}
+
+func (e *HoldSettledError) Error() string {
+	return fmt.Sprintf("the deposit of bidder %s in auction %s has already been %s", e.bidder, e.auction, e.status)
+}
+
+type HoldNotCapturedError struct {
+	bidder  auction.Bidder
+	auction auction.ID
+	status  Status
+}
+
+func (e *HoldNotCapturedError) Error() string {
+	return fmt.Sprintf("the deposit of bidder %s in auction %s is %s, so it cannot be forfeited", e.bidder, e.auction, e.status)
+}
+
+type InsufficientHoldError struct {
+	bidder   auction.Bidder
+	auction  auction.ID
+	required currency.Amount
+	held     currency.Amount
+}
+
+func (e *InsufficientHoldError) Error() string {
+	return fmt.Sprintf("auction %s requires a deposit of %s, but bidder %s holds %s", e.auction, e.required, e.bidder, e.held)
+}
@@ -0,0 +1,67 @@
+package deposit
+
+import (
+	"auction/auction"
+	"sort"
+	"sync"
+)
+
+type memoryStorage struct {
+	holds map[auction.ID]map[auction.Bidder]Hold
+	mtx   *sync.Mutex
+}
+
+func NewMemoryStorage() Storer {
+	return &memoryStorage{
+		holds: map[auction.ID]map[auction.Bidder]Hold{},
+		mtx:   &sync.Mutex{},
+	}
+}
+
+func (m memoryStorage) SaveHold(hold Hold) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.holds[hold.Auction]; !ok {
+		m.holds[hold.Auction] = map[auction.Bidder]Hold{}
+	}
+	m.holds[hold.Auction][hold.Bidder] = hold
+	return nil
+}
+
+func (m memoryStorage) GetHold(id auction.ID, bidder auction.Bidder) (Hold, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	hold, ok := m.holds[id][bidder]
+	if !ok {
+		return Hold{}, &HoldNotFoundError{bidder: bidder, auction: id}
+	}
+	return hold, nil
+}
+
+func (m memoryStorage) GetHolds(bidder auction.Bidder) ([]Hold, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	holds := []Hold{}
+	for _, auctionHolds := range m.holds {
+		if hold, ok := auctionHolds[bidder]; ok {
+			holds = append(holds, hold)
+		}
+	}
+	sort.Slice(holds, func(i, j int) bool {
+		return holds[i].Auction < holds[j].Auction
+	})
+	return holds, nil
+}
+
+func (m memoryStorage) GetAuctionHolds(id auction.ID) ([]Hold, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	holds := []Hold{}
+	for _, hold := range m.holds[id] {
+		holds = append(holds, hold)
+	}
+	sort.Slice(holds, func(i, j int) bool {
+		return holds[i].Bidder < holds[j].Bidder
+	})
+	return holds, nil
+}
@@ -0,0 +1,17 @@
+package deposit
+
+import "testing"
+
+func WithMemoryStorage() func() Storer {
+	return func() Storer {
+		return NewMemoryStorage()
+	}
+}
+
+func TestMemoryStorage(t *testing.T) {
+	tests := storageTests{
+		storeFn: WithMemoryStorage(),
+		t:       t,
+	}
+	tests.Run()
+}
@@ -0,0 +1,15 @@
+package deposit
+
+import "auction/auction"
+
+// Storer stores the holds of bidders
+type Storer interface {
+	// SaveHold creates or replaces the hold of a bidder in an auction
+	SaveHold(hold Hold) error
+	// GetHold returns a HoldNotFoundError if the bidder has no hold in the auction
+	GetHold(id auction.ID, bidder auction.Bidder) (Hold, error)
+	// GetHolds returns the holds of a bidder ordered by auction
+	GetHolds(bidder auction.Bidder) ([]Hold, error)
+	// GetAuctionHolds returns the holds of an auction ordered by bidder
+	GetAuctionHolds(id auction.ID) ([]Hold, error)
+}
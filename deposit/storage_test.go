@@ -0,0 +1,99 @@
+package deposit
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type storageTests struct {
+	storeFn func() Storer
+	t       *testing.T
+}
+
+func (g *storageTests) Run() {
+	tests := map[string]func(t *testing.T, store Storer){
+		"Test Holds":          testHolds,
+		"Test Hold Not Found": testHoldNotFound,
+	}
+	for name, test := range tests {
+		g.t.Run(name, func(t *testing.T) {
+			test(t, g.storeFn())
+		})
+	}
+}
+
+func mockHold(id auction.ID, bidder auction.Bidder, major int64) Hold {
+	return Hold{
+		Bidder:   bidder,
+		Auction:  id,
+		Amount:   currency.MustNew(major, 0, currency.USD),
+		Status:   StatusHeld,
+		PlacedAt: time.Unix(100, 0),
+	}
+}
+
+func testHolds(t *testing.T, store Storer) {
+	holds := []Hold{mockHold("a-2", "Sasha", 100), mockHold("a-1", "Sasha", 50), mockHold("a-1", "John", 50)}
+	for _, hold := range holds {
+		err := store.SaveHold(hold)
+		if err != nil {
+			t.Fatalf("Failed to save hold: %s", err.Error())
+		}
+	}
+
+	released := holds[1]
+	released.Status = StatusReleased
+	released.SettledAt = time.Unix(200, 0)
+	err := store.SaveHold(released)
+	if err != nil {
+		t.Fatalf("Failed to update hold: %s", err.Error())
+	}
+
+	saved, err := store.GetHold("a-1", "Sasha")
+	if err != nil {
+		t.Fatalf("Failed to get hold: %s", err.Error())
+	}
+	if !reflect.DeepEqual(released, saved) {
+		t.Fatalf("Holds do not match. Expected:\n%#v\nGot:\n%#v", released, saved)
+	}
+
+	bidderHolds, err := store.GetHolds("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to get holds: %s", err.Error())
+	}
+	if expected := []Hold{released, holds[0]}; !reflect.DeepEqual(expected, bidderHolds) {
+		t.Fatalf("Holds do not match. Expected:\n%#v\nGot:\n%#v", expected, bidderHolds)
+	}
+
+	auctionHolds, err := store.GetAuctionHolds("a-1")
+	if err != nil {
+		t.Fatalf("Failed to get holds: %s", err.Error())
+	}
+	if expected := []Hold{holds[2], released}; !reflect.DeepEqual(expected, auctionHolds) {
+		t.Fatalf("Holds do not match. Expected:\n%#v\nGot:\n%#v", expected, auctionHolds)
+	}
+}
+
+func testHoldNotFound(t *testing.T, store Storer) {
+	_, err := store.GetHold("a-1", "Sasha")
+	if _, ok := err.(*HoldNotFoundError); !ok {
+		t.Fatalf("Expected HoldNotFoundError but got %#v", err)
+	}
+	holds, err := store.GetHolds("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to get holds: %s", err.Error())
+	}
+	if len(holds) != 0 {
+		t.Fatalf("Expected no holds, got %#v", holds)
+	}
+	holds, err = store.GetAuctionHolds("a-1")
+	if err != nil {
+		t.Fatalf("Failed to get holds: %s", err.Error())
+	}
+	if len(holds) != 0 {
+		t.Fatalf("Expected no holds, got %#v", holds)
+	}
+}
@@ -0,0 +1,36 @@
+package events
+
+// OverflowPolicy determines what a Broker does when a subscriber's buffer is full
+type OverflowPolicy int
+
+const (
+	// Block makes Publish wait until the subscriber has room or the subscription is closed. A slow subscriber will slow
+	// down the publisher, so it should only be used by subscribers that must not miss events.
+	Block OverflowPolicy = iota
+	// DropNewest discards the event being published
+	DropNewest
+	// DropOldest discards the oldest buffered event to make room for the new one
+	DropOldest
+)
+
+type Publisher interface {
+	// Publish delivers the event to every matching subscriber. Events are delivered to each subscriber in the order
+	// they were published.
+	Publish(event Event)
+}
+
+type Broker interface {
+	Publisher
+	// Subscribe registers a new subscriber with a buffer of bufferSize events. If kinds are provided, only events of
+	// those kinds are delivered.
+	Subscribe(bufferSize int, policy OverflowPolicy, kinds ...Kind) Subscription
+}
+
+type Subscription interface {
+	// Events returns the channel events are delivered on. It is closed when the subscription is closed.
+	Events() <-chan Event
+	// Dropped returns the number of events that were discarded because the buffer was full
+	Dropped() uint64
+	// Close unsubscribes from the broker. It is safe to call multiple times.
+	Close()
+}
@@ -0,0 +1,184 @@
+package events
+
+import (
+	"auction/auction"
+	"auction/id_generator"
+	"testing"
+	"time"
+)
+
+type brokerTests struct {
+	brokerFn func() Broker
+	t        *testing.T
+}
+
+func (g *brokerTests) Run() {
+	tests := map[string]func(t *testing.T, broker Broker){
+		"Test Publish Subscribe":    testPublishSubscribe,
+		"Test Multiple Subscribers": testMultipleSubscribers,
+		"Test Kind Filter":          testKindFilter,
+		"Test Drop Newest":          testDropNewest,
+		"Test Drop Oldest":          testDropOldest,
+		"Test Block":                testBlock,
+		"Test Close":                testClose,
+		"Test Close While Blocked":  testCloseWhileBlocked,
+	}
+	for name, test := range tests {
+		g.t.Run(name, func(t *testing.T) {
+			test(t, g.brokerFn())
+		})
+	}
+}
+
+func mockEvent(id id_generator.EventID) Event {
+	return BidPlaced{
+		Header: Header{ID: id},
+		Bid:    auction.Bid{Bidder: auction.Bidder("mockBidder"), ID: id},
+	}
+}
+
+func receive(t *testing.T, sub Subscription) Event {
+	select {
+	case event := <-sub.Events():
+		return event
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for event")
+	}
+	return nil
+}
+
+func testPublishSubscribe(t *testing.T, broker Broker) {
+	sub := broker.Subscribe(10, Block)
+	defer sub.Close()
+
+	for i := 1; i <= 5; i++ {
+		broker.Publish(mockEvent(id_generator.EventID(i)))
+	}
+	for i := 1; i <= 5; i++ {
+		event := receive(t, sub)
+		if event.EventID() != id_generator.EventID(i) {
+			t.Fatalf("Expected event %d, got %d", i, event.EventID())
+		}
+	}
+}
+
+func testMultipleSubscribers(t *testing.T, broker Broker) {
+	sub1 := broker.Subscribe(1, Block)
+	defer sub1.Close()
+	sub2 := broker.Subscribe(1, Block)
+	defer sub2.Close()
+
+	broker.Publish(mockEvent(1))
+	for _, sub := range []Subscription{sub1, sub2} {
+		if event := receive(t, sub); event.EventID() != 1 {
+			t.Fatalf("Expected event 1, got %d", event.EventID())
+		}
+	}
+}
+
+func testKindFilter(t *testing.T, broker Broker) {
+	sub := broker.Subscribe(10, Block, KindAuctionClosed)
+	defer sub.Close()
+
+	broker.Publish(mockEvent(1))
+	broker.Publish(AuctionClosed{Header: Header{ID: 2}})
+
+	event := receive(t, sub)
+	if event.Kind() != KindAuctionClosed {
+		t.Fatalf("Expected %s event, got %s", KindAuctionClosed, event.Kind())
+	}
+	if len(sub.Events()) != 0 {
+		t.Fatalf("Expected no more events, got %d", len(sub.Events()))
+	}
+}
+
+func testDropNewest(t *testing.T, broker Broker) {
+	sub := broker.Subscribe(2, DropNewest)
+	defer sub.Close()
+
+	for i := 1; i <= 5; i++ {
+		broker.Publish(mockEvent(id_generator.EventID(i)))
+	}
+	if sub.Dropped() != 3 {
+		t.Fatalf("Expected 3 dropped events, got %d", sub.Dropped())
+	}
+	for i := 1; i <= 2; i++ {
+		if event := receive(t, sub); event.EventID() != id_generator.EventID(i) {
+			t.Fatalf("Expected event %d, got %d", i, event.EventID())
+		}
+	}
+}
+
+func testDropOldest(t *testing.T, broker Broker) {
+	sub := broker.Subscribe(2, DropOldest)
+	defer sub.Close()
+
+	for i := 1; i <= 5; i++ {
+		broker.Publish(mockEvent(id_generator.EventID(i)))
+	}
+	if sub.Dropped() != 3 {
+		t.Fatalf("Expected 3 dropped events, got %d", sub.Dropped())
+	}
+	for i := 4; i <= 5; i++ {
+		if event := receive(t, sub); event.EventID() != id_generator.EventID(i) {
+			t.Fatalf("Expected event %d, got %d", i, event.EventID())
+		}
+	}
+}
+
+func testBlock(t *testing.T, broker Broker) {
+	sub := broker.Subscribe(1, Block)
+	defer sub.Close()
+
+	published := make(chan struct{})
+	go func() {
+		broker.Publish(mockEvent(1))
+		broker.Publish(mockEvent(2))
+		close(published)
+	}()
+
+	select {
+	case <-published:
+		t.Fatalf("Expected publish to block on a full subscriber")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	for i := 1; i <= 2; i++ {
+		if event := receive(t, sub); event.EventID() != id_generator.EventID(i) {
+			t.Fatalf("Expected event %d, got %d", i, event.EventID())
+		}
+	}
+	<-published
+	if sub.Dropped() != 0 {
+		t.Fatalf("Expected no dropped events, got %d", sub.Dropped())
+	}
+}
+
+func testClose(t *testing.T, broker Broker) {
+	sub := broker.Subscribe(1, Block)
+	sub.Close()
+	sub.Close()
+
+	broker.Publish(mockEvent(1))
+	if _, ok := <-sub.Events(); ok {
+		t.Fatalf("Expected events channel to be closed")
+	}
+}
+
+func testCloseWhileBlocked(t *testing.T, broker Broker) {
+	sub := broker.Subscribe(0, Block)
+
+	published := make(chan struct{})
+	go func() {
+		broker.Publish(mockEvent(1))
+		close(published)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	sub.Close()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected publish to be released when the subscription closed")
+	}
+}
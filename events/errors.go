@@ -0,0 +1,19 @@
+package events
+
+import "fmt"
+
+type CorruptEventError struct {
+	line int
+}
+
+func (e *CorruptEventError) Error() string {
+	return fmt.Sprintf("event on line %d could not be read", e.line)
+}
+
+type UnknownKindError struct {
+	kind Kind
+}
+
+func (e *UnknownKindError) Error() string {
+	return fmt.Sprintf("unknown event kind %q", e.kind)
+}
@@ -0,0 +1,259 @@
+package events
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/id_generator"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindBidPlaced        Kind = "BidPlaced"
+	KindBidRejected      Kind = "BidRejected"
+	KindBidAmended       Kind = "BidAmended"
+	KindBidRetracted     Kind = "BidRetracted"
+	KindLeaderChanged    Kind = "LeaderChanged"
+	KindPriceChanged     Kind = "PriceChanged"
+	KindBidderOutbid     Kind = "BidderOutbid"
+	KindBidderExhausted  Kind = "BidderExhausted"
+	KindAuctionClosed    Kind = "AuctionClosed"
+	KindWinnerDetermined Kind = "WinnerDetermined"
+
+	KindBidderDefaulted      Kind = "BidderDefaulted"
+	KindSecondChanceOffered  Kind = "SecondChanceOffered"
+	KindSecondChanceAccepted Kind = "SecondChanceAccepted"
+	KindSecondChanceDeclined Kind = "SecondChanceDeclined"
+	KindBoughtItNow          Kind = "BoughtItNow"
+)
+
+// Event is implemented by every event published by a BidManager. Subscribers can switch on Kind, or on the concrete
+// type to access the event specific fields.
+//
+// Events that carry amounts are generic over the representation of the amounts, and the names without the Of suffix are
+// the events of an auction in currency.Amount. A manager running on currency.BigAmount publishes events such as
+// BidPlacedOf[currency.BigAmount], which have the same Kind.
+type Event interface {
+	Kind() Kind
+	EventID() id_generator.EventID
+	AuctionID() auction.ID
+	OccurredAt() time.Time
+}
+
+// Header contains the fields shared by every event. IDs come from the same IDGenerator as bids, so events and bids
+// share a single ordering, which is only unique within an auction. Auction is the ID set on the manager with
+// bid_manager.WithAuctionID, and is empty if it was not set.
+type Header struct {
+	ID      id_generator.EventID
+	Auction auction.ID
+	Time    time.Time
+}
+
+func (h Header) EventID() id_generator.EventID {
+	return h.ID
+}
+
+func (h Header) AuctionID() auction.ID {
+	return h.Auction
+}
+
+func (h Header) OccurredAt() time.Time {
+	return h.Time
+}
+
+// BidPlaced is published after a bid has been saved. The header ID is the ID of the bid itself.
+type BidPlaced = BidPlacedOf[currency.Amount]
+
+type BidPlacedOf[M currency.Money[M]] struct {
+	Header
+	Bid auction.BidOf[M]
+}
+
+func (e BidPlacedOf[M]) Kind() Kind {
+	return KindBidPlaced
+}
+
+// The reasons a bid is rejected. A reason is published to every consumer, so it never carries the details of the
+// rejection, which can reveal a bidder's credit limit or max bid, or why a seller blocked them.
+const (
+	ReasonInvalidBid          = "invalid_bid"
+	ReasonDuplicateBid        = "duplicate_bid"
+	ReasonBidNotFound         = "bid_not_found"
+	ReasonAuctionClosed       = "auction_closed"
+	ReasonAccessDenied        = "access_denied"
+	ReasonUnverifiedBidder    = "unverified_bidder"
+	ReasonCreditLimit         = "credit_limit"
+	ReasonCurrencyMismatch    = "currency_mismatch"
+	ReasonInsufficientDeposit = "insufficient_deposit"
+	ReasonRejected            = "rejected"
+)
+
+// BidRejected is published when a bid or an amendment to a bid is not accepted. Reason is one of the reasons above.
+// Detail is the full error, which is only recorded in the audit log and is cleared before the event is published.
+type BidRejected struct {
+	Header
+	Bidder auction.Bidder
+	Reason string
+	Detail string
+}
+
+func (e BidRejected) Kind() Kind {
+	return KindBidRejected
+}
+
+// BidAmended is published after a bid's max bid or increment has been changed. Bid is the bid after the change.
+type BidAmended = BidAmendedOf[currency.Amount]
+
+type BidAmendedOf[M currency.Money[M]] struct {
+	Header
+	Bid auction.BidOf[M]
+}
+
+func (e BidAmendedOf[M]) Kind() Kind {
+	return KindBidAmended
+}
+
+// BidRetracted is published after a bid has been removed from the auction
+type BidRetracted struct {
+	Header
+	Bidder auction.Bidder
+}
+
+func (e BidRetracted) Kind() Kind {
+	return KindBidRetracted
+}
+
+// LeaderChanged is published when a change to the bids results in a different bidder leading the auction. Previous is
+// empty when the auction had no bids, and Leader is empty when every bid has been retracted.
+type LeaderChanged = LeaderChangedOf[currency.Amount]
+
+type LeaderChangedOf[M currency.Money[M]] struct {
+	Header
+	Previous auction.WinningBidOf[M]
+	Leader   auction.WinningBidOf[M]
+}
+
+func (e LeaderChangedOf[M]) Kind() Kind {
+	return KindLeaderChanged
+}
+
+// PriceChanged is published when a change to the bids moves the leader's price without changing who is leading
+type PriceChanged = PriceChangedOf[currency.Amount]
+
+type PriceChangedOf[M currency.Money[M]] struct {
+	Header
+	Leader auction.WinningBidOf[M]
+}
+
+func (e PriceChangedOf[M]) Kind() Kind {
+	return KindPriceChanged
+}
+
+// BidderOutbid is published to notify the previous leader that they are no longer winning
+type BidderOutbid = BidderOutbidOf[currency.Amount]
+
+type BidderOutbidOf[M currency.Money[M]] struct {
+	Header
+	Bidder auction.Bidder
+	Leader auction.WinningBidOf[M]
+}
+
+func (e BidderOutbidOf[M]) Kind() Kind {
+	return KindBidderOutbid
+}
+
+// BidderExhausted is published when a bidder can no longer increase their bid to beat the leader without exceeding
+// their max bid.
+type BidderExhausted = BidderExhaustedOf[currency.Amount]
+
+type BidderExhaustedOf[M currency.Money[M]] struct {
+	Header
+	Bidder auction.Bidder
+	Amount M
+	MaxBid M
+}
+
+func (e BidderExhaustedOf[M]) Kind() Kind {
+	return KindBidderExhausted
+}
+
+// AuctionClosed is published once the auction stops accepting bids
+type AuctionClosed struct {
+	Header
+}
+
+func (e AuctionClosed) Kind() Kind {
+	return KindAuctionClosed
+}
+
+// WinnerDetermined is published each time the winner is calculated
+type WinnerDetermined = WinnerDeterminedOf[currency.Amount]
+
+type WinnerDeterminedOf[M currency.Money[M]] struct {
+	Header
+	Winner auction.WinningBidOf[M]
+}
+
+func (e WinnerDeterminedOf[M]) Kind() Kind {
+	return KindWinnerDetermined
+}
+
+// BidderDefaulted is published when the winner of a closed auction does not pay for the lot
+type BidderDefaulted struct {
+	Header
+	Bidder auction.Bidder
+}
+
+func (e BidderDefaulted) Kind() Kind {
+	return KindBidderDefaulted
+}
+
+// SecondChanceOffered is published when the lot is offered to an underbidder after the winner defaulted
+type SecondChanceOffered = SecondChanceOfferedOf[currency.Amount]
+
+type SecondChanceOfferedOf[M currency.Money[M]] struct {
+	Header
+	Offer auction.SecondChanceOfferOf[M]
+}
+
+func (e SecondChanceOfferedOf[M]) Kind() Kind {
+	return KindSecondChanceOffered
+}
+
+// SecondChanceAccepted is published when an underbidder accepts a second-chance offer. Winner is the underbidder at
+// the price of the offer.
+type SecondChanceAccepted = SecondChanceAcceptedOf[currency.Amount]
+
+type SecondChanceAcceptedOf[M currency.Money[M]] struct {
+	Header
+	Winner auction.WinningBidOf[M]
+}
+
+func (e SecondChanceAcceptedOf[M]) Kind() Kind {
+	return KindSecondChanceAccepted
+}
+
+// SecondChanceDeclined is published when an underbidder declines a second-chance offer, or it is declined on their
+// behalf after it expired
+type SecondChanceDeclined struct {
+	Header
+	Bidder auction.Bidder
+}
+
+func (e SecondChanceDeclined) Kind() Kind {
+	return KindSecondChanceDeclined
+}
+
+// BoughtItNow is published when a bidder buys the lot at its buy-it-now price, which closes the auction. Winner is the
+// buyer at that price.
+type BoughtItNow = BoughtItNowOf[currency.Amount]
+
+type BoughtItNowOf[M currency.Money[M]] struct {
+	Header
+	Winner auction.WinningBidOf[M]
+}
+
+func (e BoughtItNowOf[M]) Kind() Kind {
+	return KindBoughtItNow
+}
@@ -0,0 +1,144 @@
+package events
+
+import (
+	"auction/currency"
+	"auction/id_generator"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+)
+
+// fileLog stores one JSON encoded record per line, holding the kind of the event and the event itself. The file is
+// only ever opened for appending, and each event is synced to disk before Append returns. The kind of each record
+// decides the type it is read back as, with the amounts of the generic events represented by M.
+type fileLog[M currency.Money[M]] struct {
+	path string
+	mtx  *sync.Mutex
+}
+
+// record is a line of a file log
+type record struct {
+	Kind  Kind            `json:"kind"`
+	Event json.RawMessage `json:"event"`
+}
+
+// NewFileLog creates a log kept in the file at path, which is created when the first event is appended. The events
+// are read back as the events of an auction in currency.Amount.
+func NewFileLog(path string) Log {
+	return NewFileLogOf[currency.Amount](path)
+}
+
+// NewFileLogOf creates a file log whose generic events are read back with their amounts represented by M. It must only
+// hold the events of auctions with the same representation.
+func NewFileLogOf[M currency.Money[M]](path string) Log {
+	return &fileLog[M]{
+		path: path,
+		mtx:  &sync.Mutex{},
+	}
+}
+
+func (f *fileLog[M]) Append(event Event) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(record{Kind: event.Kind(), Event: body})
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// After returns no events if the file has not been created yet
+func (f *fileLog[M]) After(id id_generator.EventID) ([]Event, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	file, err := os.Open(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Event{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	after := []Event{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		event, err := decode[M](scanner.Bytes())
+		if err != nil {
+			return nil, errors.Join(&CorruptEventError{line: line}, err)
+		}
+		if event.EventID() > id {
+			after = append(after, event)
+		}
+	}
+	return after, scanner.Err()
+}
+
+// decode reads a record, returning its event as the type of its kind
+func decode[M currency.Money[M]](line []byte) (Event, error) {
+	var r record
+	err := json.Unmarshal(line, &r)
+	if err != nil {
+		return nil, err
+	}
+	switch r.Kind {
+	case KindBidPlaced:
+		return decodeAs[BidPlacedOf[M]](r.Event)
+	case KindBidRejected:
+		return decodeAs[BidRejected](r.Event)
+	case KindBidAmended:
+		return decodeAs[BidAmendedOf[M]](r.Event)
+	case KindBidRetracted:
+		return decodeAs[BidRetracted](r.Event)
+	case KindLeaderChanged:
+		return decodeAs[LeaderChangedOf[M]](r.Event)
+	case KindPriceChanged:
+		return decodeAs[PriceChangedOf[M]](r.Event)
+	case KindBidderOutbid:
+		return decodeAs[BidderOutbidOf[M]](r.Event)
+	case KindBidderExhausted:
+		return decodeAs[BidderExhaustedOf[M]](r.Event)
+	case KindAuctionClosed:
+		return decodeAs[AuctionClosed](r.Event)
+	case KindWinnerDetermined:
+		return decodeAs[WinnerDeterminedOf[M]](r.Event)
+	case KindBidderDefaulted:
+		return decodeAs[BidderDefaulted](r.Event)
+	case KindSecondChanceOffered:
+		return decodeAs[SecondChanceOfferedOf[M]](r.Event)
+	case KindSecondChanceAccepted:
+		return decodeAs[SecondChanceAcceptedOf[M]](r.Event)
+	case KindSecondChanceDeclined:
+		return decodeAs[SecondChanceDeclined](r.Event)
+	case KindBoughtItNow:
+		return decodeAs[BoughtItNowOf[M]](r.Event)
+	}
+	return nil, &UnknownKindError{kind: r.Kind}
+}
+
+func decodeAs[E Event](body []byte) (Event, error) {
+	var event E
+	err := json.Unmarshal(body, &event)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,94 @@
+package events
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/id_generator"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func WithFileLog(t *testing.T) func() Log {
+	return func() Log {
+		return NewFileLog(filepath.Join(t.TempDir(), "events.log"))
+	}
+}
+
+func TestFileLog(t *testing.T) {
+	tests := logTests{
+		logFn: WithFileLog(t),
+		t:     t,
+	}
+	tests.Run()
+}
+
+func TestFileLogKinds(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	header := func(id int) Header {
+		return Header{ID: id_generator.EventID(id), Auction: "auction-1", Time: at}
+	}
+	usd := func(major int64) currency.Amount {
+		return currency.MustNew(major, 0, currency.USD)
+	}
+	rate := currency.ExchangeRate{From: currency.EUR, To: currency.USD, Rate: currency.NewRate(10842, 4)}
+	bid := auction.Bid{Bidder: "Sasha", StartingBid: usd(50), MaxBid: usd(80), Increment: usd(3), ID: 1, ExchangeRate: &rate}
+	leader := auction.WinningBid{Bidder: "Sasha", Amount: usd(50)}
+	history := []Event{
+		BidPlaced{Header: header(1), Bid: bid},
+		BidRejected{Header: header(2), Bidder: "Pat", Reason: ReasonInvalidBid},
+		BidAmended{Header: header(3), Bid: bid},
+		BidRetracted{Header: header(4), Bidder: "John"},
+		LeaderChanged{Header: header(5), Leader: leader},
+		PriceChanged{Header: header(6), Leader: leader},
+		BidderOutbid{Header: header(7), Bidder: "John", Leader: leader},
+		BidderExhausted{Header: header(8), Bidder: "John", Amount: usd(47), MaxBid: usd(47)},
+		AuctionClosed{Header: header(9)},
+		WinnerDetermined{Header: header(10), Winner: leader},
+		BidderDefaulted{Header: header(11), Bidder: "Sasha"},
+		SecondChanceOffered{Header: header(12), Offer: auction.SecondChanceOffer{Bidder: "John", Amount: usd(47), ExpiresAt: at}},
+		SecondChanceAccepted{Header: header(13), Winner: leader},
+		SecondChanceDeclined{Header: header(14), Bidder: "John"},
+		BoughtItNow{Header: header(15), Winner: leader},
+	}
+	log := NewFileLog(filepath.Join(t.TempDir(), "events.log"))
+	for _, event := range history {
+		err := log.Append(event)
+		if err != nil {
+			t.Fatalf("Failed to append event: %s", err.Error())
+		}
+	}
+
+	// every event is read back as the type it was appended as
+	after, err := log.After(0)
+	if err != nil {
+		t.Fatalf("Failed to read log: %s", err.Error())
+	}
+	if !reflect.DeepEqual(history, after) {
+		t.Fatalf("Expected %#v, got %#v", history, after)
+	}
+}
+
+func TestFileLogCorruptEvent(t *testing.T) {
+	lines := []string{
+		"not json\n",
+		"{\"kind\":\"Unknown\",\"event\":{}}\n",
+		"{\"kind\":\"BidPlaced\",\"event\":{\"Bid\":{\"starting_bid\":\"not an amount\"}}}\n",
+	}
+	for _, line := range lines {
+		path := filepath.Join(t.TempDir(), "events.log")
+		err := os.WriteFile(path, []byte(line), 0o644)
+		if err != nil {
+			t.Fatalf("Failed to write file: %s", err.Error())
+		}
+
+		_, err = NewFileLog(path).After(0)
+		var corrupt *CorruptEventError
+		if !errors.As(err, &corrupt) {
+			t.Fatalf("Expected CorruptEventError but got %#v", err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package events
+
+import "auction/id_generator"
+
+// Log stores published events so that consumers that were disconnected can catch up on the events they missed
+type Log interface {
+	Append(event Event) error
+	// After returns the events with an ID greater than id, in the order they were appended
+	After(id id_generator.EventID) ([]Event, error)
+}
+
+// recordingBroker appends every event to the log before publishing it
+type recordingBroker struct {
+	Broker
+	log Log
+}
+
+// NewRecordingBroker wraps a broker so that every published event is also appended to the log. The event is appended
+// before it is delivered, so a consumer that subscribes and then reads the log will see every event in one or the
+// other.
+func NewRecordingBroker(broker Broker, log Log) Broker {
+	return &recordingBroker{
+		Broker: broker,
+		log:    log,
+	}
+}
+
+// Publish still delivers the event if it could not be appended, as live subscribers should not miss it because of a
+// failing log.
+func (b *recordingBroker) Publish(event Event) {
+	_ = b.log.Append(event)
+	b.Broker.Publish(event)
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"auction/id_generator"
+	"testing"
+)
+
+type logTests struct {
+	logFn func() Log
+	t     *testing.T
+}
+
+func (g *logTests) Run() {
+	tests := map[string]func(t *testing.T, log Log){
+		"Test Append After": testAppendAfter,
+		"Test Empty Log":    testEmptyLog,
+		"Test Recording":    testRecording,
+	}
+	for name, test := range tests {
+		g.t.Run(name, func(t *testing.T) {
+			test(t, g.logFn())
+		})
+	}
+}
+
+func testAppendAfter(t *testing.T, log Log) {
+	for i := 1; i <= 5; i++ {
+		err := log.Append(mockEvent(id_generator.EventID(i)))
+		if err != nil {
+			t.Fatalf("Failed to append event: %s", err.Error())
+		}
+	}
+
+	type testCase struct {
+		name     string
+		after    id_generator.EventID
+		expected []id_generator.EventID
+	}
+	testCases := []testCase{
+		{"All", 0, []id_generator.EventID{1, 2, 3, 4, 5}},
+		{"Some", 3, []id_generator.EventID{4, 5}},
+		{"None", 5, []id_generator.EventID{}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			after, err := log.After(test.after)
+			if err != nil {
+				t.Fatalf("Failed to read log: %s", err.Error())
+			}
+			if len(after) != len(test.expected) {
+				t.Fatalf("Expected %d events, got %d", len(test.expected), len(after))
+			}
+			for i, event := range after {
+				if event.EventID() != test.expected[i] {
+					t.Fatalf("Expected event %d, got %d", test.expected[i], event.EventID())
+				}
+			}
+		})
+	}
+}
+
+func testEmptyLog(t *testing.T, log Log) {
+	after, err := log.After(0)
+	if err != nil {
+		t.Fatalf("Failed to read log: %s", err.Error())
+	}
+	if len(after) != 0 {
+		t.Fatalf("Expected no events, got %d", len(after))
+	}
+}
+
+func testRecording(t *testing.T, log Log) {
+	broker := NewRecordingBroker(NewMemoryBroker(), log)
+	sub := broker.Subscribe(1, Block)
+	defer sub.Close()
+
+	broker.Publish(mockEvent(1))
+	if event := receive(t, sub); event.EventID() != 1 {
+		t.Fatalf("Expected event 1, got %d", event.EventID())
+	}
+	after, err := log.After(0)
+	if err != nil {
+		t.Fatalf("Failed to read log: %s", err.Error())
+	}
+	if len(after) != 1 || after[0].EventID() != 1 {
+		t.Fatalf("Expected event 1 to be recorded, got %#v", after)
+	}
+}
@@ -0,0 +1,161 @@
+package events
+
+import (
+	"auction/id_generator"
+	"sync"
+	"sync/atomic"
+)
+
+type memoryBroker struct {
+	subscribers map[*memorySubscription]struct{}
+	mtx         *sync.Mutex
+}
+
+func NewMemoryBroker() Broker {
+	return &memoryBroker{
+		subscribers: map[*memorySubscription]struct{}{},
+		mtx:         &sync.Mutex{},
+	}
+}
+
+// Publish holds the lock for the entire delivery so that events published concurrently are still received in the
+// same order by every subscriber.
+func (b *memoryBroker) Publish(event Event) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	for sub := range b.subscribers {
+		if sub.accepts(event.Kind()) {
+			sub.deliver(event)
+		}
+	}
+}
+
+func (b *memoryBroker) Subscribe(bufferSize int, policy OverflowPolicy, kinds ...Kind) Subscription {
+	sub := &memorySubscription{
+		broker: b,
+		events: make(chan Event, bufferSize),
+		done:   make(chan struct{}),
+		policy: policy,
+		kinds:  map[Kind]struct{}{},
+	}
+	for _, kind := range kinds {
+		sub.kinds[kind] = struct{}{}
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (b *memoryBroker) unsubscribe(sub *memorySubscription) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	delete(b.subscribers, sub)
+	close(sub.events)
+}
+
+type memorySubscription struct {
+	broker    *memoryBroker
+	events    chan Event
+	done      chan struct{}
+	policy    OverflowPolicy
+	kinds     map[Kind]struct{}
+	dropped   atomic.Uint64
+	closeOnce sync.Once
+}
+
+func (s *memorySubscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *memorySubscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close signals done before taking the broker lock, so that a Publish blocked on this subscriber is released instead
+// of deadlocking with the unsubscribe.
+func (s *memorySubscription) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.broker.unsubscribe(s)
+	})
+}
+
+func (s *memorySubscription) accepts(kind Kind) bool {
+	if len(s.kinds) == 0 {
+		return true
+	}
+	_, ok := s.kinds[kind]
+	return ok
+}
+
+// deliver must be called with the broker lock held
+func (s *memorySubscription) deliver(event Event) {
+	switch s.policy {
+	case Block:
+		select {
+		case s.events <- event:
+		case <-s.done:
+		}
+	case DropNewest:
+		s.deliverOrDrop(event)
+	case DropOldest:
+		// an unbuffered subscriber has no oldest event to discard
+		if cap(s.events) == 0 {
+			s.deliverOrDrop(event)
+			return
+		}
+		for {
+			select {
+			case s.events <- event:
+				return
+			default:
+			}
+			select {
+			case <-s.events:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	}
+}
+
+func (s *memorySubscription) deliverOrDrop(event Event) {
+	select {
+	case s.events <- event:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+type memoryLog struct {
+	events []Event
+	mtx    *sync.Mutex
+}
+
+func NewMemoryLog() Log {
+	return &memoryLog{
+		events: []Event{},
+		mtx:    &sync.Mutex{},
+	}
+}
+
+func (l *memoryLog) Append(event Event) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.events = append(l.events, event)
+	return nil
+}
+
+func (l *memoryLog) After(id id_generator.EventID) ([]Event, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	after := []Event{}
+	for _, event := range l.events {
+		if event.EventID() > id {
+			after = append(after, event)
+		}
+	}
+	return after, nil
+}
@@ -0,0 +1,31 @@
+package events
+
+import "testing"
+
+func WithMemoryBroker() func() Broker {
+	return func() Broker {
+		return NewMemoryBroker()
+	}
+}
+
+func Test(t *testing.T) {
+	tests := brokerTests{
+		brokerFn: WithMemoryBroker(),
+		t:        t,
+	}
+	tests.Run()
+}
+
+func WithMemoryLog() func() Log {
+	return func() Log {
+		return NewMemoryLog()
+	}
+}
+
+func TestLog(t *testing.T) {
+	tests := logTests{
+		logFn: WithMemoryLog(),
+		t:     t,
+	}
+	tests.Run()
+}
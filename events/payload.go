@@ -0,0 +1,114 @@
+package events
+
+import (
+	"auction/auction"
+	"auction/id_generator"
+	"time"
+)
+
+// Payload is the JSON representation of an event for consumers outside of the process. Auction is the auction the
+// event happened in, as the IDs of events are only unique within an auction. Bidder and Amount describe the
+// bidder the event is about, and Leader and LeaderAmount describe who is leading the auction. Max bids are never
+// included so that a bidder's limit is not revealed to the other bidders.
+type Payload struct {
+	ID           id_generator.EventID `json:"id"`
+	Auction      auction.ID           `json:"auction,omitempty"`
+	Kind         Kind                 `json:"kind"`
+	Time         time.Time            `json:"time"`
+	Bidder       auction.Bidder       `json:"bidder,omitempty"`
+	Amount       string               `json:"amount,omitempty"`
+	Leader       auction.Bidder       `json:"leader,omitempty"`
+	LeaderAmount string               `json:"leader_amount,omitempty"`
+	Reason       string               `json:"reason,omitempty"`
+}
+
+// NewPayload converts an event to its Payload. For LeaderChanged, Bidder and Amount are the previous leader.
+func NewPayload(event Event) Payload {
+	payload := Payload{
+		ID:      event.EventID(),
+		Auction: event.AuctionID(),
+		Kind:    event.Kind(),
+		Time:    event.OccurredAt(),
+	}
+	if e, ok := event.(describer); ok {
+		e.describe(&payload)
+	}
+	return payload
+}
+
+// describer is implemented by events with fields to add to their payload. It is a method rather than a switch on the
+// type of the event so that it covers every representation of the amounts of the generic events.
+type describer interface {
+	describe(payload *Payload)
+}
+
+func (e BidPlacedOf[M]) describe(payload *Payload) {
+	payload.Bidder = e.Bid.Bidder
+	payload.Amount = e.Bid.StartingBid.String()
+}
+
+func (e BidRejected) describe(payload *Payload) {
+	payload.Bidder = e.Bidder
+	payload.Reason = e.Reason
+}
+
+func (e BidAmendedOf[M]) describe(payload *Payload) {
+	payload.Bidder = e.Bid.Bidder
+}
+
+func (e BidRetracted) describe(payload *Payload) {
+	payload.Bidder = e.Bidder
+}
+
+func (e LeaderChangedOf[M]) describe(payload *Payload) {
+	if e.Previous.Bidder != "" {
+		payload.Bidder = e.Previous.Bidder
+		payload.Amount = e.Previous.Amount.String()
+	}
+	payload.Leader = e.Leader.Bidder
+	payload.LeaderAmount = e.Leader.Amount.String()
+}
+
+func (e PriceChangedOf[M]) describe(payload *Payload) {
+	payload.Leader = e.Leader.Bidder
+	payload.LeaderAmount = e.Leader.Amount.String()
+}
+
+func (e BidderOutbidOf[M]) describe(payload *Payload) {
+	payload.Bidder = e.Bidder
+	payload.Leader = e.Leader.Bidder
+	payload.LeaderAmount = e.Leader.Amount.String()
+}
+
+func (e BidderExhaustedOf[M]) describe(payload *Payload) {
+	payload.Bidder = e.Bidder
+	payload.Amount = e.Amount.String()
+}
+
+func (e WinnerDeterminedOf[M]) describe(payload *Payload) {
+	payload.Bidder = e.Winner.Bidder
+	payload.Amount = e.Winner.Amount.String()
+}
+
+func (e BidderDefaulted) describe(payload *Payload) {
+	payload.Bidder = e.Bidder
+}
+
+func (e SecondChanceOfferedOf[M]) describe(payload *Payload) {
+	payload.Bidder = e.Offer.Bidder
+	payload.Amount = e.Offer.Amount.String()
+}
+
+func (e SecondChanceAcceptedOf[M]) describe(payload *Payload) {
+	payload.Bidder = e.Winner.Bidder
+	payload.Amount = e.Winner.Amount.String()
+}
+
+func (e SecondChanceDeclined) describe(payload *Payload) {
+	payload.Bidder = e.Bidder
+}
+
+func (e BoughtItNowOf[M]) describe(payload *Payload) {
+	payload.Bidder = e.Winner.Bidder
+	payload.Amount = e.Winner.Amount.String()
+}
@@ -0,0 +1,11 @@
+package feed
+
+import "fmt"
+
+type InvalidLastEventIDError struct {
+	value string
+}
+
+func (e *InvalidLastEventIDError) Error() string {
+	return fmt.Sprintf("invalid last event id %s. It must be a non-negative integer", e.value)
+}
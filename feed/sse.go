@@ -0,0 +1,191 @@
+package feed
+
+import (
+	"auction/auction"
+	"auction/events"
+	"auction/id_generator"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	LastEventIDHeader = "Last-Event-ID"
+	// LastEventIDParam can be used instead of the header by clients that cannot set headers on reconnect
+	LastEventIDParam = "lastEventId"
+	// StandingEvent is the name of the snapshot sent to clients that are not resuming
+	StandingEvent = "standing"
+
+	defaultHeartbeat  = 15 * time.Second
+	defaultBufferSize = 64
+)
+
+// Standing is the snapshot of the auction sent when a client connects without a Last-Event-ID
+type Standing struct {
+	Leader auction.Bidder `json:"leader,omitempty"`
+	Amount string         `json:"amount,omitempty"`
+	Closed bool           `json:"closed"`
+}
+
+// Config configures the SSE handler. Zero values are replaced by the defaults.
+type Config struct {
+	// Heartbeat is how often a comment is sent to keep idle connections open
+	Heartbeat time.Duration
+	// BufferSize is the number of events buffered for a client before it is disconnected
+	BufferSize int
+}
+
+// sseHandler streams the events of a single auction as Server-Sent Events. The SSE id of every message is the
+// EventID, so a client that reconnects with Last-Event-ID is sent the events it missed from the log.
+type sseHandler struct {
+	broker events.Broker
+	log    events.Log
+	config Config
+}
+
+// NewSSEHandler creates a handler that streams events published to the broker. The log must record every event
+// published to the broker, which is done by wrapping the broker with events.NewRecordingBroker.
+func NewSSEHandler(broker events.Broker, log events.Log, config Config) http.Handler {
+	if config.Heartbeat == 0 {
+		config.Heartbeat = defaultHeartbeat
+	}
+	if config.BufferSize == 0 {
+		config.BufferSize = defaultBufferSize
+	}
+	return &sseHandler{
+		broker: broker,
+		log:    log,
+		config: config,
+	}
+}
+
+// ServeHTTP subscribes before reading the log, so that no event falls between the two. Events that are in both are
+// skipped by ID. A client that falls behind is disconnected instead of slowing down the auction, and catches up from
+// the log when it reconnects.
+func (h *sseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	lastID, resuming, err := parseLastEventID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sub := h.broker.Subscribe(h.config.BufferSize, events.DropNewest)
+	defer sub.Close()
+
+	history, err := h.log.After(lastID)
+	if err != nil {
+		http.Error(w, "failed to read events", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if resuming {
+		for _, event := range history {
+			err = writeEvent(w, event)
+			if err != nil {
+				return
+			}
+			lastID = event.EventID()
+		}
+	} else if len(history) > 0 {
+		lastID = history[len(history)-1].EventID()
+		err = writeMessage(w, lastID, StandingEvent, standingOf(history))
+		if err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.config.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if event.EventID() <= lastID {
+				continue
+			}
+			err = writeEvent(w, event)
+			lastID = event.EventID()
+		}
+		if err != nil || sub.Dropped() > 0 {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// parseLastEventID returns the ID the client is resuming from and whether it is resuming at all
+func parseLastEventID(r *http.Request) (id_generator.EventID, bool, error) {
+	value := r.Header.Get(LastEventIDHeader)
+	if value == "" {
+		value = r.URL.Query().Get(LastEventIDParam)
+	}
+	if value == "" {
+		return 0, false, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false, &InvalidLastEventIDError{value: value}
+	}
+	return id_generator.EventID(id), true, nil
+}
+
+// standingOf replays the history to find the current leader. The leader is read from the payload of each event, so
+// that it works for every representation of the amounts. An auction whose winner defaulted has no leader until a
+// second-chance offer is accepted.
+func standingOf(history []events.Event) Standing {
+	standing := Standing{}
+	for _, event := range history {
+		payload := events.NewPayload(event)
+		switch event.Kind() {
+		case events.KindLeaderChanged, events.KindPriceChanged:
+			standing.Leader, standing.Amount = payload.Leader, payload.LeaderAmount
+		case events.KindWinnerDetermined, events.KindSecondChanceAccepted:
+			standing.Leader, standing.Amount = payload.Bidder, payload.Amount
+		case events.KindAuctionClosed:
+			standing.Closed = true
+		case events.KindBoughtItNow:
+			standing.Leader, standing.Amount = payload.Bidder, payload.Amount
+			standing.Closed = true
+		case events.KindBidderDefaulted, events.KindSecondChanceDeclined:
+			standing.Leader = ""
+		}
+		// the amount of an empty leader, such as after every bid was retracted, is zero rather than a price
+		if standing.Leader == "" {
+			standing.Amount = ""
+		}
+	}
+	return standing
+}
+
+func writeEvent(w http.ResponseWriter, event events.Event) error {
+	return writeMessage(w, event.EventID(), string(event.Kind()), events.NewPayload(event))
+}
+
+func writeMessage(w http.ResponseWriter, id id_generator.EventID, name string, data any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, name, body)
+	return err
+}
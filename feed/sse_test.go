@@ -0,0 +1,248 @@
+package feed
+
+import (
+	"auction/auction"
+	"auction/bid_manager"
+	"auction/currency"
+	"auction/events"
+	"auction/id_generator"
+	"auction/storage"
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type message struct {
+	id    string
+	event string
+	data  string
+}
+
+type testFeed struct {
+	manager bid_manager.BidManager
+	server  *httptest.Server
+}
+
+func newTestFeed(t *testing.T) *testFeed {
+	log := events.NewMemoryLog()
+	broker := events.NewRecordingBroker(events.NewMemoryBroker(), log)
+	manager, err := bid_manager.NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), bid_manager.WithPublisher(broker))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	// registered before any connection is opened, so that connections are cancelled before the server is closed
+	server := httptest.NewServer(NewSSEHandler(broker, log, Config{}))
+	t.Cleanup(server.Close)
+	return &testFeed{
+		manager: manager,
+		server:  server,
+	}
+}
+
+func (f *testFeed) addBid(t *testing.T, bidder, startingBid, maxBid, increment string) {
+	err := f.manager.AddBid(bidder, startingBid, maxBid, increment)
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+}
+
+// connect opens the stream and returns a channel of the messages received
+func (f *testFeed) connect(t *testing.T, lastEventID string) <-chan message {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.server.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %s", err.Error())
+	}
+	if lastEventID != "" {
+		req.Header.Set(LastEventIDHeader, lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to connect: %s", err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	messages := make(chan message, 100)
+	go func() {
+		defer resp.Body.Close()
+		defer close(messages)
+		scanner := bufio.NewScanner(resp.Body)
+		msg := message{}
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if msg.event != "" {
+					messages <- msg
+				}
+				msg = message{}
+			case strings.HasPrefix(line, "id: "):
+				msg.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				msg.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				msg.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+	return messages
+}
+
+func receive(t *testing.T, messages <-chan message) message {
+	select {
+	case msg := <-messages:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for message")
+	}
+	return message{}
+}
+
+func TestLiveEvents(t *testing.T) {
+	feed := newTestFeed(t)
+
+	messages := feed.connect(t, "")
+	feed.addBid(t, "Sasha", "$50.00", "$80.00", "$3.00")
+
+	expected := []message{
+		{id: "1", event: string(events.KindBidPlaced)},
+		{id: "2", event: string(events.KindLeaderChanged)},
+	}
+	for _, exp := range expected {
+		msg := receive(t, messages)
+		if msg.id != exp.id || msg.event != exp.event {
+			t.Fatalf("Expected message %#v, got %#v", exp, msg)
+		}
+	}
+}
+
+func TestStandingSnapshot(t *testing.T) {
+	feed := newTestFeed(t)
+
+	feed.addBid(t, "Sasha", "$50.00", "$80.00", "$3.00")
+	feed.addBid(t, "Pat", "$55.00", "$85.00", "$5.00")
+
+	msg := receive(t, feed.connect(t, ""))
+	if msg.event != StandingEvent {
+		t.Fatalf("Expected %s message, got %s", StandingEvent, msg.event)
+	}
+	var standing Standing
+	err := json.Unmarshal([]byte(msg.data), &standing)
+	if err != nil {
+		t.Fatalf("Failed to decode standing: %s", err.Error())
+	}
+	expected := Standing{Leader: "Pat", Amount: "$85.00"}
+	if standing != expected {
+		t.Fatalf("Expected standing %#v, got %#v", expected, standing)
+	}
+}
+
+func TestStandingOf(t *testing.T) {
+	usd := func(major int64) currency.Amount {
+		return currency.MustNew(major, 0, currency.USD)
+	}
+	sasha := auction.WinningBid{Bidder: "Sasha", Amount: usd(50)}
+	john := auction.WinningBid{Bidder: "John", Amount: usd(47)}
+	closed := []events.Event{
+		events.LeaderChanged{Header: events.Header{ID: 1}, Leader: sasha},
+		events.AuctionClosed{Header: events.Header{ID: 2}},
+		events.WinnerDetermined{Header: events.Header{ID: 3}, Winner: sasha},
+		events.BidderDefaulted{Header: events.Header{ID: 4}, Bidder: "Sasha"},
+		events.SecondChanceOffered{Header: events.Header{ID: 5}, Offer: auction.SecondChanceOffer{Bidder: "John", Amount: usd(47)}},
+	}
+	type testCase struct {
+		name     string
+		history  []events.Event
+		expected Standing
+	}
+	testCases := []testCase{
+		{
+			name: "Every Bid Retracted",
+			history: []events.Event{
+				events.LeaderChanged{Header: events.Header{ID: 1}, Leader: sasha},
+				events.LeaderChanged{Header: events.Header{ID: 2}, Previous: sasha},
+			},
+			expected: Standing{},
+		},
+		{
+			name:     "Winner Defaulted",
+			history:  closed,
+			expected: Standing{Closed: true},
+		},
+		{
+			name:     "Offer Declined",
+			history:  append(slices.Clone(closed), events.SecondChanceDeclined{Header: events.Header{ID: 6}, Bidder: "John"}),
+			expected: Standing{Closed: true},
+		},
+		{
+			name:     "Offer Accepted",
+			history:  append(slices.Clone(closed), events.SecondChanceAccepted{Header: events.Header{ID: 6}, Winner: john}),
+			expected: Standing{Leader: "John", Amount: "$47.00", Closed: true},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			standing := standingOf(test.history)
+			if standing != test.expected {
+				t.Fatalf("Expected standing %#v, got %#v", test.expected, standing)
+			}
+		})
+	}
+}
+
+func TestResume(t *testing.T) {
+	feed := newTestFeed(t)
+
+	feed.addBid(t, "Sasha", "$50.00", "$80.00", "$3.00")
+	feed.addBid(t, "Pat", "$55.00", "$85.00", "$5.00")
+
+	messages := feed.connect(t, "2")
+	expected := []message{
+		{id: "3", event: string(events.KindBidPlaced)},
+		{id: "4", event: string(events.KindLeaderChanged)},
+		{id: "5", event: string(events.KindBidderOutbid)},
+		{id: "6", event: string(events.KindBidderExhausted)},
+	}
+	for _, exp := range expected {
+		msg := receive(t, messages)
+		if msg.id != exp.id || msg.event != exp.event {
+			t.Fatalf("Expected message %#v, got %#v", exp, msg)
+		}
+	}
+
+	err := feed.manager.Close()
+	if err != nil {
+		t.Fatalf("Failed to close auction: %s", err.Error())
+	}
+	msg := receive(t, messages)
+	if msg.id != "7" || msg.event != string(events.KindAuctionClosed) {
+		t.Fatalf("Expected live message after resuming, got %#v", msg)
+	}
+}
+
+func TestInvalidLastEventID(t *testing.T) {
+	feed := newTestFeed(t)
+
+	for _, value := range []string{"abc", "-1"} {
+		t.Run(value, func(t *testing.T) {
+			resp, err := http.Get(fmt.Sprintf("%s?%s=%s", feed.server.URL, LastEventIDParam, value))
+			if err != nil {
+				t.Fatalf("Failed to connect: %s", err.Error())
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("Expected status 400, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package fees
+
+import (
+	"auction/auction"
+	"auction/currency"
+)
+
+type Kind string
+
+const (
+	KindHammerPrice      Kind = "hammer_price"
+	KindBuyersPremium    Kind = "buyers_premium"
+	KindSalesTax         Kind = "sales_tax"
+	KindSellerCommission Kind = "seller_commission"
+	KindListingFee       Kind = "listing_fee"
+)
+
+// LineItem is a single charge or deduction on a breakdown. Amounts charged to the buyer are positive and amounts
+// deducted from the payout of the seller are negative, so the items of each side add up to its total.
+type LineItem struct {
+	Kind        Kind            `json:"kind"`
+	Description string          `json:"description"`
+	Amount      currency.Amount `json:"amount"`
+}
+
+// Breakdown itemizes what the buyer of a lot pays and what its seller is paid. Both sides start from the hammer price.
+type Breakdown struct {
+	Bidder       auction.Bidder  `json:"bidder"`
+	HammerPrice  currency.Amount `json:"hammer_price"`
+	Jurisdiction Jurisdiction    `json:"jurisdiction"`
+	Buyer        []LineItem      `json:"buyer"`
+	BuyerTotal   currency.Amount `json:"buyer_total"`
+	Seller       []LineItem      `json:"seller"`
+	SellerPayout currency.Amount `json:"seller_payout"`
+}
+
+// sum adds up the amounts of the line items, starting from zero in the currency
+func sum(code currency.Code, items []LineItem) (currency.Amount, error) {
+	total := currency.FromMinorUnits(0, code)
+	for _, item := range items {
+		var err error
+		total, err = total.Add(item.Amount)
+		if err != nil {
+			return currency.Amount{}, err
+		}
+	}
+	return total, nil
+}
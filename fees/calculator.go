@@ -0,0 +1,170 @@
+package fees
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"fmt"
+)
+
+type Calculator interface {
+	// Calculate itemizes the fees of the winning bid, charging the sales tax of the buyer's jurisdiction
+	Calculate(winner auction.WinningBid, jurisdiction Jurisdiction) (Breakdown, error)
+}
+
+type scheduleCalculator struct {
+	schedule Schedule
+}
+
+// NewScheduleCalculator creates a Calculator that charges the fees of the schedule. It returns an
+// InvalidScheduleError if the schedule cannot be applied.
+func NewScheduleCalculator(schedule Schedule) (Calculator, error) {
+	schedule = schedule.withTierCurrency()
+	err := schedule.validate()
+	if err != nil {
+		return nil, err
+	}
+	return &scheduleCalculator{schedule: schedule}, nil
+}
+
+// Calculate charges the buyer the hammer price, the buyer's premium and sales tax, and pays the seller the hammer
+// price less the seller commission and the listing fee. Each line item is rounded separately, so the totals are always
+// the sum of the items that are shown. The listing fee is limited to what is left of the payout after the commission,
+// so a seller is never paid a negative amount.
+func (c *scheduleCalculator) Calculate(winner auction.WinningBid, jurisdiction Jurisdiction) (Breakdown, error) {
+	s := c.schedule
+	hammer := winner.Amount
+	_, err := hammer.Cmp(currency.FromMinorUnits(0, s.Currency))
+	if err != nil {
+		return Breakdown{}, err
+	}
+	tax, ok := s.SalesTax[jurisdiction]
+	if !ok {
+		return Breakdown{}, &UnknownJurisdictionError{jurisdiction: jurisdiction}
+	}
+
+	hammerItem := LineItem{Kind: KindHammerPrice, Description: "Hammer price", Amount: hammer}
+
+	premium, err := c.tiered(KindBuyersPremium, "Buyer's premium", hammer, s.BuyersPremium)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	buyer := append([]LineItem{hammerItem}, premium...)
+	if tax.Rate.IsPositive() {
+		item, err := c.salesTax(jurisdiction, tax, hammer, premium)
+		if err != nil {
+			return Breakdown{}, err
+		}
+		buyer = append(buyer, item)
+	}
+
+	commission, err := c.tiered(KindSellerCommission, "Seller commission", hammer, s.SellerCommission)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	seller := []LineItem{hammerItem}
+	for _, item := range commission {
+		item.Amount, err = item.Amount.Neg()
+		if err != nil {
+			return Breakdown{}, err
+		}
+		seller = append(seller, item)
+	}
+	sellerPayout, err := sum(s.Currency, seller)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	if sellerPayout.Sign() < 0 {
+		return Breakdown{}, &InvalidScheduleError{message: fmt.Sprintf("seller commission on %s exceeds the hammer price", hammer)}
+	}
+	if !s.ListingFee.IsZero() {
+		item, err := c.listingFee(sellerPayout)
+		if err != nil {
+			return Breakdown{}, err
+		}
+		seller = append(seller, item)
+		sellerPayout, err = sellerPayout.Add(item.Amount)
+		if err != nil {
+			return Breakdown{}, err
+		}
+	}
+
+	buyerTotal, err := sum(s.Currency, buyer)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	return Breakdown{
+		Bidder:       winner.Bidder,
+		HammerPrice:  hammer,
+		Jurisdiction: jurisdiction,
+		Buyer:        buyer,
+		BuyerTotal:   buyerTotal,
+		Seller:       seller,
+		SellerPayout: sellerPayout,
+	}, nil
+}
+
+// tiered charges the rate of each tier on the part of the hammer price that falls in it, with a line item for each
+// tier the hammer price reaches
+func (c *scheduleCalculator) tiered(kind Kind, name string, hammer currency.Amount, tiers []Tier) ([]LineItem, error) {
+	items := []LineItem{}
+	for i, tier := range tiers {
+		if !hammer.Greater(tier.From) {
+			break
+		}
+		upper := hammer
+		if i+1 < len(tiers) && tiers[i+1].From.Less(hammer) {
+			upper = tiers[i+1].From
+		}
+		band, err := upper.Sub(tier.From)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := band.Percent(tier.Rate, c.schedule.Rounding)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, LineItem{
+			Kind:        kind,
+			Description: fmt.Sprintf("%s at %s%% of %s", name, tier.Rate, band),
+			Amount:      amount,
+		})
+	}
+	return items, nil
+}
+
+// listingFee deducts the listing fee from the payout, or only the payout if the fee is larger
+func (c *scheduleCalculator) listingFee(payout currency.Amount) (LineItem, error) {
+	fee, description := c.schedule.ListingFee, "Listing fee"
+	if payout.Less(fee) {
+		fee, description = payout, fmt.Sprintf("Listing fee of %s, limited to the payout", fee)
+	}
+	amount, err := fee.Neg()
+	if err != nil {
+		return LineItem{}, err
+	}
+	return LineItem{Kind: KindListingFee, Description: description, Amount: amount}, nil
+}
+
+// salesTax charges the tax of the jurisdiction on the hammer price, and on the premium if the jurisdiction taxes it
+func (c *scheduleCalculator) salesTax(jurisdiction Jurisdiction, tax Tax, hammer currency.Amount, premium []LineItem) (LineItem, error) {
+	base := hammer
+	if tax.IncludePremium {
+		premiumTotal, err := sum(c.schedule.Currency, premium)
+		if err != nil {
+			return LineItem{}, err
+		}
+		base, err = base.Add(premiumTotal)
+		if err != nil {
+			return LineItem{}, err
+		}
+	}
+	amount, err := base.Percent(tax.Rate, c.schedule.Rounding)
+	if err != nil {
+		return LineItem{}, err
+	}
+	return LineItem{
+		Kind:        KindSalesTax,
+		Description: fmt.Sprintf("Sales tax (%s) at %s%% of %s", jurisdiction, tax.Rate, base),
+		Amount:      amount,
+	}, nil
+}
@@ -0,0 +1,284 @@
+package fees
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func usd(major, minor int64) currency.Amount {
+	return currency.MustNew(major, minor, currency.USD)
+}
+
+func newSchedule() Schedule {
+	return Schedule{
+		Currency: currency.USD,
+		BuyersPremium: []Tier{
+			{From: usd(0, 0), Rate: currency.NewRate(25, 0)},
+			{From: usd(1_000_000, 0), Rate: currency.NewRate(20, 0)},
+			{From: usd(4_500_000, 0), Rate: currency.NewRate(139, 1)},
+		},
+		SellerCommission: []Tier{{From: usd(0, 0), Rate: currency.NewRate(10, 0)}},
+		ListingFee:       usd(50, 0),
+		SalesTax: map[Jurisdiction]Tax{
+			"US-NY": {Rate: currency.NewRate(8875, 3), IncludePremium: true},
+			"US-TX": {Rate: currency.NewRate(625, 2)},
+			"US-OR": {},
+		},
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	type testCase struct {
+		name         string
+		hammer       currency.Amount
+		jurisdiction Jurisdiction
+		buyer        []LineItem
+		buyerTotal   currency.Amount
+		seller       []LineItem
+		sellerPayout currency.Amount
+	}
+	testCases := []testCase{
+		{
+			name:         "Single Tier",
+			hammer:       usd(86, 0),
+			jurisdiction: "US-NY",
+			buyer: []LineItem{
+				{Kind: KindHammerPrice, Description: "Hammer price", Amount: usd(86, 0)},
+				{Kind: KindBuyersPremium, Description: "Buyer's premium at 25% of $86.00", Amount: usd(21, 50)},
+				// 8.875% of $107.50 is $9.540625
+				{Kind: KindSalesTax, Description: "Sales tax (US-NY) at 8.875% of $107.50", Amount: usd(9, 54)},
+			},
+			buyerTotal: usd(117, 4),
+			seller: []LineItem{
+				{Kind: KindHammerPrice, Description: "Hammer price", Amount: usd(86, 0)},
+				{Kind: KindSellerCommission, Description: "Seller commission at 10% of $86.00", Amount: usd(-8, -60)},
+				{Kind: KindListingFee, Description: "Listing fee", Amount: usd(-50, 0)},
+			},
+			sellerPayout: usd(27, 40),
+		},
+		{
+			name:         "Several Tiers",
+			hammer:       usd(1_500_000, 0),
+			jurisdiction: "US-NY",
+			buyer: []LineItem{
+				{Kind: KindHammerPrice, Description: "Hammer price", Amount: usd(1_500_000, 0)},
+				{Kind: KindBuyersPremium, Description: "Buyer's premium at 25% of $1000000.00", Amount: usd(250_000, 0)},
+				{Kind: KindBuyersPremium, Description: "Buyer's premium at 20% of $500000.00", Amount: usd(100_000, 0)},
+				{Kind: KindSalesTax, Description: "Sales tax (US-NY) at 8.875% of $1850000.00", Amount: usd(164_187, 50)},
+			},
+			buyerTotal: usd(2_014_187, 50),
+			seller: []LineItem{
+				{Kind: KindHammerPrice, Description: "Hammer price", Amount: usd(1_500_000, 0)},
+				{Kind: KindSellerCommission, Description: "Seller commission at 10% of $1500000.00", Amount: usd(-150_000, 0)},
+				{Kind: KindListingFee, Description: "Listing fee", Amount: usd(-50, 0)},
+			},
+			sellerPayout: usd(1_349_950, 0),
+		},
+		{
+			name:         "Tier Boundary",
+			hammer:       usd(1_000_000, 0),
+			jurisdiction: "US-OR",
+			buyer: []LineItem{
+				{Kind: KindHammerPrice, Description: "Hammer price", Amount: usd(1_000_000, 0)},
+				{Kind: KindBuyersPremium, Description: "Buyer's premium at 25% of $1000000.00", Amount: usd(250_000, 0)},
+			},
+			buyerTotal: usd(1_250_000, 0),
+			seller: []LineItem{
+				{Kind: KindHammerPrice, Description: "Hammer price", Amount: usd(1_000_000, 0)},
+				{Kind: KindSellerCommission, Description: "Seller commission at 10% of $1000000.00", Amount: usd(-100_000, 0)},
+				{Kind: KindListingFee, Description: "Listing fee", Amount: usd(-50, 0)},
+			},
+			sellerPayout: usd(899_950, 0),
+		},
+		{
+			name:         "Tax On Hammer Price",
+			hammer:       usd(10, 1),
+			jurisdiction: "US-TX",
+			buyer: []LineItem{
+				{Kind: KindHammerPrice, Description: "Hammer price", Amount: usd(10, 1)},
+				// 25% of $10.01 is $2.5025
+				{Kind: KindBuyersPremium, Description: "Buyer's premium at 25% of $10.01", Amount: usd(2, 50)},
+				// 6.25% of $10.01 is $0.625625
+				{Kind: KindSalesTax, Description: "Sales tax (US-TX) at 6.25% of $10.01", Amount: usd(0, 63)},
+			},
+			buyerTotal: usd(13, 14),
+			seller: []LineItem{
+				{Kind: KindHammerPrice, Description: "Hammer price", Amount: usd(10, 1)},
+				{Kind: KindSellerCommission, Description: "Seller commission at 10% of $10.01", Amount: usd(-1, 0)},
+				// the $50.00 listing fee is more than the $9.01 left after the commission
+				{Kind: KindListingFee, Description: "Listing fee of $50.00, limited to the payout", Amount: usd(-9, -1)},
+			},
+			sellerPayout: usd(0, 0),
+		},
+	}
+
+	calculator, err := NewScheduleCalculator(newSchedule())
+	if err != nil {
+		t.Fatalf("could not initialize calculator: %s", err.Error())
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			breakdown, err := calculator.Calculate(auction.WinningBid{Bidder: "Sasha", Amount: test.hammer}, test.jurisdiction)
+			if err != nil {
+				t.Fatalf("Failed to calculate fees: %s", err.Error())
+			}
+			expected := Breakdown{
+				Bidder:       "Sasha",
+				HammerPrice:  test.hammer,
+				Jurisdiction: test.jurisdiction,
+				Buyer:        test.buyer,
+				BuyerTotal:   test.buyerTotal,
+				Seller:       test.seller,
+				SellerPayout: test.sellerPayout,
+			}
+			if !reflect.DeepEqual(expected, breakdown) {
+				t.Fatalf("Expected %#v, got %#v", expected, breakdown)
+			}
+		})
+	}
+}
+
+func TestCalculate_Rounding(t *testing.T) {
+	schedule := newSchedule()
+	schedule.Rounding = currency.RoundUp
+	calculator, err := NewScheduleCalculator(schedule)
+	if err != nil {
+		t.Fatalf("could not initialize calculator: %s", err.Error())
+	}
+	breakdown, err := calculator.Calculate(auction.WinningBid{Bidder: "Sasha", Amount: usd(10, 1)}, "US-TX")
+	if err != nil {
+		t.Fatalf("Failed to calculate fees: %s", err.Error())
+	}
+	if breakdown.BuyerTotal != usd(13, 15) {
+		t.Fatalf("Expected the premium to be rounded up to $2.51, got %#v", breakdown.Buyer)
+	}
+}
+
+func TestCalculate_Errors(t *testing.T) {
+	calculator, err := NewScheduleCalculator(newSchedule())
+	if err != nil {
+		t.Fatalf("could not initialize calculator: %s", err.Error())
+	}
+
+	_, err = calculator.Calculate(auction.WinningBid{Bidder: "Sasha", Amount: usd(86, 0)}, "GB")
+	if _, ok := err.(*UnknownJurisdictionError); !ok {
+		t.Errorf("Expected UnknownJurisdictionError but got %#v", err)
+	}
+
+	_, err = calculator.Calculate(auction.WinningBid{Bidder: "Sasha", Amount: currency.MustNew(86, 0, currency.EUR)}, "US-NY")
+	var mismatch *currency.CurrencyMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Errorf("Expected CurrencyMismatchError but got %#v", err)
+	}
+
+	schedule := newSchedule()
+	schedule.SellerCommission[0].Rate = currency.NewRate(150, 0)
+	calculator, err = NewScheduleCalculator(schedule)
+	if err != nil {
+		t.Fatalf("could not initialize calculator: %s", err.Error())
+	}
+	_, err = calculator.Calculate(auction.WinningBid{Bidder: "Sasha", Amount: usd(86, 0)}, "US-NY")
+	if _, ok := err.(*InvalidScheduleError); !ok {
+		t.Errorf("Expected InvalidScheduleError but got %#v", err)
+	}
+}
+
+func TestNewScheduleCalculator_Invalid(t *testing.T) {
+	testCases := map[string]func(s *Schedule){
+		"First Tier Above Zero": func(s *Schedule) { s.BuyersPremium[0].From = usd(1, 0) },
+		"Tiers Out Of Order":    func(s *Schedule) { s.BuyersPremium[2].From = usd(1_000_000, 0) },
+		"Tier Currency":         func(s *Schedule) { s.SellerCommission[0].From = currency.MustNew(0, 0, currency.EUR) },
+		"Negative Rate":         func(s *Schedule) { s.SellerCommission[0].Rate = currency.NewRate(-10, 0) },
+		"Negative Listing Fee":  func(s *Schedule) { s.ListingFee = usd(-50, 0) },
+		"Listing Fee Currency":  func(s *Schedule) { s.ListingFee = currency.MustNew(50, 0, currency.GBP) },
+		"Negative Tax":          func(s *Schedule) { s.SalesTax["US-OR"] = Tax{Rate: currency.NewRate(-1, 0)} },
+		"No Currency":           func(s *Schedule) { s.Currency = "" },
+	}
+	for name, change := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule := newSchedule()
+			change(&schedule)
+			_, err := NewScheduleCalculator(schedule)
+			if _, ok := err.(*InvalidScheduleError); !ok {
+				t.Fatalf("Expected InvalidScheduleError but got %#v", err)
+			}
+		})
+	}
+
+	_, err := NewScheduleCalculator(Schedule{Currency: "XXX"})
+	if _, ok := err.(*currency.UnknownCurrencyError); !ok {
+		t.Fatalf("Expected UnknownCurrencyError but got %#v", err)
+	}
+}
+
+func TestNewScheduleCalculator_NoFees(t *testing.T) {
+	calculator, err := NewScheduleCalculator(Schedule{Currency: currency.EUR, SalesTax: map[Jurisdiction]Tax{"DE": {}}})
+	if err != nil {
+		t.Fatalf("could not initialize calculator: %s", err.Error())
+	}
+	hammer := currency.MustNew(1425, 0, currency.EUR)
+	breakdown, err := calculator.Calculate(auction.WinningBid{Bidder: "Sasha", Amount: hammer}, "DE")
+	if err != nil {
+		t.Fatalf("Failed to calculate fees: %s", err.Error())
+	}
+	if breakdown.BuyerTotal != hammer || breakdown.SellerPayout != hammer {
+		t.Fatalf("Expected both sides to be the hammer price, got %#v", breakdown)
+	}
+}
+
+func TestNewScheduleCalculator_UnsetFrom(t *testing.T) {
+	// the first tier starts from the zero Amount, which is in dollars, in a schedule in euros
+	schedule := Schedule{
+		Currency:      currency.EUR,
+		BuyersPremium: []Tier{{Rate: currency.NewRate(20, 0)}, {From: currency.MustNew(1000, 0, currency.EUR), Rate: currency.NewRate(10, 0)}},
+		SalesTax:      map[Jurisdiction]Tax{"DE": {}},
+	}
+	calculator, err := NewScheduleCalculator(schedule)
+	if err != nil {
+		t.Fatalf("could not initialize calculator: %s", err.Error())
+	}
+	breakdown, err := calculator.Calculate(auction.WinningBid{Bidder: "Sasha", Amount: currency.MustNew(1500, 0, currency.EUR)}, "DE")
+	if err != nil {
+		t.Fatalf("Failed to calculate fees: %s", err.Error())
+	}
+	// 20% of the first €1,000.00 and 10% of the remaining €500.00
+	expected := currency.MustNew(1750, 0, currency.EUR)
+	if breakdown.BuyerTotal != expected {
+		t.Fatalf("Expected a buyer total of %s, got %s", expected, breakdown.BuyerTotal)
+	}
+	if schedule.BuyersPremium[0].From != (currency.Amount{}) {
+		t.Fatalf("Expected the schedule that was given to be unchanged, got %#v", schedule.BuyersPremium[0])
+	}
+}
+
+func TestBreakdown_JSON(t *testing.T) {
+	breakdown := Breakdown{
+		Bidder:       "Sasha",
+		HammerPrice:  usd(86, 0),
+		Jurisdiction: "US-OR",
+		Buyer: []LineItem{
+			{Kind: KindHammerPrice, Description: "Hammer price", Amount: usd(86, 0)},
+		},
+		BuyerTotal: usd(86, 0),
+		Seller: []LineItem{
+			{Kind: KindHammerPrice, Description: "Hammer price", Amount: usd(86, 0)},
+			{Kind: KindListingFee, Description: "Listing fee", Amount: usd(-50, 0)},
+		},
+		SellerPayout: usd(36, 0),
+	}
+	data, err := json.Marshal(breakdown)
+	if err != nil {
+		t.Fatalf("Failed to marshal breakdown: %s", err.Error())
+	}
+	expected := `{"bidder":"Sasha","hammer_price":"USD 86.00","jurisdiction":"US-OR",` +
+		`"buyer":[{"kind":"hammer_price","description":"Hammer price","amount":"USD 86.00"}],"buyer_total":"USD 86.00",` +
+		`"seller":[{"kind":"hammer_price","description":"Hammer price","amount":"USD 86.00"},` +
+		`{"kind":"listing_fee","description":"Listing fee","amount":"USD -50.00"}],"seller_payout":"USD 36.00"}`
+	if string(data) != expected {
+		t.Fatalf("Expected %s, got %s", expected, data)
+	}
+}
@@ -0,0 +1,19 @@
+package fees
+
+import "fmt"
+
+type InvalidScheduleError struct {
+	message string
+}
+
+func (e *InvalidScheduleError) Error() string {
+	return e.message
+}
+
+type UnknownJurisdictionError struct {
+	jurisdiction Jurisdiction
+}
+
+func (e *UnknownJurisdictionError) Error() string {
+	return fmt.Sprintf("no sales tax is configured for jurisdiction %s", e.jurisdiction)
+}
@@ -0,0 +1,117 @@
+package fees
+
+import (
+	"auction/currency"
+	"fmt"
+)
+
+// Jurisdiction identifies where sales tax is charged, such as "US-NY" or "GB"
+type Jurisdiction string
+
+// Tier charges a percentage of the part of the hammer price from From up to the From of the next tier. The tiers of a
+// schedule are marginal, so with tiers of 25% from $0 and 20% from $1,000,000 a hammer price of $1,500,000 pays 25%
+// of the first $1,000,000 and 20% of the remaining $500,000.
+type Tier struct {
+	From currency.Amount
+	Rate currency.Rate
+}
+
+// Tax is the sales tax of a jurisdiction. Some jurisdictions tax the buyer's premium along with the hammer price and
+// others only tax the hammer price.
+type Tax struct {
+	Rate           currency.Rate
+	IncludePremium bool
+}
+
+// Schedule configures the fees of an auction. Every amount must be in the currency of the schedule, and rates are
+// percentages, so a rate of 12.5 is 12.5%.
+type Schedule struct {
+	Currency currency.Code
+	// BuyersPremium is charged to the buyer on top of the hammer price. The first tier must start from zero, which can
+	// be left unset, and the tiers must be in increasing order of From.
+	BuyersPremium []Tier
+	// SellerCommission is deducted from the hammer price paid to the seller, in tiers in the same way as the premium
+	SellerCommission []Tier
+	// ListingFee is a flat fee deducted from the payout of the seller, up to what is left of the payout after the
+	// commission
+	ListingFee currency.Amount
+	// SalesTax is the tax charged to the buyer in each jurisdiction. A jurisdiction that does not charge tax must be
+	// given a rate of zero, so that a missing jurisdiction is not mistaken for one without tax.
+	SalesTax map[Jurisdiction]Tax
+	// Rounding rounds each line item to a minor unit of the currency, and is half-even if it is not set
+	Rounding currency.RoundingMode
+}
+
+// withTierCurrency returns the schedule with the unset From of each tier, which is the zero Amount in the
+// DefaultCurrency, set to zero in the currency of the schedule. The tiers are copied, so the schedule that was given is
+// not changed.
+func (s Schedule) withTierCurrency() Schedule {
+	s.BuyersPremium = tiersIn(s.Currency, s.BuyersPremium)
+	s.SellerCommission = tiersIn(s.Currency, s.SellerCommission)
+	return s
+}
+
+func tiersIn(code currency.Code, tiers []Tier) []Tier {
+	if tiers == nil {
+		return nil
+	}
+	copied := make([]Tier, len(tiers))
+	for i, tier := range tiers {
+		if tier.From == (currency.Amount{}) {
+			tier.From = currency.FromMinorUnits(0, code)
+		}
+		copied[i] = tier
+	}
+	return copied
+}
+
+// validate checks that the amounts are in the currency of the schedule and that the tiers and rates can be applied
+func (s Schedule) validate() error {
+	// Lookup treats an empty code as the DefaultCurrency, which the amounts of the schedule would not be in
+	if s.Currency == "" {
+		return &InvalidScheduleError{message: "a schedule must have a currency"}
+	}
+	_, err := currency.Lookup(s.Currency)
+	if err != nil {
+		return err
+	}
+	err = s.validateTiers("buyer's premium", s.BuyersPremium)
+	if err != nil {
+		return err
+	}
+	err = s.validateTiers("seller commission", s.SellerCommission)
+	if err != nil {
+		return err
+	}
+	// the zero Amount is in the DefaultCurrency, so a schedule without a listing fee can leave it unset
+	if !s.ListingFee.IsZero() && s.ListingFee.Code() != s.Currency {
+		return &InvalidScheduleError{message: fmt.Sprintf("listing fee %s must be in %s", s.ListingFee, s.Currency)}
+	}
+	if s.ListingFee.Sign() < 0 {
+		return &InvalidScheduleError{message: fmt.Sprintf("listing fee %s cannot be negative", s.ListingFee)}
+	}
+	for jurisdiction, tax := range s.SalesTax {
+		if tax.Rate.Sign() < 0 {
+			return &InvalidScheduleError{message: fmt.Sprintf("sales tax of %s cannot be negative", jurisdiction)}
+		}
+	}
+	return nil
+}
+
+func (s Schedule) validateTiers(name string, tiers []Tier) error {
+	for i, tier := range tiers {
+		if tier.From.Code() != s.Currency {
+			return &InvalidScheduleError{message: fmt.Sprintf("%s tier %d must be in %s", name, i+1, s.Currency)}
+		}
+		if tier.Rate.Sign() < 0 {
+			return &InvalidScheduleError{message: fmt.Sprintf("%s tier %d cannot have a negative rate", name, i+1)}
+		}
+		if i == 0 && !tier.From.IsZero() {
+			return &InvalidScheduleError{message: fmt.Sprintf("the first %s tier must start from zero", name)}
+		}
+		if i > 0 && !tier.From.Greater(tiers[i-1].From) {
+			return &InvalidScheduleError{message: fmt.Sprintf("%s tier %d must start above tier %d", name, i+1, i)}
+		}
+	}
+	return nil
+}
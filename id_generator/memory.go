@@ -9,8 +9,14 @@ type memoryIDGenerator struct {
 }
 
 func NewMemoryIDGenerator() IDGenerator {
+	return NewMemoryIDGeneratorFrom(0)
+}
+
+// NewMemoryIDGeneratorFrom creates a generator that continues after the last ID that was issued, such as when an
+// auction is rebuilt from its event log after a restart
+func NewMemoryIDGeneratorFrom(last EventID) IDGenerator {
 	return &memoryIDGenerator{
-		latestID: 0,
+		latestID: uint32(last),
 	}
 }
 
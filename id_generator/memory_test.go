@@ -15,3 +15,10 @@ func Test(t *testing.T) {
 	}
 	tests.Run()
 }
+
+func TestMemoryIDGeneratorFrom(t *testing.T) {
+	generator := NewMemoryIDGeneratorFrom(41)
+	if id := generator.Next(); id != 42 {
+		t.Fatalf("Expected the generator to continue at 42, got %d", id)
+	}
+}
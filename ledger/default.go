@@ -0,0 +1,158 @@
+package ledger
+
+import (
+	"auction/currency"
+	"auction/id_generator"
+	"errors"
+	"sort"
+	"time"
+)
+
+// defaultLedger keeps its transactions in a Storer. Transactions are only ever added, so the balances are calculated
+// from the history of an account rather than stored.
+type defaultLedger struct {
+	config      Config
+	idGenerator id_generator.IDGenerator
+	store       Storer
+}
+
+func NewLedger(config Config, idGenerator id_generator.IDGenerator, store Storer) Ledger {
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &defaultLedger{
+		config:      config,
+		idGenerator: idGenerator,
+		store:       store,
+	}
+}
+
+func (l *defaultLedger) Post(description string, entries ...Entry) (Transaction, error) {
+	return l.post(Transaction{Description: description, Entries: entries})
+}
+
+func (l *defaultLedger) Reverse(id id_generator.EventID, description string) (Transaction, error) {
+	original, err := l.store.GetTransaction(id)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if original.Reverses != 0 {
+		return Transaction{}, &IrreversibleTransactionError{id: id, reverses: original.Reverses}
+	}
+	entries := make([]Entry, len(original.Entries))
+	for i, entry := range original.Entries {
+		entries[i] = entry
+		entries[i].Side = SideDebit
+		if entry.Side == SideDebit {
+			entries[i].Side = SideCredit
+		}
+	}
+	return l.post(Transaction{Description: description, Entries: entries, Reverses: id})
+}
+
+func (l *defaultLedger) GetTransaction(id id_generator.EventID) (Transaction, error) {
+	return l.store.GetTransaction(id)
+}
+
+func (l *defaultLedger) Balance(account Account, code currency.Code) (currency.Amount, error) {
+	transactions, err := l.History(account)
+	if err != nil {
+		return currency.Amount{}, err
+	}
+	balance := currency.FromMinorUnits(0, code)
+	for _, transaction := range transactions {
+		for _, entry := range transaction.Entries {
+			if entry.Account != account || entry.Amount.Code() != code {
+				continue
+			}
+			if entry.Side == SideDebit {
+				balance, err = balance.Add(entry.Amount)
+			} else {
+				balance, err = balance.Sub(entry.Amount)
+			}
+			if err != nil {
+				return currency.Amount{}, err
+			}
+		}
+	}
+	return balance, nil
+}
+
+func (l *defaultLedger) History(account Account) ([]Transaction, error) {
+	transactions, err := l.store.GetTransactions(account)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to fetch transactions"), err)
+	}
+	return transactions, nil
+}
+
+// post checks that the transaction balances, then gives it an ID and saves it
+func (l *defaultLedger) post(transaction Transaction) (Transaction, error) {
+	err := validate(transaction.Entries)
+	if err != nil {
+		return Transaction{}, err
+	}
+	transaction.ID = l.idGenerator.Next()
+	transaction.PostedAt = l.config.Now()
+	err = l.store.SaveTransaction(transaction)
+	if err != nil {
+		return Transaction{}, errors.Join(errors.New("failed to save transaction"), err)
+	}
+	return transaction, nil
+}
+
+// totals are the debits and credits of a transaction in a single currency
+type totals struct {
+	debits  currency.Amount
+	credits currency.Amount
+}
+
+// validate checks that every entry is a positive amount on one side of an account, and that the debits equal the
+// credits in each currency
+func validate(entries []Entry) error {
+	if len(entries) < 2 {
+		return &InvalidTransactionError{message: "a transaction must have at least two entries"}
+	}
+	byCurrency := map[currency.Code]*totals{}
+	for _, entry := range entries {
+		if entry.Account == "" {
+			return &InvalidTransactionError{message: "an entry must have an account"}
+		}
+		if entry.Amount.Sign() <= 0 {
+			return &InvalidTransactionError{message: "the amount of an entry must be greater than zero"}
+		}
+		code := entry.Amount.Code()
+		t, ok := byCurrency[code]
+		if !ok {
+			t = &totals{debits: currency.FromMinorUnits(0, code), credits: currency.FromMinorUnits(0, code)}
+			byCurrency[code] = t
+		}
+		var err error
+		switch entry.Side {
+		case SideDebit:
+			t.debits, err = t.debits.Add(entry.Amount)
+		case SideCredit:
+			t.credits, err = t.credits.Add(entry.Amount)
+		default:
+			return &InvalidTransactionError{message: "unknown side " + string(entry.Side)}
+		}
+		if err != nil {
+			return errors.Join(&InvalidTransactionError{message: "the entries of a transaction are too large"}, err)
+		}
+	}
+
+	codes := make([]currency.Code, 0, len(byCurrency))
+	for code := range byCurrency {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i] < codes[j]
+	})
+	for _, code := range codes {
+		t := byCurrency[code]
+		if !t.debits.Equals(t.credits) {
+			return &UnbalancedTransactionError{code: code, debits: t.debits, credits: t.credits}
+		}
+	}
+	return nil
+}
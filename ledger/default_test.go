@@ -0,0 +1,183 @@
+package ledger
+
+import (
+	"auction/currency"
+	"auction/id_generator"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func usd(major, minor int64) currency.Amount {
+	return currency.MustNew(major, minor, currency.USD)
+}
+
+func newLedger() Ledger {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	return NewLedger(Config{Now: func() time.Time { return now }}, id_generator.NewMemoryIDGenerator(), NewMemoryStorage())
+}
+
+func TestPost(t *testing.T) {
+	ledger := newLedger()
+	transaction, err := ledger.Post("invoice", Debit("buyer:Pat", usd(106, 25)), Credit("seller:Riley", usd(85, 0)), Credit("house:buyers_premium", usd(21, 25)))
+	if err != nil {
+		t.Fatalf("Failed to post transaction: %s", err.Error())
+	}
+	if transaction.ID != 1 || transaction.Description != "invoice" || len(transaction.Entries) != 3 {
+		t.Fatalf("Expected the first transaction to be the invoice, got %#v", transaction)
+	}
+	saved, err := ledger.GetTransaction(transaction.ID)
+	if err != nil {
+		t.Fatalf("Failed to get transaction: %s", err.Error())
+	}
+	if !reflect.DeepEqual(transaction, saved) {
+		t.Fatalf("Expected %#v, got %#v", transaction, saved)
+	}
+}
+
+func TestPost_Invalid(t *testing.T) {
+	type testCase struct {
+		name    string
+		entries []Entry
+		err     error
+	}
+	testCases := []testCase{
+		{name: "Single Entry", entries: []Entry{Debit("buyer:Pat", usd(10, 0))}, err: &InvalidTransactionError{}},
+		{name: "No Account", entries: []Entry{Debit("", usd(10, 0)), Credit("house:cash", usd(10, 0))}, err: &InvalidTransactionError{}},
+		{name: "Zero Amount", entries: []Entry{Debit("buyer:Pat", usd(0, 0)), Credit("house:cash", usd(0, 0))}, err: &InvalidTransactionError{}},
+		{name: "Negative Amount", entries: []Entry{Debit("buyer:Pat", usd(-10, 0)), Credit("house:cash", usd(-10, 0))}, err: &InvalidTransactionError{}},
+		{name: "Unknown Side", entries: []Entry{{Account: "buyer:Pat", Side: "both", Amount: usd(10, 0)}, Credit("house:cash", usd(10, 0))}, err: &InvalidTransactionError{}},
+		{name: "Unbalanced", entries: []Entry{Debit("buyer:Pat", usd(10, 0)), Credit("house:cash", usd(9, 99))}, err: &UnbalancedTransactionError{}},
+		{
+			name:    "Balanced Across Currencies",
+			entries: []Entry{Debit("buyer:Pat", usd(10, 0)), Credit("house:cash", currency.MustNew(10, 0, currency.EUR))},
+			err:     &UnbalancedTransactionError{},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ledger := newLedger()
+			_, err := ledger.Post("invalid", test.entries...)
+			if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+				t.Fatalf("Expected %T but got %#v", test.err, err)
+			}
+			history, err := ledger.History("buyer:Pat")
+			if err != nil {
+				t.Fatalf("Failed to get history: %s", err.Error())
+			}
+			if len(history) != 0 {
+				t.Fatalf("Expected nothing to be posted, got %#v", history)
+			}
+		})
+	}
+}
+
+func TestBalance(t *testing.T) {
+	ledger := newLedger()
+	eur := currency.MustNew(20, 0, currency.EUR)
+	postings := [][]Entry{
+		{Debit("buyer:Pat", usd(100, 0)), Credit("seller:Riley", usd(100, 0))},
+		{Debit("house:cash", usd(60, 0)), Credit("buyer:Pat", usd(60, 0))},
+		{Debit("buyer:Pat", eur), Credit("seller:Riley", eur)},
+		{Debit("buyer:John", usd(5, 0)), Credit("seller:Riley", usd(5, 0))},
+	}
+	for _, entries := range postings {
+		_, err := ledger.Post("transfer", entries...)
+		if err != nil {
+			t.Fatalf("Failed to post transaction: %s", err.Error())
+		}
+	}
+
+	type testCase struct {
+		account  Account
+		expected currency.Amount
+	}
+	testCases := []testCase{
+		{account: "buyer:Pat", expected: usd(40, 0)},
+		{account: "seller:Riley", expected: usd(-105, 0)},
+		{account: "house:cash", expected: usd(60, 0)},
+		{account: "buyer:Sasha", expected: usd(0, 0)},
+	}
+	for _, test := range testCases {
+		t.Run(string(test.account), func(t *testing.T) {
+			balance, err := ledger.Balance(test.account, currency.USD)
+			if err != nil {
+				t.Fatalf("Failed to get balance: %s", err.Error())
+			}
+			if balance != test.expected {
+				t.Fatalf("Expected a balance of %s, got %s", test.expected, balance)
+			}
+		})
+	}
+
+	balance, err := ledger.Balance("buyer:Pat", currency.EUR)
+	if err != nil || balance != eur {
+		t.Fatalf("Expected a balance of %s, got %s and %#v", eur, balance, err)
+	}
+	history, err := ledger.History("buyer:Pat")
+	if err != nil {
+		t.Fatalf("Failed to get history: %s", err.Error())
+	}
+	if len(history) != 3 || history[0].ID != 1 || history[1].ID != 2 || history[2].ID != 3 {
+		t.Fatalf("Expected the first three transactions in order, got %#v", history)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	ledger := newLedger()
+	original, err := ledger.Post("invoice", Debit("buyer:Pat", usd(100, 0)), Credit("seller:Riley", usd(100, 0)))
+	if err != nil {
+		t.Fatalf("Failed to post transaction: %s", err.Error())
+	}
+	reversal, err := ledger.Reverse(original.ID, "default")
+	if err != nil {
+		t.Fatalf("Failed to reverse transaction: %s", err.Error())
+	}
+	expected := []Entry{Credit("buyer:Pat", usd(100, 0)), Debit("seller:Riley", usd(100, 0))}
+	if reversal.Reverses != original.ID || !reflect.DeepEqual(expected, reversal.Entries) {
+		t.Fatalf("Expected a reversal of transaction %d, got %#v", original.ID, reversal)
+	}
+	for _, account := range []Account{"buyer:Pat", "seller:Riley"} {
+		balance, err := ledger.Balance(account, currency.USD)
+		if err != nil || !balance.IsZero() {
+			t.Fatalf("Expected %s to balance to zero, got %s and %#v", account, balance, err)
+		}
+	}
+
+	_, err = ledger.Reverse(100, "unknown")
+	if _, ok := err.(*TransactionNotFoundError); !ok {
+		t.Fatalf("Expected TransactionNotFoundError but got %#v", err)
+	}
+}
+
+func TestReverse_Twice(t *testing.T) {
+	ledger := newLedger()
+	original, err := ledger.Post("invoice", Debit("buyer:Pat", usd(100, 0)), Credit("seller:Riley", usd(100, 0)))
+	if err != nil {
+		t.Fatalf("Failed to post transaction: %s", err.Error())
+	}
+	reversal, err := ledger.Reverse(original.ID, "default")
+	if err != nil {
+		t.Fatalf("Failed to reverse transaction: %s", err.Error())
+	}
+
+	_, err = ledger.Reverse(original.ID, "default again")
+	var reversed *TransactionReversedError
+	if !errors.As(err, &reversed) {
+		t.Fatalf("Expected TransactionReversedError but got %#v", err)
+	}
+	_, err = ledger.Reverse(reversal.ID, "undo default")
+	if _, ok := err.(*IrreversibleTransactionError); !ok {
+		t.Fatalf("Expected IrreversibleTransactionError but got %#v", err)
+	}
+
+	// neither attempt posted anything, so Pat still owes nothing
+	history, err := ledger.History("buyer:Pat")
+	if err != nil {
+		t.Fatalf("Failed to get history: %s", err.Error())
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected only the invoice and its reversal, got %#v", history)
+	}
+}
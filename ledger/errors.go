@@ -0,0 +1,59 @@
+package ledger
+
+import (
+	"auction/currency"
+	"auction/id_generator"
+	"fmt"
+)
+
+type InvalidTransactionError struct {
+	message string
+}
+
+func (e *InvalidTransactionError) Error() string {
+	return e.message
+}
+
+type UnbalancedTransactionError struct {
+	code    currency.Code
+	debits  currency.Amount
+	credits currency.Amount
+}
+
+func (e *UnbalancedTransactionError) Error() string {
+	return fmt.Sprintf("the debits of %s do not equal the credits of %s in %s", e.debits, e.credits, e.code)
+}
+
+type DuplicateTransactionError struct {
+	id id_generator.EventID
+}
+
+func (e *DuplicateTransactionError) Error() string {
+	return fmt.Sprintf("transaction %d has already been posted", e.id)
+}
+
+type TransactionNotFoundError struct {
+	id id_generator.EventID
+}
+
+func (e *TransactionNotFoundError) Error() string {
+	return fmt.Sprintf("transaction %d not found", e.id)
+}
+
+type TransactionReversedError struct {
+	id       id_generator.EventID
+	reversal id_generator.EventID
+}
+
+func (e *TransactionReversedError) Error() string {
+	return fmt.Sprintf("transaction %d has already been reversed by transaction %d", e.id, e.reversal)
+}
+
+type IrreversibleTransactionError struct {
+	id       id_generator.EventID
+	reverses id_generator.EventID
+}
+
+func (e *IrreversibleTransactionError) Error() string {
+	return fmt.Sprintf("transaction %d reverses transaction %d and cannot itself be reversed", e.id, e.reverses)
+}
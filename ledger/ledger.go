@@ -0,0 +1,65 @@
+package ledger
+
+import (
+	"auction/currency"
+	"auction/id_generator"
+	"time"
+)
+
+// Account names where money is held, such as "buyer:Pat" or "house:cash"
+type Account string
+
+type Side string
+
+const (
+	SideDebit  Side = "debit"
+	SideCredit Side = "credit"
+)
+
+// Entry moves an amount into or out of an account. Amounts are always positive, and the side says which way they move.
+type Entry struct {
+	Account Account         `json:"account"`
+	Side    Side            `json:"side"`
+	Amount  currency.Amount `json:"amount"`
+}
+
+// Debit is an entry on the debit side of the account
+func Debit(account Account, amount currency.Amount) Entry {
+	return Entry{Account: account, Side: SideDebit, Amount: amount}
+}
+
+// Credit is an entry on the credit side of the account
+func Credit(account Account, amount currency.Amount) Entry {
+	return Entry{Account: account, Side: SideCredit, Amount: amount}
+}
+
+// Transaction is a set of entries posted together, whose debits equal its credits in every currency. Reverses is the
+// ID of the transaction it undoes, and is zero for a transaction that is not a reversal.
+type Transaction struct {
+	ID          id_generator.EventID `json:"id"`
+	Description string               `json:"description"`
+	Entries     []Entry              `json:"entries"`
+	Reverses    id_generator.EventID `json:"reverses,omitempty"`
+	PostedAt    time.Time            `json:"posted_at"`
+}
+
+// Ledger records every movement of money as a balanced double-entry transaction. Posted transactions are never changed,
+// so a mistake is corrected by reversing the transaction.
+type Ledger interface {
+	// Post records a transaction, returning an UnbalancedTransactionError if its debits and credits differ in any
+	// currency
+	Post(description string, entries ...Entry) (Transaction, error)
+	// Reverse posts a transaction with the entries of the given one on the opposite sides. A transaction can only be
+	// reversed once, and a reversal cannot be reversed, since that would post the original transaction a second time.
+	Reverse(id id_generator.EventID, description string) (Transaction, error)
+	GetTransaction(id id_generator.EventID) (Transaction, error)
+	// Balance returns the debits less the credits of the account in the currency
+	Balance(account Account, code currency.Code) (currency.Amount, error)
+	// History returns every transaction with an entry for the account, ordered by ID
+	History(account Account) ([]Transaction, error)
+}
+
+// Config configures a ledger. Now is the clock used to timestamp transactions, and is time.Now if it is not set.
+type Config struct {
+	Now func() time.Time
+}
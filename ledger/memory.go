@@ -0,0 +1,66 @@
+package ledger
+
+import (
+	"auction/id_generator"
+	"sort"
+	"sync"
+)
+
+type memoryStorage struct {
+	transactions map[id_generator.EventID]Transaction
+	// reversals maps the ID of each reversed transaction to the ID of its reversal
+	reversals map[id_generator.EventID]id_generator.EventID
+	mtx       *sync.Mutex
+}
+
+func NewMemoryStorage() Storer {
+	return &memoryStorage{
+		transactions: map[id_generator.EventID]Transaction{},
+		reversals:    map[id_generator.EventID]id_generator.EventID{},
+		mtx:          &sync.Mutex{},
+	}
+}
+
+func (m memoryStorage) SaveTransaction(transaction Transaction) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.transactions[transaction.ID]; ok {
+		return &DuplicateTransactionError{id: transaction.ID}
+	}
+	if transaction.Reverses != 0 {
+		if reversal, ok := m.reversals[transaction.Reverses]; ok {
+			return &TransactionReversedError{id: transaction.Reverses, reversal: reversal}
+		}
+		m.reversals[transaction.Reverses] = transaction.ID
+	}
+	m.transactions[transaction.ID] = transaction
+	return nil
+}
+
+func (m memoryStorage) GetTransaction(id id_generator.EventID) (Transaction, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	transaction, ok := m.transactions[id]
+	if !ok {
+		return Transaction{}, &TransactionNotFoundError{id: id}
+	}
+	return transaction, nil
+}
+
+func (m memoryStorage) GetTransactions(account Account) ([]Transaction, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	transactions := []Transaction{}
+	for _, transaction := range m.transactions {
+		for _, entry := range transaction.Entries {
+			if entry.Account == account {
+				transactions = append(transactions, transaction)
+				break
+			}
+		}
+	}
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].ID < transactions[j].ID
+	})
+	return transactions, nil
+}
@@ -0,0 +1,14 @@
+package ledger
+
+import "auction/id_generator"
+
+// Storer stores the transactions of a ledger
+type Storer interface {
+	// SaveTransaction stores a transaction, returning a DuplicateTransactionError if its ID has been stored before. A
+	// reversal of a transaction that has already been reversed is rejected with a TransactionReversedError, so that
+	// two reversals saved at the same time cannot both succeed.
+	SaveTransaction(transaction Transaction) error
+	GetTransaction(id id_generator.EventID) (Transaction, error)
+	// GetTransactions returns the transactions with an entry for the account ordered by ID
+	GetTransactions(account Account) ([]Transaction, error)
+}
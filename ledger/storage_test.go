@@ -0,0 +1,110 @@
+package ledger
+
+import (
+	"auction/currency"
+	"auction/id_generator"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type storageTests struct {
+	storeFn func() Storer
+	t       *testing.T
+}
+
+func (g *storageTests) Run() {
+	tests := map[string]func(t *testing.T, store Storer){
+		"Test Transactions":          testTransactions,
+		"Test Transaction Not Found": testTransactionNotFound,
+		"Test Reversals":             testReversals,
+	}
+	for name, test := range tests {
+		g.t.Run(name, func(t *testing.T) {
+			test(t, g.storeFn())
+		})
+	}
+}
+
+func mockTransaction(id int, debit, credit Account) Transaction {
+	amount := currency.MustNew(10, 0, currency.USD)
+	return Transaction{
+		ID:          id_generator.EventID(id),
+		Description: "transfer",
+		Entries:     []Entry{Debit(debit, amount), Credit(credit, amount)},
+		PostedAt:    time.Unix(100, 0),
+	}
+}
+
+func testTransactions(t *testing.T, store Storer) {
+	transactions := []Transaction{
+		mockTransaction(3, "house:cash", "buyer:Pat"),
+		mockTransaction(1, "buyer:Pat", "seller:Riley"),
+		mockTransaction(2, "buyer:John", "seller:Riley"),
+	}
+	for _, transaction := range transactions {
+		err := store.SaveTransaction(transaction)
+		if err != nil {
+			t.Fatalf("Failed to save transaction: %s", err.Error())
+		}
+	}
+	err := store.SaveTransaction(transactions[0])
+	if _, ok := err.(*DuplicateTransactionError); !ok {
+		t.Fatalf("Expected DuplicateTransactionError but got %#v", err)
+	}
+
+	saved, err := store.GetTransaction(2)
+	if err != nil {
+		t.Fatalf("Failed to get transaction: %s", err.Error())
+	}
+	if !reflect.DeepEqual(transactions[2], saved) {
+		t.Fatalf("Transactions do not match. Expected:\n%#v\nGot:\n%#v", transactions[2], saved)
+	}
+
+	expected := []Transaction{transactions[1], transactions[0]}
+	history, err := store.GetTransactions("buyer:Pat")
+	if err != nil {
+		t.Fatalf("Failed to get transactions: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, history) {
+		t.Fatalf("Transactions do not match. Expected:\n%#v\nGot:\n%#v", expected, history)
+	}
+}
+
+func testTransactionNotFound(t *testing.T, store Storer) {
+	_, err := store.GetTransaction(1)
+	if _, ok := err.(*TransactionNotFoundError); !ok {
+		t.Fatalf("Expected TransactionNotFoundError but got %#v", err)
+	}
+	transactions, err := store.GetTransactions("buyer:Pat")
+	if err != nil {
+		t.Fatalf("Failed to get transactions: %s", err.Error())
+	}
+	if len(transactions) != 0 {
+		t.Fatalf("Expected no transactions, got %#v", transactions)
+	}
+}
+
+func testReversals(t *testing.T, store Storer) {
+	err := store.SaveTransaction(mockTransaction(1, "buyer:Pat", "seller:Riley"))
+	if err != nil {
+		t.Fatalf("Failed to save transaction: %s", err.Error())
+	}
+	reversal := mockTransaction(2, "seller:Riley", "buyer:Pat")
+	reversal.Reverses = 1
+	err = store.SaveTransaction(reversal)
+	if err != nil {
+		t.Fatalf("Failed to save reversal: %s", err.Error())
+	}
+
+	again := mockTransaction(3, "seller:Riley", "buyer:Pat")
+	again.Reverses = 1
+	err = store.SaveTransaction(again)
+	if _, ok := err.(*TransactionReversedError); !ok {
+		t.Fatalf("Expected TransactionReversedError but got %#v", err)
+	}
+	_, err = store.GetTransaction(3)
+	if _, ok := err.(*TransactionNotFoundError); !ok {
+		t.Fatalf("Expected the second reversal not to be saved, got %#v", err)
+	}
+}
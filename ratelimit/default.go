@@ -0,0 +1,181 @@
+package ratelimit
+
+import (
+	"auction/auction"
+	"math"
+	"sync"
+	"time"
+)
+
+// bucket holds the tokens left at the time it was last refilled
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// refill adds the tokens earned since the bucket was last refilled, up to the burst of the limit
+func (b *bucket) refill(limit Limit, now time.Time) {
+	elapsed := now.Sub(b.updated)
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+float64(elapsed)*float64(limit.Requests)/float64(limit.Interval))
+	b.updated = now
+}
+
+// wait returns how long until the bucket holds a whole token, which is zero if it already does
+func (b *bucket) wait(limit Limit) time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - b.tokens) * float64(limit.Interval) / float64(limit.Requests)))
+}
+
+// full reports whether the bucket holds its whole burst, and so is the same as the bucket of a key never seen
+func (b *bucket) full(limit Limit) bool {
+	return b.tokens >= float64(limit.Burst)
+}
+
+// buckets holds the bucket of each key that has made a request since its bucket was last full
+type buckets[K comparable] struct {
+	limit   Limit
+	entries map[K]*bucket
+	swept   time.Time
+}
+
+func newBuckets[K comparable](limit Limit, now time.Time) *buckets[K] {
+	return &buckets[K]{limit: limit, entries: map[K]*bucket{}, swept: now}
+}
+
+// get returns the refilled bucket of a key, creating a full one when the key has no bucket
+func (b *buckets[K]) get(key K, now time.Time) *bucket {
+	entry, ok := b.entries[key]
+	if !ok {
+		entry = &bucket{tokens: float64(b.limit.Burst), updated: now}
+		b.entries[key] = entry
+	}
+	entry.refill(b.limit, now)
+	return entry
+}
+
+// sweep removes the buckets that have refilled to their burst. It runs at most once in the time an empty bucket takes
+// to refill, so a key that has been idle that long is always removed and the cost of the sweep is spread over the
+// requests in between.
+func (b *buckets[K]) sweep(now time.Time) {
+	if now.Sub(b.swept) < b.limit.refillTime() {
+		return
+	}
+	for key, entry := range b.entries {
+		entry.refill(b.limit, now)
+		if entry.full(b.limit) {
+			delete(b.entries, key)
+		}
+	}
+	b.swept = now
+}
+
+type tokenBucketLimiter struct {
+	config   Config
+	bidders  *buckets[auction.Bidder]
+	auctions *buckets[auction.ID]
+	metrics  Metrics
+	mtx      *sync.Mutex
+}
+
+func NewLimiter(config Config) (Limiter, error) {
+	var err error
+	config.Bidder, err = withDefaults(config.Bidder, defaultBidderLimit)
+	if err != nil {
+		return nil, err
+	}
+	config.Auction, err = withDefaults(config.Auction, defaultAuctionLimit)
+	if err != nil {
+		return nil, err
+	}
+	if config.MetricKeys < 0 {
+		return nil, &InvalidLimitError{message: "the number of keys counted in the metrics cannot be negative"}
+	}
+	if config.MetricKeys == 0 {
+		config.MetricKeys = defaultMetricKeys
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	now := config.Now()
+	return &tokenBucketLimiter{
+		config:   config,
+		bidders:  newBuckets[auction.Bidder](config.Bidder, now),
+		auctions: newBuckets[auction.ID](config.Auction, now),
+		metrics: Metrics{
+			ThrottledBidders:  map[auction.Bidder]uint64{},
+			ThrottledAuctions: map[auction.ID]uint64{},
+		},
+		mtx: &sync.Mutex{},
+	}, nil
+}
+
+func (l *tokenBucketLimiter) Allow(id auction.ID, bidder auction.Bidder) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := l.config.Now()
+	l.bidders.sweep(now)
+	l.auctions.sweep(now)
+	bidderBucket := l.bidders.get(bidder, now)
+	auctionBucket := l.auctions.get(id, now)
+	bidderWait := bidderBucket.wait(l.config.Bidder)
+	auctionWait := auctionBucket.wait(l.config.Auction)
+	if bidderWait == 0 && auctionWait == 0 {
+		bidderBucket.tokens--
+		auctionBucket.tokens--
+		l.metrics.Allowed++
+		return nil
+	}
+
+	l.metrics.Throttled++
+	if bidderWait > 0 && !count(l.metrics.ThrottledBidders, bidder, l.config.MetricKeys) {
+		l.metrics.ThrottledOtherBidders++
+	}
+	if auctionWait > 0 && !count(l.metrics.ThrottledAuctions, id, l.config.MetricKeys) {
+		l.metrics.ThrottledOtherAuctions++
+	}
+	return &RateLimitedError{bidder: bidder, auction: id, retryAfter: max(bidderWait, auctionWait)}
+}
+
+func (l *tokenBucketLimiter) Metrics() Metrics {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	metrics := l.metrics
+	metrics.ThrottledBidders = map[auction.Bidder]uint64{}
+	metrics.ThrottledAuctions = map[auction.ID]uint64{}
+	for bidder, count := range l.metrics.ThrottledBidders {
+		metrics.ThrottledBidders[bidder] = count
+	}
+	for id, count := range l.metrics.ThrottledAuctions {
+		metrics.ThrottledAuctions[id] = count
+	}
+	return metrics
+}
+
+// count adds one to the count of a key, unless the counts already hold limit other keys. It reports whether the key
+// was counted.
+func count[K comparable](counts map[K]uint64, key K, limit int) bool {
+	if _, ok := counts[key]; !ok && len(counts) >= limit {
+		return false
+	}
+	counts[key]++
+	return true
+}
+
+func withDefaults(limit, defaults Limit) (Limit, error) {
+	if limit == (Limit{}) {
+		limit = defaults
+	}
+	if limit.Requests <= 0 || limit.Interval <= 0 || limit.Burst < 0 {
+		return Limit{}, &InvalidLimitError{message: "a limit must allow at least one request over a positive interval"}
+	}
+	if limit.Burst == 0 {
+		limit.Burst = limit.Requests
+	}
+	return limit, nil
+}
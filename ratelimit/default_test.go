@@ -0,0 +1,194 @@
+package ratelimit
+
+import (
+	"auction/auction"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// clock is a settable clock for the limiter
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newLimiter(t *testing.T) (Limiter, *clock) {
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	limiter, err := NewLimiter(Config{
+		Bidder:  Limit{Requests: 2, Interval: time.Second},
+		Auction: Limit{Requests: 3, Interval: time.Second, Burst: 4},
+		Now:     c.Now,
+	})
+	if err != nil {
+		t.Fatalf("could not initialize limiter: %s", err.Error())
+	}
+	return limiter, c
+}
+
+func TestAllow(t *testing.T) {
+	limiter, c := newLimiter(t)
+
+	type testCase struct {
+		name       string
+		auction    auction.ID
+		bidder     auction.Bidder
+		advance    time.Duration
+		retryAfter time.Duration
+	}
+	testCases := []testCase{
+		{name: "First Request", auction: "a-1", bidder: "Sasha"},
+		{name: "Within Burst", auction: "a-1", bidder: "Sasha"},
+		{name: "Bidder Throttled", auction: "a-1", bidder: "Sasha", retryAfter: 500 * time.Millisecond},
+		{name: "Bidder Throttled In Other Auction", auction: "a-2", bidder: "Sasha", retryAfter: 500 * time.Millisecond},
+		{name: "Other Bidder", auction: "a-1", bidder: "John"},
+		{name: "Last Auction Token", auction: "a-1", bidder: "Pat"},
+		{name: "Auction Throttled", auction: "a-1", bidder: "Riley", retryAfter: 333 * time.Millisecond},
+		{name: "Auction Refilled", auction: "a-1", bidder: "Riley", advance: 334 * time.Millisecond},
+		{name: "Both Throttled", auction: "a-1", bidder: "Sasha", advance: 100 * time.Millisecond, retryAfter: 233 * time.Millisecond},
+		{name: "Bidder Refilled", auction: "a-2", bidder: "Sasha", advance: 100 * time.Millisecond},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c.advance(test.advance)
+			err := limiter.Allow(test.auction, test.bidder)
+			if test.retryAfter == 0 {
+				if err != nil {
+					t.Fatalf("Expected the request to be allowed, got %s", err.Error())
+				}
+				return
+			}
+			limited, ok := err.(*RateLimitedError)
+			if !ok {
+				t.Fatalf("Expected RateLimitedError but got %#v", err)
+			}
+			if limited.RetryAfter().Round(time.Millisecond) != test.retryAfter {
+				t.Fatalf("Expected to retry after %s, got %s", test.retryAfter, limited.RetryAfter())
+			}
+		})
+	}
+
+	expected := Metrics{
+		Allowed:           6,
+		Throttled:         4,
+		ThrottledBidders:  map[auction.Bidder]uint64{"Sasha": 3},
+		ThrottledAuctions: map[auction.ID]uint64{"a-1": 2},
+	}
+	if metrics := limiter.Metrics(); !reflect.DeepEqual(expected, metrics) {
+		t.Fatalf("Expected %#v, got %#v", expected, metrics)
+	}
+}
+
+func TestInvalidLimit(t *testing.T) {
+	limits := []Limit{
+		{Requests: 0, Interval: time.Second},
+		{Requests: 1},
+		{Requests: 1, Interval: time.Second, Burst: -1},
+	}
+	for _, limit := range limits {
+		_, err := NewLimiter(Config{Bidder: limit})
+		if _, ok := err.(*InvalidLimitError); !ok {
+			t.Fatalf("Expected InvalidLimitError for %#v but got %#v", limit, err)
+		}
+	}
+}
+
+func TestDefaultLimits(t *testing.T) {
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	limiter, err := NewLimiter(Config{Now: c.Now})
+	if err != nil {
+		t.Fatalf("could not initialize limiter: %s", err.Error())
+	}
+	for i := 0; i < 5; i++ {
+		err = limiter.Allow("a-1", "Sasha")
+		if err != nil {
+			t.Fatalf("Expected request %d to be allowed, got %s", i+1, err.Error())
+		}
+	}
+	err = limiter.Allow("a-1", "Sasha")
+	if limited, ok := err.(*RateLimitedError); !ok || limited.RetryAfter() != 200*time.Millisecond {
+		t.Fatalf("Expected RateLimitedError with a retry after of 200ms but got %#v", err)
+	}
+}
+
+func TestEviction(t *testing.T) {
+	limiter, c := newLimiter(t)
+	for _, bidder := range []auction.Bidder{"Sasha", "John", "Pat"} {
+		err := limiter.Allow(auction.ID("a-"+bidder), bidder)
+		if err != nil {
+			t.Fatalf("Expected the request to be allowed, got %s", err.Error())
+		}
+	}
+	buckets := limiter.(*tokenBucketLimiter)
+	if len(buckets.bidders.entries) != 3 || len(buckets.auctions.entries) != 3 {
+		t.Fatalf("Expected a bucket for each bidder and auction, got %#v and %#v", buckets.bidders.entries, buckets.auctions.entries)
+	}
+
+	// every bucket has refilled by the time of the next request, so only the buckets of that request are left
+	c.advance(1500 * time.Millisecond)
+	err := limiter.Allow("a-1", "Sasha")
+	if err != nil {
+		t.Fatalf("Expected the request to be allowed, got %s", err.Error())
+	}
+	if _, ok := buckets.bidders.entries["Sasha"]; !ok || len(buckets.bidders.entries) != 1 {
+		t.Fatalf("Expected only Sasha to have a bidder bucket, got %#v", buckets.bidders.entries)
+	}
+	if _, ok := buckets.auctions.entries["a-1"]; !ok || len(buckets.auctions.entries) != 1 {
+		t.Fatalf("Expected only a-1 to have an auction bucket, got %#v", buckets.auctions.entries)
+	}
+
+	// the bucket created after the sweep refills in the same way as the one it replaced
+	c.advance(time.Second)
+	for i := 0; i < 2; i++ {
+		err = limiter.Allow("a-1", "Sasha")
+		if err != nil {
+			t.Fatalf("Expected request %d to be allowed, got %s", i+1, err.Error())
+		}
+	}
+	err = limiter.Allow("a-1", "Sasha")
+	if _, ok := err.(*RateLimitedError); !ok {
+		t.Fatalf("Expected RateLimitedError but got %#v", err)
+	}
+}
+
+func TestMetricKeys(t *testing.T) {
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	limiter, err := NewLimiter(Config{
+		Bidder:     Limit{Requests: 1, Interval: time.Minute},
+		Auction:    Limit{Requests: 1, Interval: time.Minute},
+		MetricKeys: 2,
+		Now:        c.Now,
+	})
+	if err != nil {
+		t.Fatalf("could not initialize limiter: %s", err.Error())
+	}
+	for _, bidder := range []auction.Bidder{"Sasha", "John", "Pat", "Riley"} {
+		id := auction.ID("a-" + bidder)
+		_ = limiter.Allow(id, bidder)
+		_ = limiter.Allow(id, bidder)
+		_ = limiter.Allow(id, bidder)
+	}
+	expected := Metrics{
+		Allowed:                4,
+		Throttled:              8,
+		ThrottledBidders:       map[auction.Bidder]uint64{"Sasha": 2, "John": 2},
+		ThrottledAuctions:      map[auction.ID]uint64{"a-Sasha": 2, "a-John": 2},
+		ThrottledOtherBidders:  4,
+		ThrottledOtherAuctions: 4,
+	}
+	if metrics := limiter.Metrics(); !reflect.DeepEqual(expected, metrics) {
+		t.Fatalf("Expected %#v, got %#v", expected, metrics)
+	}
+
+	_, err = NewLimiter(Config{MetricKeys: -1})
+	if _, ok := err.(*InvalidLimitError); !ok {
+		t.Fatalf("Expected InvalidLimitError but got %#v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package ratelimit
+
+import (
+	"auction/auction"
+	"fmt"
+	"time"
+)
+
+type InvalidLimitError struct {
+	message string
+}
+
+func (e *InvalidLimitError) Error() string {
+	return e.message
+}
+
+// RateLimitedError reports that a bidder made too many requests, and how long they must wait before the next request
+// can be allowed
+type RateLimitedError struct {
+	bidder     auction.Bidder
+	auction    auction.ID
+	retryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("too many requests from bidder %s in auction %s, retry after %s", e.bidder, e.auction, e.retryAfter)
+}
+
+// RetryAfter returns how long to wait before the request is allowed
+func (e *RateLimitedError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
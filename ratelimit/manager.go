@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"auction/auction"
+	"auction/bid_manager"
+	"auction/currency"
+)
+
+// limitedManager takes a token from the limiter before each operation that places, changes or removes a bid. The
+// other operations are made by the auctioneer or the winner and are passed on to the manager as they are.
+type limitedManager[M currency.Money[M]] struct {
+	bid_manager.BidManagerOf[M]
+	limiter Limiter
+	id      auction.ID
+}
+
+func NewBidManager(limiter Limiter, id auction.ID, manager bid_manager.BidManager) bid_manager.BidManager {
+	return NewBidManagerOf[currency.Amount](limiter, id, manager)
+}
+
+// NewBidManagerOf limits the requests to a manager of the auction with the given ID whose amounts are represented by M
+func NewBidManagerOf[M currency.Money[M]](limiter Limiter, id auction.ID, manager bid_manager.BidManagerOf[M]) bid_manager.BidManagerOf[M] {
+	return &limitedManager[M]{
+		BidManagerOf: manager,
+		limiter:      limiter,
+		id:           id,
+	}
+}
+
+func (l *limitedManager[M]) AddBid(bidder, startingBid, maxBid, incrementAmount string) error {
+	err := l.limiter.Allow(l.id, auction.Bidder(bidder))
+	if err != nil {
+		return err
+	}
+	return l.BidManagerOf.AddBid(bidder, startingBid, maxBid, incrementAmount)
+}
+
+func (l *limitedManager[M]) AmendBid(bidder, maxBid, incrementAmount string) error {
+	err := l.limiter.Allow(l.id, auction.Bidder(bidder))
+	if err != nil {
+		return err
+	}
+	return l.BidManagerOf.AmendBid(bidder, maxBid, incrementAmount)
+}
+
+func (l *limitedManager[M]) RetractBid(bidder string) error {
+	err := l.limiter.Allow(l.id, auction.Bidder(bidder))
+	if err != nil {
+		return err
+	}
+	return l.BidManagerOf.RetractBid(bidder)
+}
+
+func (l *limitedManager[M]) BuyItNow(bidder string) (auction.WinningBidOf[M], error) {
+	err := l.limiter.Allow(l.id, auction.Bidder(bidder))
+	if err != nil {
+		return auction.WinningBidOf[M]{}, err
+	}
+	return l.BidManagerOf.BuyItNow(bidder)
+}
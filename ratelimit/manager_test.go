@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"auction/bid_manager"
+	"auction/id_generator"
+	"auction/storage"
+	"testing"
+	"time"
+)
+
+func TestLimitedManager(t *testing.T) {
+	manager, err := bid_manager.NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage())
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	c := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	limiter, err := NewLimiter(Config{Bidder: Limit{Requests: 2, Interval: time.Minute}, Now: c.Now})
+	if err != nil {
+		t.Fatalf("could not initialize limiter: %s", err.Error())
+	}
+	limited := NewBidManager(limiter, "a-1", manager)
+
+	err = limited.AddBid("Sasha", "$50.00", "$80.00", "$3.00")
+	if err != nil {
+		t.Fatalf("Failed to add bid: %s", err.Error())
+	}
+	err = limited.AmendBid("Sasha", "$90.00", "$3.00")
+	if err != nil {
+		t.Fatalf("Failed to amend bid: %s", err.Error())
+	}
+	err = limited.RetractBid("Sasha")
+	if _, ok := err.(*RateLimitedError); !ok {
+		t.Fatalf("Expected RateLimitedError but got %#v", err)
+	}
+
+	// a throttled request never reaches the manager, and the operations of the auctioneer are not limited
+	winner, err := limited.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+	if winner.Bidder != "Sasha" {
+		t.Fatalf("Expected Sasha to still be winning, got %#v", winner)
+	}
+
+	c.advance(30 * time.Second)
+	err = limited.RetractBid("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to retract bid: %s", err.Error())
+	}
+	if metrics := limiter.Metrics(); metrics.Allowed != 3 || metrics.Throttled != 1 {
+		t.Fatalf("Expected 3 allowed and 1 throttled request, got %#v", metrics)
+	}
+}
@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"auction/auction"
+	"time"
+)
+
+var (
+	defaultBidderLimit  = Limit{Requests: 5, Interval: time.Second}
+	defaultAuctionLimit = Limit{Requests: 100, Interval: time.Second}
+	defaultMetricKeys   = 1000
+)
+
+// Limit is a token bucket that is refilled with Requests tokens evenly over each Interval. Burst is how many tokens
+// the bucket holds, and is Requests if it is not set.
+type Limit struct {
+	Requests int
+	Interval time.Duration
+	Burst    int
+}
+
+// refillTime is how long an empty bucket takes to refill to its burst
+func (l Limit) refillTime() time.Duration {
+	return time.Duration(float64(l.Burst) * float64(l.Interval) / float64(l.Requests))
+}
+
+// Config configures the limits. A zero Bidder limit allows each bidder 5 requests a second, and a zero Auction limit
+// allows each auction 100 requests a second. MetricKeys is how many bidders and how many auctions the metrics count
+// throttled requests of by name, and is 1000 if it is not set. Now is time.Now if it is not set.
+type Config struct {
+	Bidder     Limit
+	Auction    Limit
+	MetricKeys int
+	Now        func() time.Time
+}
+
+// Metrics counts the requests that a limiter has allowed and throttled. A throttled request is counted against every
+// bucket that was empty, so it can be counted against both the bidder and the auction. Once the metrics count as many
+// bidders or auctions by name as Config.MetricKeys, the requests of any other are counted in ThrottledOtherBidders or
+// ThrottledOtherAuctions.
+type Metrics struct {
+	Allowed                uint64                    `json:"allowed"`
+	Throttled              uint64                    `json:"throttled"`
+	ThrottledBidders       map[auction.Bidder]uint64 `json:"throttled_bidders"`
+	ThrottledAuctions      map[auction.ID]uint64     `json:"throttled_auctions"`
+	ThrottledOtherBidders  uint64                    `json:"throttled_other_bidders"`
+	ThrottledOtherAuctions uint64                    `json:"throttled_other_auctions"`
+}
+
+// Limiter keeps a token bucket for each bidder and each auction. A request takes a token from both buckets, so a
+// bidder is limited across every auction and an auction is limited across every bidder. A bucket that has refilled is
+// removed, so only the bidders and auctions that made requests recently take up memory.
+type Limiter interface {
+	// Allow takes a token for the bidder and the auction, or returns a RateLimitedError without taking either if one
+	// of the buckets is empty
+	Allow(id auction.ID, bidder auction.Bidder) error
+	// Metrics returns a copy of the counts of allowed and throttled requests
+	Metrics() Metrics
+}
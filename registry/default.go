@@ -0,0 +1,189 @@
+package registry
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultRegistry keeps its profiles and exposures in a Storer. The mutex serializes reservations so that two max bids
+// placed at the same time cannot both fit under the credit limit when only one of them does.
+type defaultRegistry struct {
+	config Config
+	store  Storer
+	mtx    *sync.Mutex
+}
+
+func NewRegistry(config Config, store Storer) Registry {
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &defaultRegistry{
+		config: config,
+		store:  store,
+		mtx:    &sync.Mutex{},
+	}
+}
+
+func (r *defaultRegistry) Register(id auction.Bidder, displayName string, creditLimit currency.Amount) (Profile, error) {
+	if id == "" {
+		return Profile{}, &InvalidProfileError{message: "a bidder must have an ID"}
+	}
+	if creditLimit.Sign() < 0 {
+		return Profile{}, &InvalidProfileError{message: "a credit limit cannot be negative"}
+	}
+	profile := Profile{
+		ID:           id,
+		DisplayName:  displayName,
+		Status:       StatusUnverified,
+		CreditLimit:  creditLimit,
+		RegisteredAt: r.config.Now(),
+	}
+	err := r.store.SaveProfile(profile)
+	if err != nil {
+		return Profile{}, err
+	}
+	return profile, nil
+}
+
+func (r *defaultRegistry) GetProfile(id auction.Bidder) (Profile, error) {
+	return r.store.GetProfile(id)
+}
+
+func (r *defaultRegistry) SetStatus(id auction.Bidder, status Status) (Profile, error) {
+	switch status {
+	case StatusUnverified, StatusVerified, StatusSuspended:
+	default:
+		return Profile{}, &InvalidProfileError{message: "unknown status " + string(status)}
+	}
+	return r.update(id, func(profile *Profile) {
+		profile.Status = status
+	})
+}
+
+func (r *defaultRegistry) SetCreditLimit(id auction.Bidder, limit currency.Amount) (Profile, error) {
+	if limit.Sign() < 0 {
+		return Profile{}, &InvalidProfileError{message: "a credit limit cannot be negative"}
+	}
+	return r.update(id, func(profile *Profile) {
+		profile.CreditLimit = limit
+	})
+}
+
+// update applies the change to the profile of a registered bidder
+func (r *defaultRegistry) update(id auction.Bidder, change func(profile *Profile)) (Profile, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	profile, err := r.store.GetProfile(id)
+	if err != nil {
+		return Profile{}, err
+	}
+	change(&profile)
+	err = r.store.UpdateProfile(profile)
+	if err != nil {
+		return Profile{}, errors.Join(errors.New("failed to update profile"), err)
+	}
+	return profile, nil
+}
+
+func (r *defaultRegistry) Reserve(id auction.Bidder, auctionID auction.ID, maxBid currency.Amount) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	profile, err := r.store.GetProfile(id)
+	if err != nil {
+		return err
+	}
+	if profile.Status != StatusVerified {
+		return &UnverifiedBidderError{id: id, status: profile.Status}
+	}
+
+	if maxBid.Code() != profile.CreditLimit.Code() {
+		return &CurrencyMismatchError{id: id, limit: profile.CreditLimit.Code(), amount: maxBid.Code()}
+	}
+
+	exposures, err := r.exposures(id)
+	if err != nil {
+		return err
+	}
+	// the max bid replaces the one outstanding in the same auction, so it is left out of the sum
+	others := []Exposure{}
+	for _, exposure := range exposures {
+		if exposure.Auction != auctionID {
+			others = append(others, exposure)
+		}
+	}
+	outstanding, err := sum(id, profile.CreditLimit.Code(), others)
+	if err != nil {
+		return err
+	}
+	total, err := outstanding.Add(maxBid)
+	if err != nil {
+		return err
+	}
+	if total.Greater(profile.CreditLimit) {
+		return &CreditLimitExceededError{id: id, limit: profile.CreditLimit, outstanding: outstanding, maxBid: maxBid}
+	}
+	err = r.store.SaveExposure(Exposure{Bidder: id, Auction: auctionID, MaxBid: maxBid})
+	if err != nil {
+		return errors.Join(errors.New("failed to save exposure"), err)
+	}
+	return nil
+}
+
+func (r *defaultRegistry) Restore(exposure Exposure) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	err := r.store.SaveExposure(exposure)
+	if err != nil {
+		return errors.Join(errors.New("failed to save exposure"), err)
+	}
+	return nil
+}
+
+func (r *defaultRegistry) Release(id auction.Bidder, auctionID auction.ID) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.store.DeleteExposure(id, auctionID)
+}
+
+func (r *defaultRegistry) Outstanding(id auction.Bidder) (currency.Amount, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	profile, err := r.store.GetProfile(id)
+	if err != nil {
+		return currency.Amount{}, err
+	}
+	exposures, err := r.exposures(id)
+	if err != nil {
+		return currency.Amount{}, err
+	}
+	return sum(id, profile.CreditLimit.Code(), exposures)
+}
+
+func (r *defaultRegistry) exposures(id auction.Bidder) ([]Exposure, error) {
+	exposures, err := r.store.GetExposures(id)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to fetch exposures"), err)
+	}
+	return exposures, nil
+}
+
+// sum adds up the max bids of the exposures, which must be in the currency of the credit limit. An exposure can be in
+// another currency if the credit limit was changed to a new currency after it was reserved.
+func sum(id auction.Bidder, code currency.Code, exposures []Exposure) (currency.Amount, error) {
+	total := currency.FromMinorUnits(0, code)
+	for _, exposure := range exposures {
+		if exposure.MaxBid.Code() != code {
+			return currency.Amount{}, &CurrencyMismatchError{id: id, limit: code, amount: exposure.MaxBid.Code()}
+		}
+		var err error
+		total, err = total.Add(exposure.MaxBid)
+		if err != nil {
+			return currency.Amount{}, err
+		}
+	}
+	return total, nil
+}
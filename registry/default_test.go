@@ -0,0 +1,174 @@
+package registry
+
+import (
+	"auction/currency"
+	"testing"
+	"time"
+)
+
+func usd(major int64) currency.Amount {
+	return currency.MustNew(major, 0, currency.USD)
+}
+
+// newRegistry creates a registry where Sasha is verified with a limit of $100.00 and John is registered but unverified
+func newRegistry(t *testing.T) Registry {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	registry := NewRegistry(Config{Now: func() time.Time { return now }}, NewMemoryStorage())
+	_, err := registry.Register("Sasha", "Sasha B.", usd(100))
+	if err != nil {
+		t.Fatalf("Failed to register bidder: %s", err.Error())
+	}
+	_, err = registry.SetStatus("Sasha", StatusVerified)
+	if err != nil {
+		t.Fatalf("Failed to verify bidder: %s", err.Error())
+	}
+	_, err = registry.Register("John", "John D.", usd(100))
+	if err != nil {
+		t.Fatalf("Failed to register bidder: %s", err.Error())
+	}
+	return registry
+}
+
+func TestRegister(t *testing.T) {
+	registry := newRegistry(t)
+	profile, err := registry.GetProfile("John")
+	if err != nil {
+		t.Fatalf("Failed to get profile: %s", err.Error())
+	}
+	expected := Profile{ID: "John", DisplayName: "John D.", Status: StatusUnverified, CreditLimit: usd(100), RegisteredAt: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	if profile != expected {
+		t.Fatalf("Expected %#v, got %#v", expected, profile)
+	}
+
+	_, err = registry.Register("John", "John D.", usd(100))
+	if _, ok := err.(*DuplicateProfileError); !ok {
+		t.Fatalf("Expected DuplicateProfileError but got %#v", err)
+	}
+	_, err = registry.Register("", "Nobody", usd(100))
+	if _, ok := err.(*InvalidProfileError); !ok {
+		t.Fatalf("Expected InvalidProfileError but got %#v", err)
+	}
+	_, err = registry.Register("Pat", "Pat K.", usd(-1))
+	if _, ok := err.(*InvalidProfileError); !ok {
+		t.Fatalf("Expected InvalidProfileError but got %#v", err)
+	}
+	_, err = registry.SetStatus("John", "banned")
+	if _, ok := err.(*InvalidProfileError); !ok {
+		t.Fatalf("Expected InvalidProfileError but got %#v", err)
+	}
+	_, err = registry.SetCreditLimit("Pat", usd(100))
+	if _, ok := err.(*ProfileNotFoundError); !ok {
+		t.Fatalf("Expected ProfileNotFoundError but got %#v", err)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	registry := newRegistry(t)
+
+	err := registry.Reserve("John", "auction-1", usd(10))
+	if _, ok := err.(*UnverifiedBidderError); !ok {
+		t.Fatalf("Expected UnverifiedBidderError but got %#v", err)
+	}
+	err = registry.Reserve("Pat", "auction-1", usd(10))
+	if _, ok := err.(*ProfileNotFoundError); !ok {
+		t.Fatalf("Expected ProfileNotFoundError but got %#v", err)
+	}
+
+	err = registry.Reserve("Sasha", "auction-1", usd(60))
+	if err != nil {
+		t.Fatalf("Failed to reserve max bid: %s", err.Error())
+	}
+	err = registry.Reserve("Sasha", "auction-2", usd(50))
+	if _, ok := err.(*CreditLimitExceededError); !ok {
+		t.Fatalf("Expected CreditLimitExceededError but got %#v", err)
+	}
+	// lowering the max bid in the first auction replaces it, which makes room in the second
+	err = registry.Reserve("Sasha", "auction-1", usd(50))
+	if err != nil {
+		t.Fatalf("Failed to reserve max bid: %s", err.Error())
+	}
+	err = registry.Reserve("Sasha", "auction-2", usd(50))
+	if err != nil {
+		t.Fatalf("Failed to reserve max bid: %s", err.Error())
+	}
+	outstanding, err := registry.Outstanding("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to get outstanding max bids: %s", err.Error())
+	}
+	if outstanding != usd(100) {
+		t.Fatalf("Expected $100.00 outstanding, got %s", outstanding)
+	}
+
+	err = registry.Release("Sasha", "auction-1")
+	if err != nil {
+		t.Fatalf("Failed to release max bid: %s", err.Error())
+	}
+	outstanding, err = registry.Outstanding("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to get outstanding max bids: %s", err.Error())
+	}
+	if outstanding != usd(50) {
+		t.Fatalf("Expected $50.00 outstanding, got %s", outstanding)
+	}
+
+	_, err = registry.SetStatus("Sasha", StatusSuspended)
+	if err != nil {
+		t.Fatalf("Failed to suspend bidder: %s", err.Error())
+	}
+	err = registry.Reserve("Sasha", "auction-3", usd(10))
+	if _, ok := err.(*UnverifiedBidderError); !ok {
+		t.Fatalf("Expected UnverifiedBidderError but got %#v", err)
+	}
+}
+
+func TestReserve_CurrencyMismatch(t *testing.T) {
+	registry := newRegistry(t)
+	err := registry.Reserve("Sasha", "auction-1", currency.MustNew(10, 0, currency.EUR))
+	if _, ok := err.(*CurrencyMismatchError); !ok {
+		t.Fatalf("Expected CurrencyMismatchError but got %#v", err)
+	}
+
+	// a max bid reserved before the credit limit moved to another currency can no longer be added up
+	err = registry.Reserve("Sasha", "auction-1", usd(10))
+	if err != nil {
+		t.Fatalf("Failed to reserve max bid: %s", err.Error())
+	}
+	_, err = registry.SetCreditLimit("Sasha", currency.MustNew(100, 0, currency.EUR))
+	if err != nil {
+		t.Fatalf("Failed to change credit limit: %s", err.Error())
+	}
+	_, err = registry.Outstanding("Sasha")
+	if _, ok := err.(*CurrencyMismatchError); !ok {
+		t.Fatalf("Expected CurrencyMismatchError but got %#v", err)
+	}
+	err = registry.Reserve("Sasha", "auction-2", currency.MustNew(10, 0, currency.EUR))
+	if _, ok := err.(*CurrencyMismatchError); !ok {
+		t.Fatalf("Expected CurrencyMismatchError but got %#v", err)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	registry := newRegistry(t)
+	err := registry.Reserve("Sasha", "auction-1", usd(40))
+	if err != nil {
+		t.Fatalf("Failed to reserve max bid: %s", err.Error())
+	}
+	err = registry.Reserve("Sasha", "auction-1", usd(90))
+	if err != nil {
+		t.Fatalf("Failed to reserve max bid: %s", err.Error())
+	}
+
+	// the amendment that reserved $90.00 failed, so the $40.00 it replaced is put back even though Sasha is suspended
+	_, err = registry.SetStatus("Sasha", StatusSuspended)
+	if err != nil {
+		t.Fatalf("Failed to suspend bidder: %s", err.Error())
+	}
+	err = registry.Restore(Exposure{Bidder: "Sasha", Auction: "auction-1", MaxBid: usd(40)})
+	if err != nil {
+		t.Fatalf("Failed to restore max bid: %s", err.Error())
+	}
+	outstanding, err := registry.Outstanding("Sasha")
+	if err != nil || outstanding != usd(40) {
+		t.Fatalf("Expected $40.00 outstanding, got %s and %#v", outstanding, err)
+	}
+}
@@ -0,0 +1,61 @@
+package registry
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"fmt"
+)
+
+type InvalidProfileError struct {
+	message string
+}
+
+func (e *InvalidProfileError) Error() string {
+	return e.message
+}
+
+type DuplicateProfileError struct {
+	id auction.Bidder
+}
+
+func (e *DuplicateProfileError) Error() string {
+	return fmt.Sprintf("bidder %s is already registered", e.id)
+}
+
+type ProfileNotFoundError struct {
+	id auction.Bidder
+}
+
+func (e *ProfileNotFoundError) Error() string {
+	return fmt.Sprintf("bidder %s is not registered", e.id)
+}
+
+type UnverifiedBidderError struct {
+	id     auction.Bidder
+	status Status
+}
+
+func (e *UnverifiedBidderError) Error() string {
+	return fmt.Sprintf("bidder %s is %s and cannot bid", e.id, e.status)
+}
+
+type CreditLimitExceededError struct {
+	id          auction.Bidder
+	limit       currency.Amount
+	outstanding currency.Amount
+	maxBid      currency.Amount
+}
+
+func (e *CreditLimitExceededError) Error() string {
+	return fmt.Sprintf("max bid %s of bidder %s would exceed their credit limit of %s with %s already outstanding", e.maxBid, e.id, e.limit, e.outstanding)
+}
+
+type CurrencyMismatchError struct {
+	id     auction.Bidder
+	limit  currency.Code
+	amount currency.Code
+}
+
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("bidder %s has a credit limit in %s, which cannot cover an amount in %s", e.id, e.limit, e.amount)
+}
@@ -0,0 +1,81 @@
+package registry
+
+import (
+	"auction/auction"
+	"sort"
+	"sync"
+)
+
+type memoryStorage struct {
+	profiles  map[auction.Bidder]Profile
+	exposures map[auction.Bidder]map[auction.ID]Exposure
+	mtx       *sync.Mutex
+}
+
+func NewMemoryStorage() Storer {
+	return &memoryStorage{
+		profiles:  map[auction.Bidder]Profile{},
+		exposures: map[auction.Bidder]map[auction.ID]Exposure{},
+		mtx:       &sync.Mutex{},
+	}
+}
+
+func (m memoryStorage) SaveProfile(profile Profile) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.profiles[profile.ID]; ok {
+		return &DuplicateProfileError{id: profile.ID}
+	}
+	m.profiles[profile.ID] = profile
+	return nil
+}
+
+func (m memoryStorage) UpdateProfile(profile Profile) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.profiles[profile.ID]; !ok {
+		return &ProfileNotFoundError{id: profile.ID}
+	}
+	m.profiles[profile.ID] = profile
+	return nil
+}
+
+func (m memoryStorage) GetProfile(id auction.Bidder) (Profile, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	profile, ok := m.profiles[id]
+	if !ok {
+		return Profile{}, &ProfileNotFoundError{id: id}
+	}
+	return profile, nil
+}
+
+func (m memoryStorage) SaveExposure(exposure Exposure) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.exposures[exposure.Bidder]; !ok {
+		m.exposures[exposure.Bidder] = map[auction.ID]Exposure{}
+	}
+	m.exposures[exposure.Bidder][exposure.Auction] = exposure
+	return nil
+}
+
+func (m memoryStorage) DeleteExposure(id auction.Bidder, auctionID auction.ID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.exposures[id], auctionID)
+	return nil
+}
+
+func (m memoryStorage) GetExposures(id auction.Bidder) ([]Exposure, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	exposures := []Exposure{}
+	for _, exposure := range m.exposures[id] {
+		exposures = append(exposures, exposure)
+	}
+	sort.Slice(exposures, func(i, j int) bool {
+		return exposures[i].Auction < exposures[j].Auction
+	})
+	return exposures, nil
+}
@@ -0,0 +1,59 @@
+package registry
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUnverified Status = "unverified"
+	StatusVerified   Status = "verified"
+	StatusSuspended  Status = "suspended"
+)
+
+// Profile is a registered bidder. Only verified bidders can bid, and the sum of their outstanding max bids across every
+// auction cannot exceed their CreditLimit.
+type Profile struct {
+	ID           auction.Bidder  `json:"id"`
+	DisplayName  string          `json:"display_name"`
+	Status       Status          `json:"status"`
+	CreditLimit  currency.Amount `json:"credit_limit"`
+	RegisteredAt time.Time       `json:"registered_at"`
+}
+
+// Exposure is the max bid a bidder has outstanding in an auction
+type Exposure struct {
+	Bidder  auction.Bidder  `json:"bidder"`
+	Auction auction.ID      `json:"auction"`
+	MaxBid  currency.Amount `json:"max_bid"`
+}
+
+// Registry holds the profiles of bidders and the max bids they have outstanding
+type Registry interface {
+	// Register creates a profile for a new bidder, which is unverified until its status is changed
+	Register(id auction.Bidder, displayName string, creditLimit currency.Amount) (Profile, error)
+	GetProfile(id auction.Bidder) (Profile, error)
+	// SetStatus changes the verification status of a bidder
+	SetStatus(id auction.Bidder, status Status) (Profile, error)
+	// SetCreditLimit changes the credit limit of a bidder. Max bids that are already outstanding are not affected.
+	SetCreditLimit(id auction.Bidder, limit currency.Amount) (Profile, error)
+	// Reserve checks that a verified bidder can afford the max bid and records it as outstanding in the auction,
+	// replacing the max bid they had outstanding there before. A max bid in a different currency from the credit limit
+	// is rejected with a CurrencyMismatchError.
+	Reserve(id auction.Bidder, auctionID auction.ID, maxBid currency.Amount) error
+	// Restore records the exposure as outstanding without checking the bidder or their credit limit. It puts back the
+	// max bid that a Reserve replaced when the change the reservation was made for fails.
+	Restore(exposure Exposure) error
+	// Release removes the max bid a bidder has outstanding in the auction
+	Release(id auction.Bidder, auctionID auction.ID) error
+	// Outstanding returns the sum of the max bids a bidder has outstanding across every auction
+	Outstanding(id auction.Bidder) (currency.Amount, error)
+}
+
+// Config configures a registry. Now is the clock used to timestamp registrations, and is time.Now if it is not set.
+type Config struct {
+	Now func() time.Time
+}
@@ -0,0 +1,17 @@
+package registry
+
+import "auction/auction"
+
+// Storer stores the profiles of bidders and their exposures
+type Storer interface {
+	// SaveProfile creates a profile, returning a DuplicateProfileError if the bidder is already registered
+	SaveProfile(profile Profile) error
+	// UpdateProfile replaces the profile of a registered bidder
+	UpdateProfile(profile Profile) error
+	GetProfile(id auction.Bidder) (Profile, error)
+	// SaveExposure creates or replaces the exposure of a bidder in an auction
+	SaveExposure(exposure Exposure) error
+	DeleteExposure(id auction.Bidder, auctionID auction.ID) error
+	// GetExposures returns the exposures of a bidder ordered by auction
+	GetExposures(id auction.Bidder) ([]Exposure, error)
+}
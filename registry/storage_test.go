@@ -0,0 +1,97 @@
+package registry
+
+import (
+	"auction/currency"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type storageTests struct {
+	storeFn func() Storer
+	t       *testing.T
+}
+
+func (g *storageTests) Run() {
+	tests := map[string]func(t *testing.T, store Storer){
+		"Test Profiles":          testProfiles,
+		"Test Profile Not Found": testProfileNotFound,
+		"Test Exposures":         testExposures,
+	}
+	for name, test := range tests {
+		g.t.Run(name, func(t *testing.T) {
+			test(t, g.storeFn())
+		})
+	}
+}
+
+func testProfiles(t *testing.T, store Storer) {
+	profile := Profile{
+		ID:           "Sasha",
+		DisplayName:  "Sasha B.",
+		Status:       StatusUnverified,
+		CreditLimit:  currency.MustNew(100, 0, currency.USD),
+		RegisteredAt: time.Unix(100, 0),
+	}
+	err := store.SaveProfile(profile)
+	if err != nil {
+		t.Fatalf("Failed to save profile: %s", err.Error())
+	}
+	err = store.SaveProfile(profile)
+	if _, ok := err.(*DuplicateProfileError); !ok {
+		t.Fatalf("Expected DuplicateProfileError but got %#v", err)
+	}
+
+	profile.Status = StatusVerified
+	err = store.UpdateProfile(profile)
+	if err != nil {
+		t.Fatalf("Failed to update profile: %s", err.Error())
+	}
+	saved, err := store.GetProfile("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to get profile: %s", err.Error())
+	}
+	if !reflect.DeepEqual(profile, saved) {
+		t.Fatalf("Profiles do not match. Expected:\n%#v\nGot:\n%#v", profile, saved)
+	}
+}
+
+func testProfileNotFound(t *testing.T, store Storer) {
+	_, err := store.GetProfile("Sasha")
+	if _, ok := err.(*ProfileNotFoundError); !ok {
+		t.Fatalf("Expected ProfileNotFoundError but got %#v", err)
+	}
+	err = store.UpdateProfile(Profile{ID: "Sasha"})
+	if _, ok := err.(*ProfileNotFoundError); !ok {
+		t.Fatalf("Expected ProfileNotFoundError but got %#v", err)
+	}
+}
+
+func testExposures(t *testing.T, store Storer) {
+	exposures := []Exposure{
+		{Bidder: "Sasha", Auction: "auction-2", MaxBid: currency.MustNew(80, 0, currency.USD)},
+		{Bidder: "Sasha", Auction: "auction-1", MaxBid: currency.MustNew(50, 0, currency.USD)},
+		{Bidder: "John", Auction: "auction-1", MaxBid: currency.MustNew(82, 0, currency.USD)},
+		{Bidder: "Sasha", Auction: "auction-1", MaxBid: currency.MustNew(60, 0, currency.USD)},
+		{Bidder: "Sasha", Auction: "auction-3", MaxBid: currency.MustNew(10, 0, currency.USD)},
+	}
+	for _, exposure := range exposures {
+		err := store.SaveExposure(exposure)
+		if err != nil {
+			t.Fatalf("Failed to save exposure: %s", err.Error())
+		}
+	}
+	err := store.DeleteExposure("Sasha", "auction-3")
+	if err != nil {
+		t.Fatalf("Failed to delete exposure: %s", err.Error())
+	}
+
+	expected := []Exposure{exposures[3], exposures[0]}
+	saved, err := store.GetExposures("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to get exposures: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, saved) {
+		t.Fatalf("Exposures do not match. Expected:\n%#v\nGot:\n%#v", expected, saved)
+	}
+}
@@ -0,0 +1,115 @@
+package settlement
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/deposit"
+	"auction/fees"
+	"auction/ledger"
+	"errors"
+	"fmt"
+)
+
+// CashAccount is the account of the house that receives the payments of buyers and pays out consignors. Once every
+// invoice has been paid and paid out, its balance is the fees the house has earned.
+const CashAccount ledger.Account = "house:cash"
+
+// BuyerAccount is what a buyer owes for the lots they have won
+func BuyerAccount(bidder auction.Bidder) ledger.Account {
+	return ledger.Account("buyer:" + string(bidder))
+}
+
+// SellerAccount is what the house owes a consignor for their lots that have sold
+func SellerAccount(seller string) ledger.Account {
+	return ledger.Account("seller:" + seller)
+}
+
+// LotAccount passes the hammer price of a lot from its buyer to its consignors, so its balance is zero once the lot is
+// invoiced
+func LotAccount(lot LotID) ledger.Account {
+	return ledger.Account("lot:" + string(lot))
+}
+
+// FeeAccount collects a kind of fee charged by the house, such as the buyer's premium or the seller's commission
+func FeeAccount(kind fees.Kind) ledger.Account {
+	return ledger.Account("house:" + string(kind))
+}
+
+// saleEntries records what the buyer owes for the lot and what each consignor is owed. The buyer is debited their
+// total, which is credited to the lot for the hammer price and to the fee accounts for the rest. The lot then pays
+// the hammer price to the consignors, less the fees deducted from their payout. A captured deposit is credited to the
+// buyer out of the captured deposits, and the money held for it becomes cash of the house.
+func saleEntries(lot LotID, breakdown fees.Breakdown, payouts []Payout, deposited currency.Amount) ([]ledger.Entry, error) {
+	entries := []ledger.Entry{ledger.Debit(BuyerAccount(breakdown.Bidder), breakdown.BuyerTotal)}
+	// items charged to the buyer are credits, and items that add to the payout of the seller are debits
+	for _, item := range breakdown.Buyer {
+		entry, err := itemEntry(lot, item, ledger.SideCredit)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	for _, item := range breakdown.Seller {
+		entry, err := itemEntry(lot, item, ledger.SideDebit)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	for _, payout := range payouts {
+		entries = append(entries, ledger.Credit(SellerAccount(payout.Seller), payout.Amount))
+	}
+	entries = append(entries,
+		ledger.Debit(deposit.CapturedAccount, deposited),
+		ledger.Credit(BuyerAccount(breakdown.Bidder), deposited),
+		ledger.Debit(CashAccount, deposited),
+		ledger.Credit(deposit.HeldAccount, deposited),
+	)
+	return withoutZeros(entries), nil
+}
+
+// paymentEntries records that the buyer paid what was left of the invoice after their deposit, and that the payouts
+// were paid to the consignors
+func paymentEntries(invoice Invoice) []ledger.Entry {
+	entries := []ledger.Entry{
+		ledger.Debit(CashAccount, invoice.AmountDue),
+		ledger.Credit(BuyerAccount(invoice.Bidder), invoice.AmountDue),
+	}
+	for _, payout := range invoice.Payouts {
+		entries = append(entries, ledger.Debit(SellerAccount(payout.Seller), payout.Amount))
+	}
+	entries = append(entries, ledger.Credit(CashAccount, invoice.Fees.SellerPayout))
+	return withoutZeros(entries)
+}
+
+// itemEntry records a line item of the breakdown on the given side, or on the opposite side if its amount is negative.
+// The hammer price goes to the account of the lot and every other item to the account of its fee.
+func itemEntry(lot LotID, item fees.LineItem, side ledger.Side) (ledger.Entry, error) {
+	account := FeeAccount(item.Kind)
+	if item.Kind == fees.KindHammerPrice {
+		account = LotAccount(lot)
+	}
+	if item.Amount.Sign() >= 0 {
+		return ledger.Entry{Account: account, Side: side, Amount: item.Amount}, nil
+	}
+	amount, err := item.Amount.Abs()
+	if err != nil {
+		return ledger.Entry{}, errors.Join(fmt.Errorf("failed to record %s", item.Kind), err)
+	}
+	if side == ledger.SideCredit {
+		return ledger.Debit(account, amount), nil
+	}
+	return ledger.Credit(account, amount), nil
+}
+
+// withoutZeros leaves out the entries of items that came to nothing, such as a sales tax of 0%, since the ledger only
+// accepts positive amounts
+func withoutZeros(entries []ledger.Entry) []ledger.Entry {
+	nonZero := make([]ledger.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Amount.IsZero() {
+			nonZero = append(nonZero, entry)
+		}
+	}
+	return nonZero
+}
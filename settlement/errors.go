@@ -0,0 +1,39 @@
+package settlement
+
+import (
+	"auction/id_generator"
+	"fmt"
+)
+
+type InvalidLotError struct {
+	message string
+}
+
+func (e *InvalidLotError) Error() string {
+	return e.message
+}
+
+type LotAlreadySoldError struct {
+	lot LotID
+}
+
+func (e *LotAlreadySoldError) Error() string {
+	return fmt.Sprintf("lot %s has already been invoiced to a bidder who has not defaulted", e.lot)
+}
+
+type InvoiceNotFoundError struct {
+	id id_generator.EventID
+}
+
+func (e *InvoiceNotFoundError) Error() string {
+	return fmt.Sprintf("invoice %d not found", e.id)
+}
+
+type InvalidStatusError struct {
+	id     id_generator.EventID
+	status Status
+}
+
+func (e *InvalidStatusError) Error() string {
+	return fmt.Sprintf("invoice %d is %s and can no longer be changed", e.id, e.status)
+}
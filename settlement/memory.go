@@ -0,0 +1,75 @@
+package settlement
+
+import (
+	"auction/id_generator"
+	"sort"
+	"sync"
+)
+
+type memoryStorage struct {
+	invoices map[id_generator.EventID]Invoice
+	offers   map[id_generator.EventID]Offer
+	mtx      *sync.Mutex
+}
+
+func NewMemoryStorage() Storer {
+	return &memoryStorage{
+		invoices: map[id_generator.EventID]Invoice{},
+		offers:   map[id_generator.EventID]Offer{},
+		mtx:      &sync.Mutex{},
+	}
+}
+
+func (m memoryStorage) SaveInvoice(invoice Invoice) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.invoices[invoice.ID] = invoice
+	return nil
+}
+
+func (m memoryStorage) GetInvoice(id id_generator.EventID) (Invoice, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	invoice, ok := m.invoices[id]
+	if !ok {
+		return Invoice{}, &InvoiceNotFoundError{id: id}
+	}
+	return invoice, nil
+}
+
+func (m memoryStorage) GetInvoices(lot LotID) ([]Invoice, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	invoices := []Invoice{}
+	for _, invoice := range m.invoices {
+		if invoice.Lot == lot {
+			invoices = append(invoices, invoice)
+		}
+	}
+	sort.Slice(invoices, func(i, j int) bool {
+		return invoices[i].ID < invoices[j].ID
+	})
+	return invoices, nil
+}
+
+func (m memoryStorage) SaveOffer(offer Offer) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.offers[offer.Invoice] = offer
+	return nil
+}
+
+func (m memoryStorage) GetOffers(lot LotID) ([]Offer, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	offers := []Offer{}
+	for _, offer := range m.offers {
+		if offer.Lot == lot {
+			offers = append(offers, offer)
+		}
+	}
+	sort.Slice(offers, func(i, j int) bool {
+		return offers[i].Invoice < offers[j].Invoice
+	})
+	return offers, nil
+}
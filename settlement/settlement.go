@@ -0,0 +1,115 @@
+package settlement
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/deposit"
+	"auction/fees"
+	"auction/id_generator"
+	"auction/registry"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusPaid      Status = "paid"
+	StatusDefaulted Status = "defaulted"
+)
+
+type LotID string
+
+// Consignor is a seller of a lot. A lot owned by several consignors pays each of them in proportion to their Share.
+type Consignor struct {
+	Seller string `json:"seller"`
+	Share  int64  `json:"share"`
+}
+
+// Lot is an item sold at auction. Auction is the ID of the auction it was sold in, which is used to find the deposit
+// of its winner.
+type Lot struct {
+	ID         LotID       `json:"id"`
+	Auction    auction.ID  `json:"auction,omitempty"`
+	Consignors []Consignor `json:"consignors"`
+}
+
+// Payout is the part of the seller payout of an invoice that is paid to a consignor
+type Payout struct {
+	Seller string          `json:"seller"`
+	Amount currency.Amount `json:"amount"`
+}
+
+// Invoice is what the winner of a lot owes. Fees itemizes the buyer total and the seller payout, and Payouts splits the
+// seller payout between the consignors of the lot. Deposit is the captured deposit of the winner, which is credited
+// against the buyer total, and AmountDue is what is left for them to pay. Transaction is the ID of the ledger
+// transaction that recorded the sale. SettledAt is when the invoice was paid or defaulted, and is zero while it is
+// pending.
+type Invoice struct {
+	ID          id_generator.EventID `json:"id"`
+	Lot         LotID                `json:"lot"`
+	Auction     auction.ID           `json:"auction,omitempty"`
+	Bidder      auction.Bidder       `json:"bidder"`
+	HammerPrice currency.Amount      `json:"hammer_price"`
+	Fees        fees.Breakdown       `json:"fees"`
+	Payouts     []Payout             `json:"payouts"`
+	Deposit     currency.Amount      `json:"deposit"`
+	AmountDue   currency.Amount      `json:"amount_due"`
+	Transaction id_generator.EventID `json:"transaction"`
+	IssuedAt    time.Time            `json:"issued_at"`
+	DueDate     time.Time            `json:"due_date"`
+	Status      Status               `json:"status"`
+	SettledAt   time.Time            `json:"settled_at"`
+}
+
+// Settler invoices the winners of lots and records every sale, payment and default in a ledger. The winner comes from
+// the bid manager of the auction, so a buy-it-now purchase or an accepted second-chance offer is invoiced at its price.
+type Settler interface {
+	// Invoice creates the invoice for the winner of a closed auction, charging the sales tax of the winner's
+	// jurisdiction, and records the hammer price, fees and payouts in the ledger. A lot can only be invoiced again once
+	// every earlier invoice for it has been defaulted.
+	Invoice(lot Lot, winner auction.WinningBid, jurisdiction fees.Jurisdiction) (Invoice, error)
+	// MarkPaid records that a pending invoice has been paid, and records the payment and the payouts to the
+	// consignors in the ledger. The buyer's max bid in the auction of the lot is then released from the bidder
+	// registry, and if that fails the paid invoice is returned along with the error.
+	MarkPaid(id id_generator.EventID) (Invoice, error)
+	// MarkDefaulted records that the buyer did not pay a pending invoice and reverses the sale in the ledger. When the
+	// settler has a Defaulter and the invoice has an auction, the lot is then offered to the runner up through it and
+	// the offer is saved, and the bidder who accepts the offer is invoiced for the lot. If the offer cannot be made or
+	// saved, the defaulted invoice is returned along with the error.
+	MarkDefaulted(id id_generator.EventID) (Invoice, error)
+	GetInvoice(id id_generator.EventID) (Invoice, error)
+	// GetOffers returns the second-chance offers of a lot ordered by the invoice that was defaulted
+	GetOffers(lot LotID) ([]Offer, error)
+}
+
+// Offer is the second-chance offer of a lot made when the buyer of Invoice defaulted
+type Offer struct {
+	Invoice   id_generator.EventID `json:"invoice"`
+	Lot       LotID                `json:"lot"`
+	Auction   auction.ID           `json:"auction"`
+	Bidder    auction.Bidder       `json:"bidder"`
+	Amount    currency.Amount      `json:"amount"`
+	ExpiresAt time.Time            `json:"expires_at"`
+}
+
+// Defaulter declares that the winner of an auction defaulted and returns the second-chance offer made to the runner
+// up, such as with the DeclareDefault of the bid manager of the auction
+type Defaulter func(id auction.ID, bidder auction.Bidder) (auction.SecondChanceOffer, error)
+
+// Config configures the settler. Zero values are replaced by the defaults below.
+type Config struct {
+	// PaymentTerms is the time the buyer has to pay an invoice after it is issued
+	PaymentTerms time.Duration
+	// Deposits holds the deposits of auctions that require one. The captured deposit of a winner is credited against
+	// their invoice, and no deposits are credited if it is not set.
+	Deposits deposit.Holds
+	// Registry is the bidder registry of the auctions, which holds the winner's max bid as outstanding until they pay.
+	// No max bids are released if it is not set.
+	Registry registry.Registry
+	// Defaulter offers the lot to the runner up when an invoice is defaulted. No offers are made if it is not set.
+	Defaulter Defaulter
+	Now       func() time.Time
+}
+
+const defaultPaymentTerms = 7 * 24 * time.Hour
@@ -0,0 +1,272 @@
+package settlement
+
+import (
+	"auction/auction"
+	"auction/bid_manager"
+	"auction/currency"
+	"auction/deposit"
+	"auction/fees"
+	"auction/id_generator"
+	"auction/ledger"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultSettler creates invoices with the fees of a fee calculator and records the money they move in a ledger. The
+// mutex serializes changes so that an invoice is never paid and defaulted at the same time, and a lot is never
+// invoiced to two bidders.
+type defaultSettler struct {
+	config      Config
+	calculator  fees.Calculator
+	idGenerator id_generator.IDGenerator
+	ledger      ledger.Ledger
+	store       Storer
+	mtx         *sync.Mutex
+}
+
+func NewSettler(config Config, calculator fees.Calculator, idGenerator id_generator.IDGenerator, ledger ledger.Ledger, store Storer) Settler {
+	if config.PaymentTerms == 0 {
+		config.PaymentTerms = defaultPaymentTerms
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &defaultSettler{
+		config:      config,
+		calculator:  calculator,
+		idGenerator: idGenerator,
+		ledger:      ledger,
+		store:       store,
+		mtx:         &sync.Mutex{},
+	}
+}
+
+func (s *defaultSettler) Invoice(lot Lot, winner auction.WinningBid, jurisdiction fees.Jurisdiction) (Invoice, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	err := validateLot(lot)
+	if err != nil {
+		return Invoice{}, err
+	}
+	err = s.checkUnsold(lot.ID)
+	if err != nil {
+		return Invoice{}, err
+	}
+	breakdown, err := s.calculator.Calculate(winner, jurisdiction)
+	if err != nil {
+		return Invoice{}, errors.Join(errors.New("failed to calculate fees"), err)
+	}
+	payouts, err := payoutsOf(lot, breakdown)
+	if err != nil {
+		return Invoice{}, err
+	}
+	credit, err := s.depositOf(lot, winner.Bidder, breakdown.BuyerTotal)
+	if err != nil {
+		return Invoice{}, err
+	}
+	due, err := breakdown.BuyerTotal.Sub(credit)
+	if err != nil {
+		return Invoice{}, errors.Join(errors.New("failed to credit deposit"), err)
+	}
+	entries, err := saleEntries(lot.ID, breakdown, payouts, credit)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	id := s.idGenerator.Next()
+	transaction, err := s.ledger.Post(fmt.Sprintf("invoice %d for lot %s", id, lot.ID), entries...)
+	if err != nil {
+		return Invoice{}, errors.Join(errors.New("failed to record sale"), err)
+	}
+	now := s.config.Now()
+	invoice := Invoice{
+		ID:          id,
+		Lot:         lot.ID,
+		Auction:     lot.Auction,
+		Bidder:      winner.Bidder,
+		HammerPrice: winner.Amount,
+		Fees:        breakdown,
+		Payouts:     payouts,
+		Deposit:     credit,
+		AmountDue:   due,
+		Transaction: transaction.ID,
+		IssuedAt:    now,
+		DueDate:     now.Add(s.config.PaymentTerms),
+		Status:      StatusPending,
+	}
+	err = s.store.SaveInvoice(invoice)
+	if err != nil {
+		// the sale is reversed so that the lot can be invoiced again once the store recovers
+		_, reverseErr := s.ledger.Reverse(transaction.ID, fmt.Sprintf("invoice %d could not be saved", id))
+		return Invoice{}, errors.Join(errors.New("failed to save invoice"), err, reverseErr)
+	}
+	return invoice, nil
+}
+
+func (s *defaultSettler) MarkPaid(id id_generator.EventID) (Invoice, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	invoice, err := s.settle(id, StatusPaid, func(invoice Invoice) error {
+		_, err := s.ledger.Post(fmt.Sprintf("payment of invoice %d", invoice.ID), paymentEntries(invoice)...)
+		return err
+	})
+	if err != nil {
+		return Invoice{}, err
+	}
+	if s.config.Registry == nil || invoice.Auction == "" {
+		return invoice, nil
+	}
+	err = s.config.Registry.Release(invoice.Bidder, invoice.Auction)
+	if err != nil {
+		return invoice, errors.Join(errors.New("failed to release max bid"), err)
+	}
+	return invoice, nil
+}
+
+func (s *defaultSettler) MarkDefaulted(id id_generator.EventID) (Invoice, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	invoice, err := s.settle(id, StatusDefaulted, func(invoice Invoice) error {
+		_, err := s.ledger.Reverse(invoice.Transaction, fmt.Sprintf("default of invoice %d", invoice.ID))
+		return err
+	})
+	if err != nil {
+		return Invoice{}, err
+	}
+	if s.config.Defaulter == nil || invoice.Auction == "" {
+		return invoice, nil
+	}
+	return invoice, s.offer(invoice)
+}
+
+// offer declares the default of the buyer of the invoice and saves the offer made to the runner up. A lot that every
+// bidder has defaulted on or declined has nobody left to offer it to, and stays unsold.
+func (s *defaultSettler) offer(invoice Invoice) error {
+	offer, err := s.config.Defaulter(invoice.Auction, invoice.Bidder)
+	var noRunnerUp *bid_manager.NoRunnerUpError
+	if errors.As(err, &noRunnerUp) {
+		return nil
+	} else if err != nil {
+		return errors.Join(errors.New("failed to declare default"), err)
+	}
+	err = s.store.SaveOffer(Offer{
+		Invoice:   invoice.ID,
+		Lot:       invoice.Lot,
+		Auction:   invoice.Auction,
+		Bidder:    offer.Bidder,
+		Amount:    offer.Amount,
+		ExpiresAt: offer.ExpiresAt,
+	})
+	if err != nil {
+		return errors.Join(errors.New("failed to save offer"), err)
+	}
+	return nil
+}
+
+func (s *defaultSettler) GetInvoice(id id_generator.EventID) (Invoice, error) {
+	return s.store.GetInvoice(id)
+}
+
+func (s *defaultSettler) GetOffers(lot LotID) ([]Offer, error) {
+	return s.store.GetOffers(lot)
+}
+
+// settle moves a pending invoice to the paid or defaulted status. The invoice is saved before the change is recorded in
+// the ledger and restored if the ledger rejects it, since a saved invoice can be replaced but a posted transaction
+// cannot be taken back.
+func (s *defaultSettler) settle(id id_generator.EventID, status Status, record func(invoice Invoice) error) (Invoice, error) {
+	pending, err := s.store.GetInvoice(id)
+	if err != nil {
+		return Invoice{}, err
+	}
+	if pending.Status != StatusPending {
+		return Invoice{}, &InvalidStatusError{id: id, status: pending.Status}
+	}
+	invoice := pending
+	invoice.Status = status
+	invoice.SettledAt = s.config.Now()
+	err = s.store.SaveInvoice(invoice)
+	if err != nil {
+		return Invoice{}, errors.Join(errors.New("failed to save invoice"), err)
+	}
+	err = record(invoice)
+	if err != nil {
+		restoreErr := s.store.SaveInvoice(pending)
+		return Invoice{}, errors.Join(fmt.Errorf("failed to record %s invoice", status), err, restoreErr)
+	}
+	return invoice, nil
+}
+
+// checkUnsold returns a LotAlreadySoldError if the lot has an invoice that is pending or paid. A lot whose invoices
+// have all been defaulted can be invoiced to the bidder who accepts its second-chance offer.
+func (s *defaultSettler) checkUnsold(lot LotID) error {
+	invoices, err := s.store.GetInvoices(lot)
+	if err != nil {
+		return errors.Join(errors.New("failed to fetch invoices"), err)
+	}
+	for _, invoice := range invoices {
+		if invoice.Status != StatusDefaulted {
+			return &LotAlreadySoldError{lot: lot}
+		}
+	}
+	return nil
+}
+
+// depositOf returns the deposit of the winner that was captured in the auction of the lot, up to the buyer total. It
+// is zero if the auction did not require a deposit, and a second-chance winner has none since their deposit was
+// released when the auction closed.
+func (s *defaultSettler) depositOf(lot Lot, bidder auction.Bidder, total currency.Amount) (currency.Amount, error) {
+	none := currency.FromMinorUnits(0, total.Code())
+	if s.config.Deposits == nil || lot.Auction == "" {
+		return none, nil
+	}
+	hold, err := s.config.Deposits.GetHold(lot.Auction, bidder)
+	var notFound *deposit.HoldNotFoundError
+	if errors.As(err, &notFound) {
+		return none, nil
+	} else if err != nil {
+		return currency.Amount{}, errors.Join(errors.New("failed to fetch deposit"), err)
+	}
+	if hold.Status != deposit.StatusCaptured {
+		return none, nil
+	}
+	cmp, err := hold.Amount.Cmp(total)
+	if err != nil {
+		return currency.Amount{}, errors.Join(errors.New("the deposit cannot be credited against the invoice"), err)
+	}
+	if cmp > 0 {
+		return total, nil
+	}
+	return hold.Amount, nil
+}
+
+func validateLot(lot Lot) error {
+	if lot.ID == "" {
+		return &InvalidLotError{message: "a lot must have an ID"}
+	}
+	if len(lot.Consignors) == 0 {
+		return &InvalidLotError{message: "a lot must have at least one consignor"}
+	}
+	return nil
+}
+
+// payoutsOf splits the seller payout between the consignors in proportion to their shares. The parts always add up to
+// the payout, so no minor unit is lost to rounding.
+func payoutsOf(lot Lot, breakdown fees.Breakdown) ([]Payout, error) {
+	shares := make([]int64, len(lot.Consignors))
+	for i, consignor := range lot.Consignors {
+		shares[i] = consignor.Share
+	}
+	parts, err := breakdown.SellerPayout.Allocate(shares...)
+	if err != nil {
+		return nil, errors.Join(&InvalidLotError{message: "the shares of the consignors cannot be allocated"}, err)
+	}
+	payouts := make([]Payout, len(parts))
+	for i, part := range parts {
+		payouts[i] = Payout{Seller: lot.Consignors[i].Seller, Amount: part}
+	}
+	return payouts, nil
+}
@@ -0,0 +1,515 @@
+package settlement
+
+import (
+	"auction/auction"
+	"auction/bid_manager"
+	"auction/currency"
+	"auction/deposit"
+	"auction/fees"
+	"auction/id_generator"
+	"auction/ledger"
+	"auction/registry"
+	"auction/storage"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func usd(major, minor int64) currency.Amount {
+	return currency.MustNew(major, minor, currency.USD)
+}
+
+func mockWinner() auction.WinningBid {
+	return auction.WinningBid{Bidder: "Pat", Amount: usd(85, 0)}
+}
+
+func mockLot() Lot {
+	return Lot{ID: "lot-1", Consignors: []Consignor{{Seller: "Riley", Share: 2}, {Seller: "Morgan", Share: 1}}}
+}
+
+func newSettler(t *testing.T) Settler {
+	settler, _ := newSettlerWithLedger(t)
+	return settler
+}
+
+func newSettlerWithLedger(t *testing.T) (Settler, ledger.Ledger) {
+	return newSettlerOf(t, NewMemoryStorage())
+}
+
+func newSettlerOf(t *testing.T, store Storer) (Settler, ledger.Ledger) {
+	settler, book, _ := newSettlerWithDeposits(t, store)
+	return settler, book
+}
+
+// newSettlerWithDeposits creates a settler whose deposits are held in the same ledger as its sales
+func newSettlerWithDeposits(t *testing.T, store Storer) (Settler, ledger.Ledger, deposit.Holds) {
+	return newSettlerWithConfig(t, store, Config{})
+}
+
+// newSettlerWithConfig creates a settler with the payment terms, clock and deposits of the tests set on the config
+func newSettlerWithConfig(t *testing.T, store Storer, config Config) (Settler, ledger.Ledger, deposit.Holds) {
+	calculator, err := fees.NewScheduleCalculator(fees.Schedule{
+		Currency:         currency.USD,
+		BuyersPremium:    []fees.Tier{{From: usd(0, 0), Rate: currency.NewRate(25, 0)}},
+		SellerCommission: []fees.Tier{{From: usd(0, 0), Rate: currency.NewRate(10, 0)}},
+		SalesTax:         map[fees.Jurisdiction]fees.Tax{"US-OR": {}},
+	})
+	if err != nil {
+		t.Fatalf("could not initialize calculator: %s", err.Error())
+	}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	config.PaymentTerms = 48 * time.Hour
+	config.Now = func() time.Time { return now }
+	book := ledger.NewLedger(ledger.Config{Now: config.Now}, id_generator.NewMemoryIDGenerator(), ledger.NewMemoryStorage())
+	config.Deposits = deposit.NewHolds(deposit.Config{Now: config.Now}, book, deposit.NewMemoryStorage())
+	return NewSettler(config, calculator, id_generator.NewMemoryIDGenerator(), book, store), book, config.Deposits
+}
+
+func TestInvoice(t *testing.T) {
+	settler := newSettler(t)
+	invoice, err := settler.Invoice(mockLot(), mockWinner(), "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice: %s", err.Error())
+	}
+
+	issued := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	if invoice.Bidder != "Pat" || invoice.HammerPrice != usd(85, 0) || invoice.Status != StatusPending {
+		t.Fatalf("Expected a pending invoice for Pat at $85.00, got %#v", invoice)
+	}
+	if !invoice.IssuedAt.Equal(issued) || !invoice.DueDate.Equal(issued.Add(48*time.Hour)) {
+		t.Fatalf("Expected the invoice to be due two days after it was issued, got %s and %s", invoice.IssuedAt, invoice.DueDate)
+	}
+	if invoice.Fees.BuyerTotal != usd(106, 25) || invoice.Fees.SellerPayout != usd(76, 50) {
+		t.Fatalf("Expected a buyer total of $106.25 and payout of $76.50, got %#v", invoice.Fees)
+	}
+	expectedPayouts := []Payout{{Seller: "Riley", Amount: usd(51, 0)}, {Seller: "Morgan", Amount: usd(25, 50)}}
+	if !reflect.DeepEqual(expectedPayouts, invoice.Payouts) {
+		t.Fatalf("Expected payouts %#v, got %#v", expectedPayouts, invoice.Payouts)
+	}
+
+	saved, err := settler.GetInvoice(invoice.ID)
+	if err != nil {
+		t.Fatalf("Failed to get invoice: %s", err.Error())
+	}
+	if !reflect.DeepEqual(invoice, saved) {
+		t.Fatalf("Expected %#v, got %#v", invoice, saved)
+	}
+
+	_, err = settler.Invoice(mockLot(), mockWinner(), "US-OR")
+	if _, ok := err.(*LotAlreadySoldError); !ok {
+		t.Fatalf("Expected LotAlreadySoldError but got %#v", err)
+	}
+}
+
+func TestInvoice_Invalid(t *testing.T) {
+	settler := newSettler(t)
+	_, err := settler.Invoice(Lot{ID: "lot-1"}, mockWinner(), "US-OR")
+	if _, ok := err.(*InvalidLotError); !ok {
+		t.Fatalf("Expected InvalidLotError but got %#v", err)
+	}
+	_, err = settler.Invoice(mockLot(), mockWinner(), "GB")
+	if err == nil {
+		t.Fatalf("Expected an error for a jurisdiction without sales tax")
+	}
+	_, err = settler.Invoice(mockLot(), auction.WinningBid{Bidder: "Pat", Amount: currency.MustNew(85, 0, currency.EUR)}, "US-OR")
+	if err == nil {
+		t.Fatalf("Expected an error for a winning bid in another currency")
+	}
+
+	// a lot that could not be invoiced can be invoiced once the problem is fixed
+	_, err = settler.Invoice(mockLot(), mockWinner(), "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice: %s", err.Error())
+	}
+}
+
+func TestMarkPaid(t *testing.T) {
+	settler := newSettler(t)
+	invoice, err := settler.Invoice(mockLot(), mockWinner(), "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice: %s", err.Error())
+	}
+
+	paid, err := settler.MarkPaid(invoice.ID)
+	if err != nil {
+		t.Fatalf("Failed to mark invoice paid: %s", err.Error())
+	}
+	if paid.Status != StatusPaid || paid.SettledAt.IsZero() {
+		t.Fatalf("Expected the invoice to be paid, got %#v", paid)
+	}
+
+	_, err = settler.MarkDefaulted(invoice.ID)
+	if _, ok := err.(*InvalidStatusError); !ok {
+		t.Fatalf("Expected InvalidStatusError but got %#v", err)
+	}
+	_, err = settler.MarkPaid(100)
+	if _, ok := err.(*InvoiceNotFoundError); !ok {
+		t.Fatalf("Expected InvoiceNotFoundError but got %#v", err)
+	}
+}
+
+func TestMarkPaid_Registry(t *testing.T) {
+	bidders := registry.NewRegistry(registry.Config{}, registry.NewMemoryStorage())
+	_, err := bidders.Register("Pat", "Pat", usd(200, 0))
+	if err == nil {
+		_, err = bidders.SetStatus("Pat", registry.StatusVerified)
+	}
+	if err == nil {
+		err = bidders.Reserve("Pat", "auction-1", usd(85, 0))
+	}
+	if err != nil {
+		t.Fatalf("Failed to register bidder: %s", err.Error())
+	}
+	calculator, err := fees.NewScheduleCalculator(fees.Schedule{Currency: currency.USD, SalesTax: map[fees.Jurisdiction]fees.Tax{"US-OR": {}}})
+	if err != nil {
+		t.Fatalf("could not initialize calculator: %s", err.Error())
+	}
+	book := ledger.NewLedger(ledger.Config{}, id_generator.NewMemoryIDGenerator(), ledger.NewMemoryStorage())
+	settler := NewSettler(Config{Registry: bidders}, calculator, id_generator.NewMemoryIDGenerator(), book, NewMemoryStorage())
+
+	lot := mockLot()
+	lot.Auction = "auction-1"
+	invoice, err := settler.Invoice(lot, mockWinner(), "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice: %s", err.Error())
+	}
+	// Pat's winning max bid stays outstanding until the lot is paid for
+	outstanding, err := bidders.Outstanding("Pat")
+	if err != nil || outstanding != usd(85, 0) {
+		t.Fatalf("Expected $85.00 outstanding before payment, got %s and %#v", outstanding, err)
+	}
+	_, err = settler.MarkPaid(invoice.ID)
+	if err != nil {
+		t.Fatalf("Failed to mark invoice paid: %s", err.Error())
+	}
+	outstanding, err = bidders.Outstanding("Pat")
+	if err != nil || !outstanding.IsZero() {
+		t.Fatalf("Expected nothing outstanding after payment, got %s and %#v", outstanding, err)
+	}
+}
+
+func TestMarkDefaulted(t *testing.T) {
+	settler := newSettler(t)
+	invoice, err := settler.Invoice(mockLot(), mockWinner(), "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice: %s", err.Error())
+	}
+
+	defaulted, err := settler.MarkDefaulted(invoice.ID)
+	if err != nil {
+		t.Fatalf("Failed to mark invoice defaulted: %s", err.Error())
+	}
+	if defaulted.Status != StatusDefaulted || defaulted.SettledAt.IsZero() {
+		t.Fatalf("Expected the invoice to be defaulted, got %#v", defaulted)
+	}
+	_, err = settler.MarkPaid(invoice.ID)
+	if _, ok := err.(*InvalidStatusError); !ok {
+		t.Fatalf("Expected InvalidStatusError but got %#v", err)
+	}
+}
+
+// TestSecondChance defaults the winner of an auction, which offers the lot to the runner up through the bid manager,
+// and invoices the runner up once they accept the offer
+func TestSecondChance(t *testing.T) {
+	generator := id_generator.NewMemoryIDGenerator()
+	manager, err := bid_manager.NewDefaultBidManager(generator, storage.NewMemoryBidStorage())
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	bids := [][4]string{{"Sasha", "$50.00", "$80.00", "$3.00"}, {"John", "$60.00", "$82.00", "$2.00"}, {"Pat", "$55.00", "$85.00", "$5.00"}}
+	for _, bid := range bids {
+		err = manager.AddBid(bid[0], bid[1], bid[2], bid[3])
+		if err != nil {
+			t.Fatalf("Failed to add bid: %s", err.Error())
+		}
+	}
+	err = manager.Close()
+	if err != nil {
+		t.Fatalf("Failed to close auction: %s", err.Error())
+	}
+	winner, err := manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+
+	defaulter := func(id auction.ID, bidder auction.Bidder) (auction.SecondChanceOffer, error) {
+		if id != "auction-1" {
+			t.Fatalf("Expected a default in auction-1, got %s", id)
+		}
+		return manager.DeclareDefault(string(bidder))
+	}
+	store := NewMemoryStorage()
+	settler, book, _ := newSettlerWithConfig(t, store, Config{Defaulter: defaulter})
+	lot := mockLot()
+	lot.Auction = "auction-1"
+	invoice, err := settler.Invoice(lot, winner, "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice: %s", err.Error())
+	}
+	_, err = settler.MarkDefaulted(invoice.ID)
+	if err != nil {
+		t.Fatalf("Failed to mark invoice defaulted: %s", err.Error())
+	}
+	// without Pat, John beats Sasha
+	offers, err := settler.GetOffers(lot.ID)
+	if err != nil {
+		t.Fatalf("Failed to get offers: %s", err.Error())
+	}
+	if len(offers) != 1 || offers[0].Invoice != invoice.ID || offers[0].Bidder != "John" || offers[0].Amount != usd(82, 0) {
+		t.Fatalf("Expected the lot to be offered to John at $82.00, got %#v", offers)
+	}
+	winner, err = manager.AcceptOffer(string(offers[0].Bidder))
+	if err != nil {
+		t.Fatalf("Failed to accept offer: %s", err.Error())
+	}
+
+	reissued, err := settler.Invoice(lot, winner, "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to invoice the runner up: %s", err.Error())
+	}
+	if reissued.Bidder != "John" || reissued.HammerPrice != usd(82, 0) || reissued.Status != StatusPending {
+		t.Fatalf("Expected a pending invoice for John at $82.00, got %#v", reissued)
+	}
+	_, err = settler.Invoice(lot, winner, "US-OR")
+	if _, ok := err.(*LotAlreadySoldError); !ok {
+		t.Fatalf("Expected LotAlreadySoldError but got %#v", err)
+	}
+	balances(t, book, map[ledger.Account]currency.Amount{
+		BuyerAccount("Pat"):  usd(0, 0),
+		BuyerAccount("John"): usd(102, 50),
+	})
+
+	// John defaults as well, and once Sasha has too there is nobody left to offer the lot to
+	_, err = settler.MarkDefaulted(reissued.ID)
+	if err != nil {
+		t.Fatalf("Failed to mark invoice defaulted: %s", err.Error())
+	}
+	winner, err = manager.AcceptOffer("Sasha")
+	if err != nil {
+		t.Fatalf("Failed to accept offer: %s", err.Error())
+	}
+	reissued, err = settler.Invoice(lot, winner, "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to invoice Sasha: %s", err.Error())
+	}
+	_, err = settler.MarkDefaulted(reissued.ID)
+	if err != nil {
+		t.Fatalf("Expected the last default to succeed without an offer, got %s", err.Error())
+	}
+	offers, err = settler.GetOffers(lot.ID)
+	if err != nil || len(offers) != 2 || offers[1].Bidder != "Sasha" {
+		t.Fatalf("Expected offers to John and Sasha only, got %#v and %#v", offers, err)
+	}
+}
+
+// failingDefaulter cannot reach the bid manager of the auction
+func failingDefaulter(auction.ID, auction.Bidder) (auction.SecondChanceOffer, error) {
+	return auction.SecondChanceOffer{}, errors.New("bid manager is unavailable")
+}
+
+func TestMarkDefaulted_DefaulterFailure(t *testing.T) {
+	settler, _, _ := newSettlerWithConfig(t, NewMemoryStorage(), Config{Defaulter: failingDefaulter})
+	lot := mockLot()
+	lot.Auction = "auction-1"
+	invoice, err := settler.Invoice(lot, mockWinner(), "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice: %s", err.Error())
+	}
+	defaulted, err := settler.MarkDefaulted(invoice.ID)
+	if err == nil {
+		t.Fatalf("Expected an error when the lot cannot be offered to the runner up")
+	}
+	if defaulted.Status != StatusDefaulted {
+		t.Fatalf("Expected the defaulted invoice to be returned with the error, got %#v", defaulted)
+	}
+}
+
+// failingStorage rejects saving invoices while fail is set
+type failingStorage struct {
+	Storer
+	fail bool
+}
+
+func (f *failingStorage) SaveInvoice(invoice Invoice) error {
+	if f.fail {
+		return errors.New("storage is unavailable")
+	}
+	return f.Storer.SaveInvoice(invoice)
+}
+
+func TestInvoice_StorageFailure(t *testing.T) {
+	store := &failingStorage{Storer: NewMemoryStorage(), fail: true}
+	settler, book := newSettlerOf(t, store)
+	_, err := settler.Invoice(mockLot(), mockWinner(), "US-OR")
+	if err == nil {
+		t.Fatalf("Expected an error when the invoice cannot be saved")
+	}
+	// the sale is reversed, so nothing is owed for an invoice that does not exist
+	balances(t, book, map[ledger.Account]currency.Amount{
+		BuyerAccount("Pat"):    usd(0, 0),
+		SellerAccount("Riley"): usd(0, 0),
+	})
+
+	store.fail = false
+	_, err = settler.Invoice(mockLot(), mockWinner(), "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice once the store recovered: %s", err.Error())
+	}
+}
+
+// failingLedger rejects every posting
+type failingLedger struct {
+	ledger.Ledger
+}
+
+func (f failingLedger) Post(string, ...ledger.Entry) (ledger.Transaction, error) {
+	return ledger.Transaction{}, errors.New("ledger is unavailable")
+}
+
+func (f failingLedger) Reverse(id_generator.EventID, string) (ledger.Transaction, error) {
+	return ledger.Transaction{}, errors.New("ledger is unavailable")
+}
+
+func TestSettle_LedgerFailure(t *testing.T) {
+	store := NewMemoryStorage()
+	settler, _ := newSettlerOf(t, store)
+	invoice, err := settler.Invoice(mockLot(), mockWinner(), "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice: %s", err.Error())
+	}
+
+	calculator, err := fees.NewScheduleCalculator(fees.Schedule{Currency: currency.USD, SalesTax: map[fees.Jurisdiction]fees.Tax{"US-OR": {}}})
+	if err != nil {
+		t.Fatalf("could not initialize calculator: %s", err.Error())
+	}
+	broken := NewSettler(Config{}, calculator, id_generator.NewMemoryIDGenerator(), failingLedger{}, store)
+	_, err = broken.MarkPaid(invoice.ID)
+	if err == nil {
+		t.Fatalf("Expected an error when the payment cannot be recorded")
+	}
+	_, err = broken.MarkDefaulted(invoice.ID)
+	if err == nil {
+		t.Fatalf("Expected an error when the default cannot be recorded")
+	}
+	saved, err := settler.GetInvoice(invoice.ID)
+	if err != nil {
+		t.Fatalf("Failed to get invoice: %s", err.Error())
+	}
+	if !reflect.DeepEqual(invoice, saved) {
+		t.Fatalf("Expected the invoice to still be pending, got %#v", saved)
+	}
+}
+
+// balances checks the USD balance of each account in the ledger
+func balances(t *testing.T, book ledger.Ledger, expected map[ledger.Account]currency.Amount) {
+	t.Helper()
+	for account, amount := range expected {
+		balance, err := book.Balance(account, currency.USD)
+		if err != nil {
+			t.Fatalf("Failed to get balance: %s", err.Error())
+		}
+		if balance != amount {
+			t.Fatalf("Expected %s to have a balance of %s, got %s", account, amount, balance)
+		}
+	}
+}
+
+func TestLedger(t *testing.T) {
+	settler, book := newSettlerWithLedger(t)
+	invoice, err := settler.Invoice(mockLot(), mockWinner(), "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice: %s", err.Error())
+	}
+	// Pat owes the hammer price and premium, the house has earned the premium and commission, and the consignors are
+	// owed their payouts
+	balances(t, book, map[ledger.Account]currency.Amount{
+		BuyerAccount("Pat"):                   usd(106, 25),
+		LotAccount("lot-1"):                   usd(0, 0),
+		FeeAccount(fees.KindBuyersPremium):    usd(-21, -25),
+		FeeAccount(fees.KindSellerCommission): usd(-8, -50),
+		SellerAccount("Riley"):                usd(-51, 0),
+		SellerAccount("Morgan"):               usd(-25, -50),
+		FeeAccount(fees.KindSalesTax):         usd(0, 0),
+	})
+
+	_, err = settler.MarkPaid(invoice.ID)
+	if err != nil {
+		t.Fatalf("Failed to mark invoice paid: %s", err.Error())
+	}
+	// the cash left once the consignors are paid is the fees of the house
+	balances(t, book, map[ledger.Account]currency.Amount{
+		BuyerAccount("Pat"):     usd(0, 0),
+		SellerAccount("Riley"):  usd(0, 0),
+		SellerAccount("Morgan"): usd(0, 0),
+		CashAccount:             usd(29, 75),
+	})
+	history, err := book.History(BuyerAccount("Pat"))
+	if err != nil {
+		t.Fatalf("Failed to get history: %s", err.Error())
+	}
+	if len(history) != 2 || history[0].ID != invoice.Transaction {
+		t.Fatalf("Expected the sale and the payment in Pat's history, got %#v", history)
+	}
+}
+
+func TestLedger_Deposit(t *testing.T) {
+	settler, book, holds := newSettlerWithDeposits(t, NewMemoryStorage())
+	for _, bidder := range []auction.Bidder{"Pat", "John"} {
+		_, err := holds.Place("auction-1", bidder, usd(50, 0))
+		if err != nil {
+			t.Fatalf("Failed to place hold: %s", err.Error())
+		}
+	}
+	_, err := holds.Settle("auction-1", "Pat")
+	if err != nil {
+		t.Fatalf("Failed to settle holds: %s", err.Error())
+	}
+
+	lot := mockLot()
+	lot.Auction = "auction-1"
+	invoice, err := settler.Invoice(lot, mockWinner(), "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice: %s", err.Error())
+	}
+	if invoice.Deposit != usd(50, 0) || invoice.AmountDue != usd(56, 25) {
+		t.Fatalf("Expected Pat's deposit of $50.00 to leave $56.25 due, got %#v", invoice)
+	}
+	// the captured deposit is credited to Pat, and the money held for it becomes cash
+	balances(t, book, map[ledger.Account]currency.Amount{
+		BuyerAccount("Pat"):              usd(56, 25),
+		deposit.CapturedAccount:          usd(0, 0),
+		deposit.DepositorAccount("Pat"):  usd(0, 0),
+		deposit.DepositorAccount("John"): usd(0, 0),
+		deposit.HeldAccount:              usd(0, 0),
+		CashAccount:                      usd(50, 0),
+	})
+
+	_, err = settler.MarkPaid(invoice.ID)
+	if err != nil {
+		t.Fatalf("Failed to mark invoice paid: %s", err.Error())
+	}
+	balances(t, book, map[ledger.Account]currency.Amount{
+		BuyerAccount("Pat"): usd(0, 0),
+		CashAccount:         usd(29, 75),
+	})
+}
+
+func TestLedger_Defaulted(t *testing.T) {
+	settler, book := newSettlerWithLedger(t)
+	invoice, err := settler.Invoice(mockLot(), mockWinner(), "US-OR")
+	if err != nil {
+		t.Fatalf("Failed to create invoice: %s", err.Error())
+	}
+	_, err = settler.MarkDefaulted(invoice.ID)
+	if err != nil {
+		t.Fatalf("Failed to mark invoice defaulted: %s", err.Error())
+	}
+	balances(t, book, map[ledger.Account]currency.Amount{
+		BuyerAccount("Pat"):                   usd(0, 0),
+		FeeAccount(fees.KindBuyersPremium):    usd(0, 0),
+		FeeAccount(fees.KindSellerCommission): usd(0, 0),
+		SellerAccount("Riley"):                usd(0, 0),
+		SellerAccount("Morgan"):               usd(0, 0),
+	})
+}
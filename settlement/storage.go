@@ -0,0 +1,15 @@
+package settlement
+
+import "auction/id_generator"
+
+type Storer interface {
+	// SaveInvoice creates or replaces an invoice
+	SaveInvoice(invoice Invoice) error
+	GetInvoice(id id_generator.EventID) (Invoice, error)
+	// GetInvoices returns the invoices of a lot ordered by ID
+	GetInvoices(lot LotID) ([]Invoice, error)
+	// SaveOffer creates or replaces the offer made when the buyer of its invoice defaulted
+	SaveOffer(offer Offer) error
+	// GetOffers returns the offers of a lot ordered by the invoice that was defaulted
+	GetOffers(lot LotID) ([]Offer, error)
+}
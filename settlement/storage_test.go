@@ -0,0 +1,112 @@
+package settlement
+
+import (
+	"auction/auction"
+	"auction/currency"
+	"auction/id_generator"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type storageTests struct {
+	storeFn func() Storer
+	t       *testing.T
+}
+
+func (g *storageTests) Run() {
+	tests := map[string]func(t *testing.T, store Storer){
+		"Test Invoices":          testInvoices,
+		"Test Invoice Not Found": testInvoiceNotFound,
+		"Test Offers":            testOffers,
+	}
+	for name, test := range tests {
+		g.t.Run(name, func(t *testing.T) {
+			test(t, g.storeFn())
+		})
+	}
+}
+
+func mockInvoice(id int, lot LotID, bidder auction.Bidder) Invoice {
+	return Invoice{
+		ID:          id_generator.EventID(id),
+		Lot:         lot,
+		Bidder:      bidder,
+		HammerPrice: currency.MustNew(86, 0, currency.USD),
+		IssuedAt:    time.Unix(100, 0),
+		DueDate:     time.Unix(200, 0),
+		Status:      StatusPending,
+	}
+}
+
+func testInvoices(t *testing.T, store Storer) {
+	invoices := []Invoice{mockInvoice(3, "lot-1", "Pat"), mockInvoice(1, "lot-1", "Sasha"), mockInvoice(2, "lot-2", "John")}
+	for _, invoice := range invoices {
+		err := store.SaveInvoice(invoice)
+		if err != nil {
+			t.Fatalf("Failed to save invoice: %s", err.Error())
+		}
+	}
+
+	paid := invoices[1]
+	paid.Status = StatusPaid
+	paid.SettledAt = time.Unix(150, 0)
+	err := store.SaveInvoice(paid)
+	if err != nil {
+		t.Fatalf("Failed to update invoice: %s", err.Error())
+	}
+
+	saved, err := store.GetInvoice(1)
+	if err != nil {
+		t.Fatalf("Failed to get invoice: %s", err.Error())
+	}
+	if !reflect.DeepEqual(paid, saved) {
+		t.Fatalf("Invoices do not match. Expected:\n%#v\nGot:\n%#v", paid, saved)
+	}
+
+	expected := []Invoice{paid, invoices[0]}
+	lotInvoices, err := store.GetInvoices("lot-1")
+	if err != nil {
+		t.Fatalf("Failed to get invoices: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, lotInvoices) {
+		t.Fatalf("Invoices do not match. Expected:\n%#v\nGot:\n%#v", expected, lotInvoices)
+	}
+}
+
+func testInvoiceNotFound(t *testing.T, store Storer) {
+	_, err := store.GetInvoice(1)
+	if _, ok := err.(*InvoiceNotFoundError); !ok {
+		t.Fatalf("Expected InvoiceNotFoundError but got %#v", err)
+	}
+	invoices, err := store.GetInvoices("lot-1")
+	if err != nil {
+		t.Fatalf("Failed to get invoices: %s", err.Error())
+	}
+	if len(invoices) != 0 {
+		t.Fatalf("Expected no invoices, got %#v", invoices)
+	}
+}
+
+func testOffers(t *testing.T, store Storer) {
+	offers := []Offer{
+		{Invoice: 4, Lot: "lot-1", Auction: "auction-1", Bidder: "Sasha", Amount: currency.MustNew(80, 0, currency.USD), ExpiresAt: time.Unix(300, 0)},
+		{Invoice: 2, Lot: "lot-1", Auction: "auction-1", Bidder: "John", Amount: currency.MustNew(82, 0, currency.USD), ExpiresAt: time.Unix(100, 0)},
+		{Invoice: 3, Lot: "lot-2", Auction: "auction-2", Bidder: "Pat", Amount: currency.MustNew(50, 0, currency.USD), ExpiresAt: time.Unix(200, 0)},
+	}
+	for _, offer := range offers {
+		err := store.SaveOffer(offer)
+		if err != nil {
+			t.Fatalf("Failed to save offer: %s", err.Error())
+		}
+	}
+
+	expected := []Offer{offers[1], offers[0]}
+	lotOffers, err := store.GetOffers("lot-1")
+	if err != nil {
+		t.Fatalf("Failed to get offers: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, lotOffers) {
+		t.Fatalf("Offers do not match. Expected:\n%#v\nGot:\n%#v", expected, lotOffers)
+	}
+}
@@ -2,24 +2,30 @@ package storage
 
 import (
 	"auction/auction"
+	"auction/currency"
 	"sync"
 )
 
-type memoryBidStorage struct {
-	bids map[auction.Bidder]auction.Bid
+type memoryBidStorage[M currency.Money[M]] struct {
+	bids map[auction.Bidder]auction.BidOf[M]
 	mtx  *sync.Mutex
 }
 
 func NewMemoryBidStorage() BidStorer {
-	return &memoryBidStorage{
-		bids: map[auction.Bidder]auction.Bid{},
+	return NewMemoryBidStorageOf[currency.Amount]()
+}
+
+// NewMemoryBidStorageOf creates an in-memory store for bids whose amounts are represented by M
+func NewMemoryBidStorageOf[M currency.Money[M]]() BidStorerOf[M] {
+	return &memoryBidStorage[M]{
+		bids: map[auction.Bidder]auction.BidOf[M]{},
 		mtx:  &sync.Mutex{},
 	}
 }
 
 // SaveBid is a concurrency safe save operation. This is so that if SaveBid and GetBid are called simultaneously
 // then it does not result in a concurrent read/write panic and so that GetBid always returns the true set of bids.
-func (m memoryBidStorage) SaveBid(bid auction.Bid) error {
+func (m memoryBidStorage[M]) SaveBid(bid auction.BidOf[M]) error {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 	if _, ok := m.bids[bid.Bidder]; ok {
@@ -29,21 +35,46 @@ func (m memoryBidStorage) SaveBid(bid auction.Bid) error {
 	return nil
 }
 
-func (m memoryBidStorage) GetBid(bidder auction.Bidder) (auction.Bid, error) {
+func (m memoryBidStorage[M]) UpdateBid(bid auction.BidOf[M]) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.bids[bid.Bidder]; !ok {
+		return &BidderNotFoundError{bidder: bid.Bidder}
+	}
+	m.bids[bid.Bidder] = bid
+	return nil
+}
+
+func (m memoryBidStorage[M]) DeleteBid(bidder auction.Bidder) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.bids[bidder]; !ok {
+		return &BidderNotFoundError{bidder: bidder}
+	}
+	delete(m.bids, bidder)
+	return nil
+}
+
+func (m memoryBidStorage[M]) GetBid(bidder auction.Bidder) (auction.BidOf[M], error) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 	if bid, ok := m.bids[bidder]; ok {
 		return bid, nil
 	} else {
-		return auction.Bid{}, &BidderNotFoundError{bidder: bid.Bidder}
+		return auction.BidOf[M]{}, &BidderNotFoundError{bidder: bidder}
 	}
 }
 
 // GetAllBids returns all bids. It currently returns a BidMap instead of a slice to make lookups easier.
 // This implementation assumes we are working with a small set of bids. If there were a significant amount of bids
 // expected, this would likely work better returning an iterator and using GetBid to lookup specific bidders instead.
-func (m memoryBidStorage) GetAllBids() (auction.BidMap, error) {
+// The map is a copy so that callers can iterate it without holding the lock or altering the stored bids.
+func (m memoryBidStorage[M]) GetAllBids() (auction.BidMapOf[M], error) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
-	return m.bids, nil
+	bids := make(auction.BidMapOf[M], len(m.bids))
+	for bidder, bid := range m.bids {
+		bids[bidder] = bid
+	}
+	return bids, nil
 }
@@ -1,9 +1,18 @@
 package storage
 
-import "auction/auction"
+import (
+	"auction/auction"
+	"auction/currency"
+)
 
-type BidStorer interface {
-	SaveBid(bid auction.Bid) error
-	GetBid(bidder auction.Bidder) (auction.Bid, error)
-	GetAllBids() (auction.BidMap, error)
+type BidStorer = BidStorerOf[currency.Amount]
+
+// BidStorerOf stores bids whose amounts are represented by M
+type BidStorerOf[M currency.Money[M]] interface {
+	SaveBid(bid auction.BidOf[M]) error
+	// UpdateBid replaces the bid of a bidder that has already bid
+	UpdateBid(bid auction.BidOf[M]) error
+	DeleteBid(bidder auction.Bidder) error
+	GetBid(bidder auction.Bidder) (auction.BidOf[M], error)
+	GetAllBids() (auction.BidMapOf[M], error)
 }
@@ -17,8 +17,13 @@ func (g *storageTests) Run() {
 		"Test Set Get":          testSetGet,
 		"Test Set Get Multiple": testSetGetMultiple,
 		"Test Get All":          testSetGetAll,
+		"Test Get All Copy":     testGetAllCopy,
 		"Test Duplicate Bid":    testDuplicateBidder,
 		"Test Bidder Not Found": testBidderNotFound,
+		"Test Update":           testUpdate,
+		"Test Update Not Found": testUpdateNotFound,
+		"Test Delete":           testDelete,
+		"Test Delete Not Found": testDeleteNotFound,
 	}
 	for name, test := range tests {
 		g.t.Run(name, func(t *testing.T) {
@@ -29,20 +34,11 @@ func (g *storageTests) Run() {
 
 func testSetGet(t *testing.T, store BidStorer) {
 	expBid := auction.Bid{
-		Bidder: auction.Bidder("mockBidder"),
-		StartingBid: currency.Amount{
-			Dollars: 1,
-			Cents:   20,
-		},
-		MaxBid: currency.Amount{
-			Dollars: 5,
-			Cents:   6,
-		},
-		Increment: currency.Amount{
-			Dollars: 0,
-			Cents:   20,
-		},
-		ID: 1,
+		Bidder:      auction.Bidder("mockBidder"),
+		StartingBid: currency.MustNew(1, 20, currency.USD),
+		MaxBid:      currency.MustNew(5, 6, currency.USD),
+		Increment:   currency.MustNew(0, 20, currency.USD),
+		ID:          1,
 	}
 	err := store.SaveBid(expBid)
 	if err != nil {
@@ -62,36 +58,18 @@ func testSetGet(t *testing.T, store BidStorer) {
 func testSetGetMultiple(t *testing.T, store BidStorer) {
 	expBids := []auction.Bid{
 		{
-			Bidder: auction.Bidder("mockBidder"),
-			StartingBid: currency.Amount{
-				Dollars: 1,
-				Cents:   20,
-			},
-			MaxBid: currency.Amount{
-				Dollars: 5,
-				Cents:   6,
-			},
-			Increment: currency.Amount{
-				Dollars: 0,
-				Cents:   20,
-			},
-			ID: 1,
+			Bidder:      auction.Bidder("mockBidder"),
+			StartingBid: currency.MustNew(1, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 6, currency.USD),
+			Increment:   currency.MustNew(0, 20, currency.USD),
+			ID:          1,
 		},
 		{
-			Bidder: auction.Bidder("mockBidder2"),
-			StartingBid: currency.Amount{
-				Dollars: 3,
-				Cents:   45,
-			},
-			MaxBid: currency.Amount{
-				Dollars: 6,
-				Cents:   33,
-			},
-			Increment: currency.Amount{
-				Dollars: 1,
-				Cents:   5,
-			},
-			ID: 2,
+			Bidder:      auction.Bidder("mockBidder2"),
+			StartingBid: currency.MustNew(3, 45, currency.USD),
+			MaxBid:      currency.MustNew(6, 33, currency.USD),
+			Increment:   currency.MustNew(1, 5, currency.USD),
+			ID:          2,
 		},
 	}
 	for _, bid := range expBids {
@@ -116,52 +94,25 @@ func testSetGetMultiple(t *testing.T, store BidStorer) {
 func testSetGetAll(t *testing.T, store BidStorer) {
 	expBids := auction.BidMap{
 		auction.Bidder("mockBidder"): {
-			Bidder: auction.Bidder("mockBidder"),
-			StartingBid: currency.Amount{
-				Dollars: 1,
-				Cents:   20,
-			},
-			MaxBid: currency.Amount{
-				Dollars: 5,
-				Cents:   6,
-			},
-			Increment: currency.Amount{
-				Dollars: 0,
-				Cents:   20,
-			},
-			ID: 1,
+			Bidder:      auction.Bidder("mockBidder"),
+			StartingBid: currency.MustNew(1, 20, currency.USD),
+			MaxBid:      currency.MustNew(5, 6, currency.USD),
+			Increment:   currency.MustNew(0, 20, currency.USD),
+			ID:          1,
 		},
 		auction.Bidder("mockBidder2"): {
-			Bidder: auction.Bidder("mockBidder2"),
-			StartingBid: currency.Amount{
-				Dollars: 3,
-				Cents:   45,
-			},
-			MaxBid: currency.Amount{
-				Dollars: 6,
-				Cents:   33,
-			},
-			Increment: currency.Amount{
-				Dollars: 1,
-				Cents:   5,
-			},
-			ID: 2,
+			Bidder:      auction.Bidder("mockBidder2"),
+			StartingBid: currency.MustNew(3, 45, currency.USD),
+			MaxBid:      currency.MustNew(6, 33, currency.USD),
+			Increment:   currency.MustNew(1, 5, currency.USD),
+			ID:          2,
 		},
 		auction.Bidder("mockBidder3"): {
-			Bidder: auction.Bidder("mockBidder3"),
-			StartingBid: currency.Amount{
-				Dollars: 5,
-				Cents:   12,
-			},
-			MaxBid: currency.Amount{
-				Dollars: 8,
-				Cents:   45,
-			},
-			Increment: currency.Amount{
-				Dollars: 0,
-				Cents:   1,
-			},
-			ID: 3,
+			Bidder:      auction.Bidder("mockBidder3"),
+			StartingBid: currency.MustNew(5, 12, currency.USD),
+			MaxBid:      currency.MustNew(8, 45, currency.USD),
+			Increment:   currency.MustNew(0, 1, currency.USD),
+			ID:          3,
 		},
 	}
 	for _, bid := range expBids {
@@ -185,22 +136,42 @@ func testSetGetAll(t *testing.T, store BidStorer) {
 	}
 }
 
+func testGetAllCopy(t *testing.T, store BidStorer) {
+	bid := auction.Bid{
+		Bidder:      auction.Bidder("mockBidder"),
+		StartingBid: currency.MustNew(1, 20, currency.USD),
+		MaxBid:      currency.MustNew(5, 6, currency.USD),
+		Increment:   currency.MustNew(0, 20, currency.USD),
+		ID:          1,
+	}
+	err := store.SaveBid(bid)
+	if err != nil {
+		t.Fatalf("Failed to save bid: %s", err.Error())
+	}
+
+	bids, err := store.GetAllBids()
+	if err != nil {
+		t.Fatalf("Failed to get bids: %s", err.Error())
+	}
+	delete(bids, bid.Bidder)
+	bids[auction.Bidder("mockBidder2")] = bid
+
+	recBids, err := store.GetAllBids()
+	if err != nil {
+		t.Fatalf("Failed to get bids: %s", err.Error())
+	}
+	if !reflect.DeepEqual(auction.BidMap{bid.Bidder: bid}, recBids) {
+		t.Fatalf("Expected changes to the returned map to leave the store alone, got:\n%#v", recBids)
+	}
+}
+
 func testDuplicateBidder(t *testing.T, store BidStorer) {
 	bid := auction.Bid{
-		Bidder: auction.Bidder("mockBidder"),
-		StartingBid: currency.Amount{
-			Dollars: 1,
-			Cents:   20,
-		},
-		MaxBid: currency.Amount{
-			Dollars: 5,
-			Cents:   6,
-		},
-		Increment: currency.Amount{
-			Dollars: 0,
-			Cents:   20,
-		},
-		ID: 1,
+		Bidder:      auction.Bidder("mockBidder"),
+		StartingBid: currency.MustNew(1, 20, currency.USD),
+		MaxBid:      currency.MustNew(5, 6, currency.USD),
+		Increment:   currency.MustNew(0, 20, currency.USD),
+		ID:          1,
 	}
 	err := store.SaveBid(bid)
 	if err != nil {
@@ -218,20 +189,11 @@ func testDuplicateBidder(t *testing.T, store BidStorer) {
 
 func testBidderNotFound(t *testing.T, store BidStorer) {
 	expBid := auction.Bid{
-		Bidder: auction.Bidder("mockBidder"),
-		StartingBid: currency.Amount{
-			Dollars: 1,
-			Cents:   20,
-		},
-		MaxBid: currency.Amount{
-			Dollars: 5,
-			Cents:   6,
-		},
-		Increment: currency.Amount{
-			Dollars: 0,
-			Cents:   20,
-		},
-		ID: 1,
+		Bidder:      auction.Bidder("mockBidder"),
+		StartingBid: currency.MustNew(1, 20, currency.USD),
+		MaxBid:      currency.MustNew(5, 6, currency.USD),
+		Increment:   currency.MustNew(0, 20, currency.USD),
+		ID:          1,
 	}
 	err := store.SaveBid(expBid)
 	if err != nil {
@@ -246,3 +208,79 @@ func testBidderNotFound(t *testing.T, store BidStorer) {
 		t.Fatalf("Expected a bidder not found error and received a different error instead: %v", err)
 	}
 }
+
+func testUpdate(t *testing.T, store BidStorer) {
+	bid := auction.Bid{
+		Bidder:      auction.Bidder("mockBidder"),
+		StartingBid: currency.MustNew(1, 20, currency.USD),
+		MaxBid:      currency.MustNew(5, 6, currency.USD),
+		Increment:   currency.MustNew(0, 20, currency.USD),
+		ID:          1,
+	}
+	err := store.SaveBid(bid)
+	if err != nil {
+		t.Fatalf("Failed to save bid: %s", err.Error())
+	}
+
+	bid.MaxBid = currency.MustNew(10, 0, currency.USD)
+	err = store.UpdateBid(bid)
+	if err != nil {
+		t.Fatalf("Failed to update bid: %s", err.Error())
+	}
+
+	recBid, err := store.GetBid(bid.Bidder)
+	if err != nil {
+		t.Fatalf("Failed to get bid: %s", err.Error())
+	}
+	if !reflect.DeepEqual(bid, recBid) {
+		t.Fatalf("Bids do not match. Expected:\n%#v\nGot:\n%#v", bid, recBid)
+	}
+}
+
+func testUpdateNotFound(t *testing.T, store BidStorer) {
+	err := store.UpdateBid(auction.Bid{Bidder: auction.Bidder("mockBidder")})
+	if err == nil {
+		t.Fatalf("Expected a bidder not found error and did not receive one")
+	}
+	if _, ok := err.(*BidderNotFoundError); !ok {
+		t.Fatalf("Expected a bidder not found error and received a different error instead: %v", err)
+	}
+}
+
+func testDelete(t *testing.T, store BidStorer) {
+	bid := auction.Bid{
+		Bidder:      auction.Bidder("mockBidder"),
+		StartingBid: currency.MustNew(1, 20, currency.USD),
+		MaxBid:      currency.MustNew(5, 6, currency.USD),
+		Increment:   currency.MustNew(0, 20, currency.USD),
+		ID:          1,
+	}
+	err := store.SaveBid(bid)
+	if err != nil {
+		t.Fatalf("Failed to save bid: %s", err.Error())
+	}
+
+	err = store.DeleteBid(bid.Bidder)
+	if err != nil {
+		t.Fatalf("Failed to delete bid: %s", err.Error())
+	}
+	_, err = store.GetBid(bid.Bidder)
+	if _, ok := err.(*BidderNotFoundError); !ok {
+		t.Fatalf("Expected a bidder not found error and received a different error instead: %v", err)
+	}
+
+	err = store.SaveBid(bid)
+	if err != nil {
+		t.Fatalf("Expected bidder to be able to bid again after deleting: %s", err.Error())
+	}
+}
+
+func testDeleteNotFound(t *testing.T, store BidStorer) {
+	err := store.DeleteBid(auction.Bidder("mockBidder"))
+	if err == nil {
+		t.Fatalf("Expected a bidder not found error and did not receive one")
+	}
+	if _, ok := err.(*BidderNotFoundError); !ok {
+		t.Fatalf("Expected a bidder not found error and received a different error instead: %v", err)
+	}
+}
@@ -0,0 +1,376 @@
+package webhook
+
+import (
+	"auction/events"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// httpDispatcher posts events to the configured endpoints. Every delivery is saved as pending once its event is
+// received, so deliveries that have not succeeded survive a restart when a persistent DeliveryStorer is used. Events
+// are received, saved and delivered by separate goroutines, and received events wait in a queue in memory until they
+// are saved, so neither a slow endpoint nor a store that is down ever holds up the publisher.
+type httpDispatcher struct {
+	config    Config
+	endpoints map[string]Endpoint
+	store     DeliveryStorer
+	mtx       *sync.Mutex
+	sub       events.Subscription
+	// queue holds the events that have been received but not saved yet
+	queue    []events.Event
+	queueMtx *sync.Mutex
+	// queued tells the saving goroutine that events have been received
+	queued chan struct{}
+	// wake tells the delivery goroutine that new deliveries have been saved
+	wake     chan struct{}
+	stop     chan struct{}
+	received chan struct{}
+	saved    chan struct{}
+	done     chan struct{}
+}
+
+func NewDispatcher(config Config, store DeliveryStorer) (Dispatcher, error) {
+	if config.MaxAttempts == 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+	if config.InitialBackoff == 0 {
+		config.InitialBackoff = defaultInitialBackoff
+	}
+	if config.MaxBackoff == 0 {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+	if config.RetryInterval == 0 {
+		config.RetryInterval = defaultRetryInterval
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: defaultTimeout}
+	}
+
+	endpoints := map[string]Endpoint{}
+	for _, endpoint := range config.Endpoints {
+		if endpoint.Name == "" || endpoint.URL == "" {
+			return nil, &InvalidConfigError{message: "endpoints must have a name and url"}
+		}
+		if _, ok := endpoints[endpoint.Name]; ok {
+			return nil, &InvalidConfigError{message: fmt.Sprintf("endpoint %s is configured more than once", endpoint.Name)}
+		}
+		endpoints[endpoint.Name] = endpoint
+	}
+
+	return &httpDispatcher{
+		config:    config,
+		endpoints: endpoints,
+		store:     store,
+		mtx:       &sync.Mutex{},
+		queueMtx:  &sync.Mutex{},
+	}, nil
+}
+
+// subscriptionBuffer lets the manager keep publishing while a received event is being queued
+const subscriptionBuffer = 256
+
+// Start uses a blocking subscription, as a dropped event would never be delivered. The publisher only waits for events
+// to be queued, not for them to be saved or delivered.
+func (d *httpDispatcher) Start(broker events.Broker) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.sub != nil {
+		return &DispatcherRunningError{}
+	}
+
+	d.sub = broker.Subscribe(subscriptionBuffer, events.Block, d.subscribedKinds()...)
+	d.queued = make(chan struct{}, 1)
+	d.wake = make(chan struct{}, 1)
+	d.stop = make(chan struct{})
+	d.received = make(chan struct{})
+	d.saved = make(chan struct{})
+	d.done = make(chan struct{})
+	go d.receive(d.sub, d.queued, d.received)
+	go d.save(d.queued, d.wake, d.stop, d.received, d.saved)
+	go d.deliver(d.wake, d.stop, d.done)
+	return nil
+}
+
+// Stop closes the subscription and saves the events left in its buffer and the queue as pending deliveries, which are
+// sent when the dispatcher is started again
+func (d *httpDispatcher) Stop() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.sub == nil {
+		return
+	}
+	close(d.stop)
+	d.sub.Close()
+	<-d.received
+	<-d.saved
+	<-d.done
+	d.sub = nil
+}
+
+func (d *httpDispatcher) Redeliver(id string) error {
+	deadLetters, err := d.store.GetDeadLetters()
+	if err != nil {
+		return errors.Join(errors.New("failed to fetch dead letters"), err)
+	}
+	for _, delivery := range deadLetters {
+		if delivery.ID != id {
+			continue
+		}
+		delivery.Attempts = 0
+		delivery.NextAttempt = time.Now()
+		delivery.LastError = ""
+		err = d.store.SavePending(delivery)
+		if err != nil {
+			return errors.Join(errors.New("failed to save delivery"), err)
+		}
+		return d.store.DeleteDeadLetter(id)
+	}
+	return &DeliveryNotFoundError{id: id}
+}
+
+// subscribedKinds returns the kinds needed by the endpoints. No kinds are returned if any endpoint wants every event.
+func (d *httpDispatcher) subscribedKinds() []events.Kind {
+	kinds := []events.Kind{}
+	for _, endpoint := range d.endpoints {
+		if len(endpoint.Kinds) == 0 {
+			return nil
+		}
+		kinds = append(kinds, endpoint.Kinds...)
+	}
+	return kinds
+}
+
+// receive queues every event until the subscription is closed, which leaves the events that were still buffered to be
+// queued before it returns
+func (d *httpDispatcher) receive(sub events.Subscription, queued, done chan struct{}) {
+	defer close(done)
+	for event := range sub.Events() {
+		d.queueMtx.Lock()
+		d.queue = append(d.queue, event)
+		d.queueMtx.Unlock()
+		signal(queued)
+	}
+}
+
+// save saves the deliveries of the queued events until the dispatcher stops, then saves what was received before the
+// subscription closed
+func (d *httpDispatcher) save(queued, wake, stop, received, done chan struct{}) {
+	defer close(done)
+	for {
+		select {
+		case <-queued:
+			d.saveQueued(wake, stop)
+		case <-stop:
+			<-received
+			d.saveQueued(wake, stop)
+			return
+		}
+	}
+}
+
+// saveQueued saves the queued events in the order they were received
+func (d *httpDispatcher) saveQueued(wake, stop chan struct{}) {
+	for {
+		d.queueMtx.Lock()
+		if len(d.queue) == 0 {
+			d.queueMtx.Unlock()
+			return
+		}
+		event := d.queue[0]
+		d.queue = d.queue[1:]
+		d.queueMtx.Unlock()
+
+		d.persist(event, stop)
+		signal(wake)
+	}
+}
+
+// signal wakes the goroutine waiting on the channel, unless it has already been woken
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// persist saves the deliveries of the event, retrying while the store fails so that the event is not lost. The
+// deliveries are created once, so a retry replaces the deliveries that were already saved rather than adding new ones.
+// Once the dispatcher is stopping, each event is only attempted once so that Stop returns.
+func (d *httpDispatcher) persist(event events.Event, stop chan struct{}) {
+	deliveries, err := d.deliveriesOf(event)
+	for err == nil && d.enqueue(deliveries) != nil {
+		select {
+		case <-stop:
+			return
+		case <-time.After(d.config.RetryInterval):
+		}
+	}
+}
+
+func (d *httpDispatcher) deliver(wake, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(d.config.RetryInterval)
+	defer ticker.Stop()
+
+	d.retryPending()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-wake:
+			d.retryPending()
+		case <-ticker.C:
+			d.retryPending()
+		}
+	}
+}
+
+// deliveriesOf creates a delivery of the event for every endpoint that wants it. An event that cannot be encoded can
+// never be delivered, so its deliveries carry the reason and are saved straight to the dead letters.
+func (d *httpDispatcher) deliveriesOf(event events.Event) ([]Delivery, error) {
+	body, err := json.Marshal(events.NewPayload(event))
+	lastError := ""
+	if err != nil {
+		lastError = (&EncodingError{err: err}).Error()
+	}
+	deliveries := []Delivery{}
+	for _, endpoint := range d.endpoints {
+		if !wants(endpoint, event.Kind()) {
+			continue
+		}
+		id, err := newDeliveryID()
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, Delivery{
+			ID:          id,
+			EventID:     event.EventID(),
+			Auction:     event.AuctionID(),
+			Kind:        event.Kind(),
+			Endpoint:    endpoint.Name,
+			Payload:     body,
+			NextAttempt: time.Now(),
+			LastError:   lastError,
+		})
+	}
+	return deliveries, nil
+}
+
+// enqueue saves the deliveries as pending, or as dead letters if their event could not be encoded
+func (d *httpDispatcher) enqueue(deliveries []Delivery) error {
+	var err error
+	for _, delivery := range deliveries {
+		if delivery.LastError != "" {
+			err = errors.Join(err, d.store.SaveDeadLetter(delivery))
+			continue
+		}
+		err = errors.Join(err, d.store.SavePending(delivery))
+	}
+	return err
+}
+
+// newDeliveryID returns a random ID, which partners can use to ignore a delivery they have already received
+func newDeliveryID() (string, error) {
+	id := make([]byte, 16)
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", errors.Join(errors.New("failed to generate delivery ID"), err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func wants(endpoint Endpoint, kind events.Kind) bool {
+	if len(endpoint.Kinds) == 0 {
+		return true
+	}
+	for _, k := range endpoint.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// retryPending attempts every pending delivery that is due. A failed delivery is rescheduled with exponential backoff
+// until it runs out of attempts, then it is moved to the dead letters.
+func (d *httpDispatcher) retryPending() {
+	pending, err := d.store.GetPending()
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	for _, delivery := range pending {
+		if delivery.NextAttempt.After(now) {
+			continue
+		}
+
+		err = d.send(delivery)
+		delivery.Attempts++
+		if err == nil {
+			_ = d.store.DeletePending(delivery.ID)
+			continue
+		}
+
+		delivery.LastError = err.Error()
+		var notFound *EndpointNotFoundError
+		var encoding *EncodingError
+		if delivery.Attempts >= d.config.MaxAttempts || errors.As(err, &notFound) || errors.As(err, &encoding) {
+			if d.store.SaveDeadLetter(delivery) == nil {
+				_ = d.store.DeletePending(delivery.ID)
+			}
+			continue
+		}
+		delivery.NextAttempt = now.Add(d.backoff(delivery.Attempts))
+		_ = d.store.SavePending(delivery)
+	}
+}
+
+func (d *httpDispatcher) backoff(attempts int) time.Duration {
+	backoff := d.config.InitialBackoff
+	for i := 1; i < attempts && backoff < d.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > d.config.MaxBackoff {
+		return d.config.MaxBackoff
+	}
+	return backoff
+}
+
+func (d *httpDispatcher) send(delivery Delivery) error {
+	endpoint, ok := d.endpoints[delivery.Endpoint]
+	if !ok {
+		return &EndpointNotFoundError{name: delivery.Endpoint}
+	}
+	if len(delivery.Payload) == 0 {
+		return &EncodingError{}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(delivery.Kind))
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &UnexpectedStatusError{status: resp.StatusCode}
+	}
+	return nil
+}
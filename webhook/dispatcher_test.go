@@ -0,0 +1,508 @@
+package webhook
+
+import (
+	"auction/auction"
+	"auction/bid_manager"
+	"auction/events"
+	"auction/id_generator"
+	"auction/storage"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+var mockSecret = []byte("mockSecret")
+
+// mockEndpoint records the payloads it receives and fails the first failures requests
+type mockEndpoint struct {
+	mtx      sync.Mutex
+	failures int
+	requests int
+	payloads []events.Payload
+	t        *testing.T
+}
+
+func (e *mockEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.requests++
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		e.t.Errorf("Failed to read body: %s", err.Error())
+	}
+	if !Verify(mockSecret, r.Header.Get(TimestampHeader), body, r.Header.Get(SignatureHeader)) {
+		e.t.Errorf("Invalid signature %s", r.Header.Get(SignatureHeader))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if e.failures > 0 {
+		e.failures--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var payload events.Payload
+	err = json.Unmarshal(body, &payload)
+	if err != nil {
+		e.t.Errorf("Failed to decode payload: %s", err.Error())
+	}
+	e.payloads = append(e.payloads, payload)
+}
+
+func (e *mockEndpoint) received() ([]events.Payload, int) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return append([]events.Payload{}, e.payloads...), e.requests
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func newTestDispatcher(t *testing.T, url string, store DeliveryStorer, kinds ...events.Kind) Dispatcher {
+	dispatcher, err := NewDispatcher(Config{
+		Endpoints:      []Endpoint{{Name: "partner", URL: url, Secret: mockSecret, Kinds: kinds}},
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		RetryInterval:  time.Millisecond,
+	}, store)
+	if err != nil {
+		t.Fatalf("could not initialize dispatcher: %s", err.Error())
+	}
+	return dispatcher
+}
+
+func TestDispatcherDelivers(t *testing.T) {
+	endpoint := &mockEndpoint{t: t}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	broker := events.NewMemoryBroker()
+	dispatcher := newTestDispatcher(t, server.URL, NewMemoryDeliveryStorage(), events.KindBidderOutbid, events.KindWinnerDetermined)
+	err := dispatcher.Start(broker)
+	if err != nil {
+		t.Fatalf("Failed to start dispatcher: %s", err.Error())
+	}
+	defer dispatcher.Stop()
+
+	manager, err := bid_manager.NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(), bid_manager.WithPublisher(broker))
+	if err != nil {
+		t.Fatalf("could not initialize manager: %s", err.Error())
+	}
+	for _, bid := range [][]string{{"Sasha", "$50.00", "$80.00", "$3.00"}, {"Pat", "$55.00", "$85.00", "$5.00"}} {
+		err = manager.AddBid(bid[0], bid[1], bid[2], bid[3])
+		if err != nil {
+			t.Fatalf("Failed to add bid: %s", err.Error())
+		}
+	}
+	_, err = manager.CalculateWinner()
+	if err != nil {
+		t.Fatalf("Failed to calculate winner: %s", err.Error())
+	}
+
+	waitFor(t, func() bool {
+		payloads, _ := endpoint.received()
+		return len(payloads) == 2
+	})
+	payloads, _ := endpoint.received()
+	if payloads[0].Kind != events.KindBidderOutbid || payloads[0].Bidder != auction.Bidder("Sasha") || payloads[0].Leader != auction.Bidder("Pat") {
+		t.Fatalf("Unexpected outbid payload %#v", payloads[0])
+	}
+	if payloads[1].Kind != events.KindWinnerDetermined || payloads[1].Bidder != auction.Bidder("Pat") {
+		t.Fatalf("Unexpected winner payload %#v", payloads[1])
+	}
+}
+
+// TestDispatcherAuctions delivers the events of two auctions that draw the same event IDs from their own generators
+func TestDispatcherAuctions(t *testing.T) {
+	endpoint := &mockEndpoint{t: t}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	broker := events.NewMemoryBroker()
+	dispatcher := newTestDispatcher(t, server.URL, NewMemoryDeliveryStorage(), events.KindBidPlaced)
+	err := dispatcher.Start(broker)
+	if err != nil {
+		t.Fatalf("Failed to start dispatcher: %s", err.Error())
+	}
+	defer dispatcher.Stop()
+
+	for _, id := range []auction.ID{"auction-1", "auction-2"} {
+		manager, err := bid_manager.NewDefaultBidManager(id_generator.NewMemoryIDGenerator(), storage.NewMemoryBidStorage(),
+			bid_manager.WithAuctionID(id), bid_manager.WithPublisher(broker))
+		if err != nil {
+			t.Fatalf("could not initialize manager: %s", err.Error())
+		}
+		err = manager.AddBid("Sasha", "$50.00", "$80.00", "$3.00")
+		if err != nil {
+			t.Fatalf("Failed to add bid: %s", err.Error())
+		}
+	}
+
+	waitFor(t, func() bool {
+		payloads, _ := endpoint.received()
+		return len(payloads) == 2
+	})
+	payloads, _ := endpoint.received()
+	if payloads[0].ID != payloads[1].ID || payloads[0].Auction == payloads[1].Auction {
+		t.Fatalf("Expected the bid of each auction with the same event ID, got %#v", payloads)
+	}
+}
+
+func TestDispatcherRetries(t *testing.T) {
+	endpoint := &mockEndpoint{t: t, failures: 2}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	store := NewMemoryDeliveryStorage()
+	broker := events.NewMemoryBroker()
+	dispatcher := newTestDispatcher(t, server.URL, store)
+	err := dispatcher.Start(broker)
+	if err != nil {
+		t.Fatalf("Failed to start dispatcher: %s", err.Error())
+	}
+	defer dispatcher.Stop()
+
+	broker.Publish(events.AuctionClosed{Header: events.Header{ID: 1}})
+	waitFor(t, func() bool {
+		payloads, _ := endpoint.received()
+		return len(payloads) == 1
+	})
+	if _, requests := endpoint.received(); requests != 3 {
+		t.Fatalf("Expected 3 requests, got %d", requests)
+	}
+	waitFor(t, func() bool {
+		pending, _ := store.GetPending()
+		return len(pending) == 0
+	})
+}
+
+func TestDispatcherDeadLetter(t *testing.T) {
+	endpoint := &mockEndpoint{t: t, failures: 3}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	store := NewMemoryDeliveryStorage()
+	broker := events.NewMemoryBroker()
+	dispatcher := newTestDispatcher(t, server.URL, store)
+	err := dispatcher.Start(broker)
+	if err != nil {
+		t.Fatalf("Failed to start dispatcher: %s", err.Error())
+	}
+	defer dispatcher.Stop()
+
+	broker.Publish(events.AuctionClosed{Header: events.Header{ID: 1}})
+	var deadLetters []Delivery
+	waitFor(t, func() bool {
+		deadLetters, _ = store.GetDeadLetters()
+		return len(deadLetters) == 1
+	})
+	if deadLetters[0].Attempts != 3 || deadLetters[0].LastError == "" {
+		t.Fatalf("Unexpected dead letter %#v", deadLetters[0])
+	}
+
+	err = dispatcher.Redeliver(deadLetters[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to redeliver: %s", err.Error())
+	}
+	waitFor(t, func() bool {
+		payloads, _ := endpoint.received()
+		return len(payloads) == 1
+	})
+
+	err = dispatcher.Redeliver("missing")
+	if _, ok := err.(*DeliveryNotFoundError); !ok {
+		t.Fatalf("Expected a delivery not found error and received a different error instead: %v", err)
+	}
+}
+
+func TestDispatcherResumesPending(t *testing.T) {
+	endpoint := &mockEndpoint{t: t}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	store := NewMemoryDeliveryStorage()
+	body, _ := json.Marshal(events.Payload{ID: 7, Kind: events.KindAuctionClosed})
+	err := store.SavePending(Delivery{ID: "7-partner", EventID: 7, Kind: events.KindAuctionClosed, Endpoint: "partner", Payload: body})
+	if err != nil {
+		t.Fatalf("Failed to save delivery: %s", err.Error())
+	}
+
+	dispatcher := newTestDispatcher(t, server.URL, store)
+	err = dispatcher.Start(events.NewMemoryBroker())
+	if err != nil {
+		t.Fatalf("Failed to start dispatcher: %s", err.Error())
+	}
+	defer dispatcher.Stop()
+
+	waitFor(t, func() bool {
+		payloads, _ := endpoint.received()
+		return len(payloads) == 1
+	})
+	if payloads, _ := endpoint.received(); payloads[0].ID != 7 {
+		t.Fatalf("Expected event 7 to be delivered, got %d", payloads[0].ID)
+	}
+}
+
+// TestDispatcherSlowEndpoint publishes more events than the subscription buffer holds while the endpoint is stuck, which
+// must not block the publisher
+func TestDispatcherSlowEndpoint(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	store := NewMemoryDeliveryStorage()
+	broker := events.NewMemoryBroker()
+	dispatcher := newTestDispatcher(t, server.URL, store)
+	err := dispatcher.Start(broker)
+	if err != nil {
+		t.Fatalf("Failed to start dispatcher: %s", err.Error())
+	}
+	defer dispatcher.Stop()
+	// the endpoint is released before stopping, since Stop waits for the delivery in progress
+	defer close(release)
+
+	published := make(chan struct{})
+	go func() {
+		for i := 1; i <= 2*subscriptionBuffer; i++ {
+			broker.Publish(events.AuctionClosed{Header: events.Header{ID: id_generator.EventID(i)}})
+		}
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Publishing was blocked by the endpoint")
+	}
+	waitFor(t, func() bool {
+		pending, _ := store.GetPending()
+		return len(pending) == 2*subscriptionBuffer
+	})
+}
+
+// blockingStorage holds up saving pending deliveries until it is released
+type blockingStorage struct {
+	DeliveryStorer
+	release chan struct{}
+}
+
+func (b blockingStorage) SavePending(delivery Delivery) error {
+	<-b.release
+	return b.DeliveryStorer.SavePending(delivery)
+}
+
+func TestDispatcherStopDrains(t *testing.T) {
+	endpoint := &mockEndpoint{t: t, failures: 100}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	store := blockingStorage{DeliveryStorer: NewMemoryDeliveryStorage(), release: make(chan struct{})}
+	broker := events.NewMemoryBroker()
+	dispatcher := newTestDispatcher(t, server.URL, store)
+	err := dispatcher.Start(broker)
+	if err != nil {
+		t.Fatalf("Failed to start dispatcher: %s", err.Error())
+	}
+	// the first event is held up being saved, so the rest wait in the buffer
+	for i := 1; i <= 5; i++ {
+		broker.Publish(events.AuctionClosed{Header: events.Header{ID: id_generator.EventID(i)}})
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		dispatcher.Stop()
+		close(stopped)
+	}()
+	close(store.release)
+	<-stopped
+
+	pending, _ := store.GetPending()
+	deadLetters, _ := store.GetDeadLetters()
+	if len(pending)+len(deadLetters) != 5 {
+		t.Fatalf("Expected all 5 events to be saved, got %d pending and %d dead lettered", len(pending), len(deadLetters))
+	}
+}
+
+// outageStorage rejects saving deliveries while down is set, and counts the deliveries that were saved
+type outageStorage struct {
+	DeliveryStorer
+	mtx   sync.Mutex
+	down  bool
+	saved map[string]bool
+}
+
+func (o *outageStorage) SavePending(delivery Delivery) error {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if o.down {
+		return errors.New("storage is unavailable")
+	}
+	o.saved[delivery.ID] = true
+	return o.DeliveryStorer.SavePending(delivery)
+}
+
+func (o *outageStorage) setDown(down bool) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	o.down = down
+}
+
+func (o *outageStorage) count() int {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	return len(o.saved)
+}
+
+// TestDispatcherStoreOutage publishes more events than the subscription buffers while the store is down, which must
+// not block the publisher
+func TestDispatcherStoreOutage(t *testing.T) {
+	endpoint := &mockEndpoint{t: t}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	store := &outageStorage{DeliveryStorer: NewMemoryDeliveryStorage(), down: true, saved: map[string]bool{}}
+	broker := events.NewMemoryBroker()
+	dispatcher := newTestDispatcher(t, server.URL, store)
+	err := dispatcher.Start(broker)
+	if err != nil {
+		t.Fatalf("Failed to start dispatcher: %s", err.Error())
+	}
+	defer dispatcher.Stop()
+
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for i := 1; i <= 4*subscriptionBuffer; i++ {
+			broker.Publish(events.AuctionClosed{Header: events.Header{ID: id_generator.EventID(i)}})
+		}
+	}()
+	select {
+	case <-published:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Publishing blocked while the store was down")
+	}
+
+	store.setDown(false)
+	waitFor(t, func() bool {
+		return store.count() == 4*subscriptionBuffer
+	})
+}
+
+func TestDispatcherEncodingError(t *testing.T) {
+	endpoint := &mockEndpoint{t: t}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	store := NewMemoryDeliveryStorage()
+	broker := events.NewMemoryBroker()
+	dispatcher := newTestDispatcher(t, server.URL, store)
+	err := dispatcher.Start(broker)
+	if err != nil {
+		t.Fatalf("Failed to start dispatcher: %s", err.Error())
+	}
+	defer dispatcher.Stop()
+
+	// JSON cannot represent a time after the year 9999
+	broker.Publish(events.AuctionClosed{Header: events.Header{ID: 1, Time: time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)}})
+	var deadLetters []Delivery
+	waitFor(t, func() bool {
+		deadLetters, _ = store.GetDeadLetters()
+		return len(deadLetters) == 1
+	})
+	if deadLetters[0].EventID != 1 || deadLetters[0].LastError == "" {
+		t.Fatalf("Expected the event to be dead lettered with the encoding error, got %#v", deadLetters[0])
+	}
+
+	// redelivering it still cannot send a payload that was never encoded
+	err = dispatcher.Redeliver(deadLetters[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to redeliver: %s", err.Error())
+	}
+	waitFor(t, func() bool {
+		deadLetters, _ = store.GetDeadLetters()
+		return len(deadLetters) == 1
+	})
+	if _, requests := endpoint.received(); requests != 0 {
+		t.Fatalf("Expected no requests for an event without a payload, got %d", requests)
+	}
+}
+
+func TestDispatcherStartTwice(t *testing.T) {
+	dispatcher := newTestDispatcher(t, "http://localhost", NewMemoryDeliveryStorage())
+	broker := events.NewMemoryBroker()
+	err := dispatcher.Start(broker)
+	if err != nil {
+		t.Fatalf("Failed to start dispatcher: %s", err.Error())
+	}
+	defer dispatcher.Stop()
+
+	err = dispatcher.Start(broker)
+	if _, ok := err.(*DispatcherRunningError); !ok {
+		t.Fatalf("Expected a dispatcher running error and received a different error instead: %v", err)
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	configs := map[string]Config{
+		"Missing URL":        {Endpoints: []Endpoint{{Name: "partner"}}},
+		"Duplicate Endpoint": {Endpoints: []Endpoint{{Name: "partner", URL: "http://a"}, {Name: "partner", URL: "http://b"}}},
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewDispatcher(config, NewMemoryDeliveryStorage())
+			if _, ok := err.(*InvalidConfigError); !ok {
+				t.Fatalf("Expected an invalid config error and received a different error instead: %v", err)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	dispatcher := &httpDispatcher{config: Config{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}}
+	type testCase struct {
+		attempts int
+		expected time.Duration
+	}
+	testCases := []testCase{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{50, 10 * time.Second},
+	}
+	for _, test := range testCases {
+		if backoff := dispatcher.backoff(test.attempts); backoff != test.expected {
+			t.Fatalf("Expected backoff after %d attempts to be %s, got %s", test.attempts, test.expected, backoff)
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	signature := Sign(mockSecret, "100", body)
+	if !Verify(mockSecret, "100", body, signature) {
+		t.Fatalf("Expected signature to verify")
+	}
+	if Verify(mockSecret, "101", body, signature) {
+		t.Fatalf("Expected signature with a different timestamp to fail")
+	}
+	if Verify([]byte("wrong"), "100", body, signature) {
+		t.Fatalf("Expected signature with a different secret to fail")
+	}
+}
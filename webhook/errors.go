@@ -0,0 +1,53 @@
+package webhook
+
+import "fmt"
+
+type DeliveryNotFoundError struct {
+	id string
+}
+
+func (e *DeliveryNotFoundError) Error() string {
+	return fmt.Sprintf("delivery %s not found", e.id)
+}
+
+type EndpointNotFoundError struct {
+	name string
+}
+
+func (e *EndpointNotFoundError) Error() string {
+	return fmt.Sprintf("endpoint %s is not configured", e.name)
+}
+
+type InvalidConfigError struct {
+	message string
+}
+
+func (e *InvalidConfigError) Error() string {
+	return e.message
+}
+
+type DispatcherRunningError struct {
+}
+
+func (e *DispatcherRunningError) Error() string {
+	return "dispatcher has already been started"
+}
+
+type UnexpectedStatusError struct {
+	status int
+}
+
+func (e *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("endpoint responded with status %d", e.status)
+}
+
+type EncodingError struct {
+	err error
+}
+
+func (e *EncodingError) Error() string {
+	if e.err == nil {
+		return "delivery has no payload"
+	}
+	return fmt.Sprintf("failed to encode event: %s", e.err.Error())
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"sort"
+	"sync"
+)
+
+type memoryDeliveryStorage struct {
+	pending     map[string]Delivery
+	deadLetters map[string]Delivery
+	mtx         *sync.Mutex
+}
+
+func NewMemoryDeliveryStorage() DeliveryStorer {
+	return &memoryDeliveryStorage{
+		pending:     map[string]Delivery{},
+		deadLetters: map[string]Delivery{},
+		mtx:         &sync.Mutex{},
+	}
+}
+
+func (m memoryDeliveryStorage) SavePending(delivery Delivery) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.pending[delivery.ID] = delivery
+	return nil
+}
+
+func (m memoryDeliveryStorage) GetPending() ([]Delivery, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return sortedDeliveries(m.pending), nil
+}
+
+func (m memoryDeliveryStorage) DeletePending(id string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.pending[id]; !ok {
+		return &DeliveryNotFoundError{id: id}
+	}
+	delete(m.pending, id)
+	return nil
+}
+
+func (m memoryDeliveryStorage) SaveDeadLetter(delivery Delivery) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.deadLetters[delivery.ID] = delivery
+	return nil
+}
+
+func (m memoryDeliveryStorage) GetDeadLetters() ([]Delivery, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return sortedDeliveries(m.deadLetters), nil
+}
+
+func (m memoryDeliveryStorage) DeleteDeadLetter(id string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.deadLetters[id]; !ok {
+		return &DeliveryNotFoundError{id: id}
+	}
+	delete(m.deadLetters, id)
+	return nil
+}
+
+func sortedDeliveries(deliveries map[string]Delivery) []Delivery {
+	sorted := make([]Delivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		sorted = append(sorted, delivery)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].EventID != sorted[j].EventID {
+			return sorted[i].EventID < sorted[j].EventID
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
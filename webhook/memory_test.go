@@ -0,0 +1,17 @@
+package webhook
+
+import "testing"
+
+func WithMemoryDeliveryStorage() func() DeliveryStorer {
+	return func() DeliveryStorer {
+		return NewMemoryDeliveryStorage()
+	}
+}
+
+func Test(t *testing.T) {
+	tests := storageTests{
+		storeFn: WithMemoryDeliveryStorage(),
+		t:       t,
+	}
+	tests.Run()
+}
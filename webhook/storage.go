@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"auction/auction"
+	"auction/events"
+	"auction/id_generator"
+	"time"
+)
+
+// Delivery is a single event that needs to be sent to a single endpoint. The endpoint is stored by name so that its
+// secret is never persisted alongside the payload. The ID is random, as event IDs are only unique within an auction and
+// a manager started with a new generator reuses them.
+type Delivery struct {
+	ID          string
+	EventID     id_generator.EventID
+	Auction     auction.ID
+	Kind        events.Kind
+	Endpoint    string
+	Payload     []byte
+	Attempts    int
+	NextAttempt time.Time
+	LastError   string
+}
+
+type DeliveryStorer interface {
+	// SavePending creates or replaces a delivery that still needs to be sent
+	SavePending(delivery Delivery) error
+	// GetPending returns the pending deliveries ordered by event ID
+	GetPending() ([]Delivery, error)
+	DeletePending(id string) error
+	// SaveDeadLetter stores a delivery that will no longer be retried
+	SaveDeadLetter(delivery Delivery) error
+	// GetDeadLetters returns the dead lettered deliveries ordered by event ID
+	GetDeadLetters() ([]Delivery, error)
+	DeleteDeadLetter(id string) error
+}
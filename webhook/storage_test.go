@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"auction/events"
+	"auction/id_generator"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type storageTests struct {
+	storeFn func() DeliveryStorer
+	t       *testing.T
+}
+
+func (g *storageTests) Run() {
+	tests := map[string]func(t *testing.T, store DeliveryStorer){
+		"Test Pending":            testPending,
+		"Test Pending Ordering":   testPendingOrdering,
+		"Test Dead Letters":       testDeadLetters,
+		"Test Delivery Not Found": testDeliveryNotFound,
+	}
+	for name, test := range tests {
+		g.t.Run(name, func(t *testing.T) {
+			test(t, g.storeFn())
+		})
+	}
+}
+
+func mockDelivery(id string, eventID int) Delivery {
+	return Delivery{
+		ID:          id,
+		EventID:     id_generator.EventID(eventID),
+		Kind:        events.KindBidderOutbid,
+		Endpoint:    "mockEndpoint",
+		Payload:     []byte(`{"id":1}`),
+		NextAttempt: time.Unix(100, 0),
+	}
+}
+
+func testPending(t *testing.T, store DeliveryStorer) {
+	delivery := mockDelivery("1-mockEndpoint", 1)
+	err := store.SavePending(delivery)
+	if err != nil {
+		t.Fatalf("Failed to save delivery: %s", err.Error())
+	}
+
+	delivery.Attempts = 2
+	delivery.LastError = "mock error"
+	err = store.SavePending(delivery)
+	if err != nil {
+		t.Fatalf("Failed to update delivery: %s", err.Error())
+	}
+
+	pending, err := store.GetPending()
+	if err != nil {
+		t.Fatalf("Failed to get pending deliveries: %s", err.Error())
+	}
+	if !reflect.DeepEqual([]Delivery{delivery}, pending) {
+		t.Fatalf("Deliveries do not match. Expected:\n%#v\nGot:\n%#v", []Delivery{delivery}, pending)
+	}
+
+	err = store.DeletePending(delivery.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete delivery: %s", err.Error())
+	}
+	pending, err = store.GetPending()
+	if err != nil {
+		t.Fatalf("Failed to get pending deliveries: %s", err.Error())
+	}
+	if len(pending) != 0 {
+		t.Fatalf("Expected no pending deliveries, got %d", len(pending))
+	}
+}
+
+func testPendingOrdering(t *testing.T, store DeliveryStorer) {
+	expected := []Delivery{mockDelivery("1-a", 1), mockDelivery("1-b", 1), mockDelivery("2-a", 2)}
+	for _, i := range []int{2, 0, 1} {
+		err := store.SavePending(expected[i])
+		if err != nil {
+			t.Fatalf("Failed to save delivery: %s", err.Error())
+		}
+	}
+
+	pending, err := store.GetPending()
+	if err != nil {
+		t.Fatalf("Failed to get pending deliveries: %s", err.Error())
+	}
+	if !reflect.DeepEqual(expected, pending) {
+		t.Fatalf("Deliveries do not match. Expected:\n%#v\nGot:\n%#v", expected, pending)
+	}
+}
+
+func testDeadLetters(t *testing.T, store DeliveryStorer) {
+	delivery := mockDelivery("1-mockEndpoint", 1)
+	err := store.SaveDeadLetter(delivery)
+	if err != nil {
+		t.Fatalf("Failed to save dead letter: %s", err.Error())
+	}
+
+	deadLetters, err := store.GetDeadLetters()
+	if err != nil {
+		t.Fatalf("Failed to get dead letters: %s", err.Error())
+	}
+	if !reflect.DeepEqual([]Delivery{delivery}, deadLetters) {
+		t.Fatalf("Deliveries do not match. Expected:\n%#v\nGot:\n%#v", []Delivery{delivery}, deadLetters)
+	}
+
+	pending, err := store.GetPending()
+	if err != nil {
+		t.Fatalf("Failed to get pending deliveries: %s", err.Error())
+	}
+	if len(pending) != 0 {
+		t.Fatalf("Expected dead letters to not be pending, got %d", len(pending))
+	}
+
+	err = store.DeleteDeadLetter(delivery.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete dead letter: %s", err.Error())
+	}
+}
+
+func testDeliveryNotFound(t *testing.T, store DeliveryStorer) {
+	err := store.DeletePending("missing")
+	if _, ok := err.(*DeliveryNotFoundError); !ok {
+		t.Fatalf("Expected a delivery not found error and received a different error instead: %v", err)
+	}
+	err = store.DeleteDeadLetter("missing")
+	if _, ok := err.(*DeliveryNotFoundError); !ok {
+		t.Fatalf("Expected a delivery not found error and received a different error instead: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"auction/events"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Auction-Signature"
+	TimestampHeader = "X-Auction-Timestamp"
+	EventHeader     = "X-Auction-Event"
+	DeliveryHeader  = "X-Auction-Delivery"
+)
+
+type Dispatcher interface {
+	// Start subscribes to the broker and delivers events until Stop is called. Pending deliveries left over from a
+	// previous run are retried.
+	Start(broker events.Broker) error
+	// Stop unsubscribes from the broker, saves the events it has received but not yet saved, and waits for the current
+	// delivery attempt to finish
+	Stop()
+	// Redeliver moves a dead lettered delivery back to pending so that it is retried
+	Redeliver(id string) error
+}
+
+// Endpoint is a partner URL that events are posted to. If Kinds is empty, every event is sent.
+type Endpoint struct {
+	Name   string
+	URL    string
+	Secret []byte
+	Kinds  []events.Kind
+}
+
+// Config configures the dispatcher. Zero values are replaced by the defaults below.
+type Config struct {
+	Endpoints []Endpoint
+	// MaxAttempts is the number of attempts before a delivery is dead lettered
+	MaxAttempts int
+	// InitialBackoff is the wait after the first failed attempt. It doubles after each attempt up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryInterval is how often pending deliveries are checked for retries
+	RetryInterval time.Duration
+	Client        *http.Client
+}
+
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = time.Minute
+	defaultRetryInterval  = time.Second
+	defaultTimeout        = 10 * time.Second
+)
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp and body. Including the timestamp lets receivers reject
+// old deliveries that are replayed.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature created by Sign in constant time
+func Verify(secret []byte, timestamp string, body []byte, signature string) bool {
+	expected := Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}